@@ -55,6 +55,30 @@ func GetRegion(d *schema.ResourceData, cfg *config.Config) string {
 	return cfg.Region
 }
 
+// GetPeerConfig returns the configuration of the peer account which is assumed by the peer_assume_role, nil is
+// returned if the peer_assume_role is not specified.
+func GetPeerConfig(d *schema.ResourceData, cfg *config.Config) (*config.Config, error) {
+	roles := d.Get("peer_assume_role").([]interface{})
+	if len(roles) == 0 || roles[0] == nil {
+		return nil, nil
+	}
+
+	role := roles[0].(map[string]interface{})
+	if role["domain_name"].(string) == "" && role["domain_id"].(string) == "" {
+		return nil, fmt.Errorf("one of domain_name and domain_id must be specified in peer_assume_role")
+	}
+	peerCfg, err := cfg.NewConfigByAssumeRole(config.AssumeRole{
+		RoleAgency:   role["agency_name"].(string),
+		RoleDomain:   role["domain_name"].(string),
+		RoleDomainID: role["domain_id"].(string),
+		RoleDuration: role["duration"].(int),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error assuming the agency (%s) of the peer account: %s", role["agency_name"], err)
+	}
+	return peerCfg, nil
+}
+
 // GetEipIDbyAddress returns the EIP ID of address when success.
 func GetEipIDbyAddress(client *golangsdk.ServiceClient, address, epsID string) (string, error) {
 	listOpts := &eips.ListOpts{
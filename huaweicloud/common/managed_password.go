@@ -0,0 +1,294 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/csms/v1/secrets"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const (
+	managedPasswordLength = 16
+	// The stage of the secret version which is created but not yet set on the instance.
+	managedPasswordPendingStage = "SYSPENDING"
+	managedPasswordCurrentStage = "SYSCURRENT"
+	// The description of the secret created by the provider, which is used to identify the secret left by a failed
+	// creation of the instance.
+	managedPasswordSecretDescription = "The master password managed by Terraform"
+)
+
+// ResetManagedPasswordFunc is the function to set the new master password on the instance.
+type ResetManagedPasswordFunc func(password string) error
+
+type managedPasswordGetter interface {
+	Get(key string) interface{}
+}
+
+// IsManagedMasterPassword checks whether the master password is generated by the provider and stored in CSMS.
+func IsManagedMasterPassword(d managedPasswordGetter) bool {
+	return len(d.Get("manage_master_password").([]interface{})) > 0
+}
+
+// isManagedMasterPasswordRotationDue checks whether the rotation period has passed since the last password generation.
+func isManagedMasterPasswordRotationDue(d managedPasswordGetter) bool {
+	rotationDays := d.Get("manage_master_password.0.rotation_days").(int)
+	rotatedAt := d.Get("manage_master_password.0.rotated_at").(string)
+	if rotationDays == 0 || rotatedAt == "" {
+		return false
+	}
+
+	lastRotated, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return false
+	}
+	return time.Now().After(lastRotated.AddDate(0, 0, rotationDays))
+}
+
+// CheckManagedMasterPasswordRotation is a CustomizeDiff function which plans a new secret version of the master
+// password when the rotation period has passed.
+func CheckManagedMasterPasswordRotation(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !IsManagedMasterPassword(d) {
+		return nil
+	}
+	if d.HasChange("manage_master_password") || isManagedMasterPasswordRotationDue(d) {
+		return d.SetNewComputed("master_password_version_id")
+	}
+	return nil
+}
+
+func setManagedMasterPassword(d *schema.ResourceData, secretName, secretId, versionId string) error {
+	managedPassword := []interface{}{
+		map[string]interface{}{
+			"secret_name":   secretName,
+			"rotation_days": d.Get("manage_master_password.0.rotation_days"),
+			"secret_id":     secretId,
+			"rotated_at":    time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+	if err := d.Set("manage_master_password", managedPassword); err != nil {
+		return err
+	}
+	return d.Set("master_password_version_id", versionId)
+}
+
+// CreateManagedMasterPassword generates a master password and stores it in a new CSMS secret before the instance is
+// created, the secret is named after the instance if the name is not specified. If the secret created by the provider
+// already exists, e.g. it is left by a failed creation of the instance, the password is stored as a new current version
+// of it instead.
+func CreateManagedMasterPassword(d *schema.ResourceData, cfg *config.Config, region,
+	defaultSecretName string) (string, error) {
+	client, err := cfg.KmsV1Client(region)
+	if err != nil {
+		return "", fmt.Errorf("error creating KMS client: %s", err)
+	}
+
+	secretName := d.Get("manage_master_password.0.secret_name").(string)
+	if secretName == "" {
+		secretName = defaultSecretName
+	}
+	password := utils.GenerateStrongPassword(managedPasswordLength)
+
+	secret, err := secrets.Get(client, secretName)
+	if err == nil {
+		if secret.Description != managedPasswordSecretDescription {
+			return "", fmt.Errorf("the CSMS secret (%s) already exists and is not created for the master password, "+
+				"please specify another secret_name", secretName)
+		}
+		version, err := secrets.CreateSecretVersion(client, secretName, secrets.CreateVersionOpts{
+			SecretString:  password,
+			VersionStages: []string{managedPasswordCurrentStage},
+		})
+		if err != nil {
+			return "", fmt.Errorf("error creating version of CSMS secret (%s): %s", secretName, err)
+		}
+		if err = setManagedMasterPassword(d, secretName, secret.ID, version.ID); err != nil {
+			return "", err
+		}
+		return password, nil
+	}
+	if _, ok := err.(golangsdk.ErrDefault404); !ok {
+		return "", fmt.Errorf("error retrieving CSMS secret (%s): %s", secretName, err)
+	}
+
+	secret, err = secrets.Create(client, secrets.CreateSecretOpts{
+		Name:                secretName,
+		Description:         managedPasswordSecretDescription,
+		EnterpriseProjectID: cfg.GetEnterpriseProjectID(d),
+		SecretString:        password,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating CSMS secret (%s) for the master password: %s", secretName, err)
+	}
+
+	versions, err := secrets.ListSecretVersions(client, secretName)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving versions of CSMS secret (%s): %s", secretName, err)
+	}
+	versionId := ""
+	if len(versions) > 0 {
+		versionId = versions[0].ID
+	}
+
+	if err = setManagedMasterPassword(d, secretName, secret.ID, versionId); err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
+// RotateManagedMasterPassword generates a new master password and sets it on the instance. The new password is stored
+// as a pending secret version first, and it becomes the current version after it is set on the instance, so that the
+// password in use can always be retrieved from CSMS.
+func RotateManagedMasterPassword(d *schema.ResourceData, cfg *config.Config, region string,
+	resetFunc ResetManagedPasswordFunc) error {
+	client, err := cfg.KmsV1Client(region)
+	if err != nil {
+		return fmt.Errorf("error creating KMS client: %s", err)
+	}
+
+	var (
+		secretName = d.Get("manage_master_password.0.secret_name").(string)
+		secretId   = d.Get("manage_master_password.0.secret_id").(string)
+		password   = utils.GenerateStrongPassword(managedPasswordLength)
+	)
+	version, err := secrets.CreateSecretVersion(client, secretName, secrets.CreateVersionOpts{
+		SecretString:  password,
+		VersionStages: []string{managedPasswordPendingStage},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating version of CSMS secret (%s): %s", secretName, err)
+	}
+
+	if err = resetFunc(password); err != nil {
+		return err
+	}
+
+	if err = updateSecretVersionStage(client, secretName, managedPasswordCurrentStage, version.ID); err != nil {
+		return fmt.Errorf("the master password has been reset, but the secret version (%s) can not be marked as "+
+			"current, please retrieve the password from the %s stage of CSMS secret (%s): %s", version.ID,
+			managedPasswordPendingStage, secretName, err)
+	}
+	return setManagedMasterPassword(d, secretName, secretId, version.ID)
+}
+
+func updateSecretVersionStage(client *golangsdk.ServiceClient, secretName, stageName, versionId string) error {
+	httpUrl := "v1/{project_id}/secrets/{secret_name}/stages/{stage_name}"
+	updatePath := client.Endpoint + httpUrl
+	updatePath = strings.ReplaceAll(updatePath, "{project_id}", client.ProjectID)
+	updatePath = strings.ReplaceAll(updatePath, "{secret_name}", secretName)
+	updatePath = strings.ReplaceAll(updatePath, "{stage_name}", stageName)
+
+	updateOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+		JSONBody: map[string]interface{}{
+			"version_id": versionId,
+		},
+	}
+	_, err := client.Request("PUT", updatePath, &updateOpt)
+	return err
+}
+
+// UpdateManagedMasterPassword stores the master password in a new CSMS secret when the managed mode is enabled, and
+// rotates the password when the rotation period has passed.
+func UpdateManagedMasterPassword(d *schema.ResourceData, cfg *config.Config, region, defaultSecretName string,
+	resetFunc ResetManagedPasswordFunc) error {
+	if !IsManagedMasterPassword(d) {
+		return nil
+	}
+
+	oldVal, _ := d.GetChange("manage_master_password")
+	if len(oldVal.([]interface{})) == 0 {
+		password, err := CreateManagedMasterPassword(d, cfg, region, defaultSecretName)
+		if err != nil {
+			return err
+		}
+		return resetFunc(password)
+	}
+
+	if d.HasChange("manage_master_password.0.secret_name") {
+		oldName, newName := d.GetChange("manage_master_password.0.secret_name")
+		if oldName.(string) != "" && newName.(string) != "" {
+			return fmt.Errorf("the secret name of the master password can not be changed from %s to %s", oldName,
+				newName)
+		}
+	}
+
+	if isManagedMasterPasswordRotationDue(d) {
+		return RotateManagedMasterPassword(d, cfg, region, resetFunc)
+	}
+	return nil
+}
+
+// getManagedPasswordCurrentVersionId returns the ID of the secret version in the current stage.
+func getManagedPasswordCurrentVersionId(client *golangsdk.ServiceClient, secretName string) (string, error) {
+	versions, err := secrets.ListSecretVersions(client, secretName)
+	if err != nil {
+		return "", err
+	}
+	for _, version := range versions {
+		if utils.StrSliceContains(version.VersionStages, managedPasswordCurrentStage) {
+			return version.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// DeleteManagedMasterPassword deletes the CSMS secret of the managed master password after the instance is deleted.
+// The secret is deleted only if it still belongs to the instance, that is, it is the secret recorded in the state and
+// its current version is the one set on the instance. Otherwise, e.g. the secret has been recreated or reused by
+// another instance, it is kept.
+func DeleteManagedMasterPassword(d *schema.ResourceData, cfg *config.Config, region string) error {
+	if !IsManagedMasterPassword(d) {
+		return nil
+	}
+
+	client, err := cfg.KmsV1Client(region)
+	if err != nil {
+		return fmt.Errorf("error creating KMS client: %s", err)
+	}
+
+	var (
+		secretName = d.Get("manage_master_password.0.secret_name").(string)
+		secretId   = d.Get("manage_master_password.0.secret_id").(string)
+		versionId  = d.Get("master_password_version_id").(string)
+	)
+	secret, err := secrets.Get(client, secretName)
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("error retrieving CSMS secret (%s) of the master password: %s", secretName, err)
+	}
+	if secretId == "" || secret.ID != secretId {
+		log.Printf("[WARN] the CSMS secret (%s) is not the one created for the instance (%s), skip deleting it",
+			secretName, d.Id())
+		return nil
+	}
+
+	currentVersionId, err := getManagedPasswordCurrentVersionId(client, secretName)
+	if err != nil {
+		return fmt.Errorf("error retrieving versions of CSMS secret (%s): %s", secretName, err)
+	}
+	if versionId == "" || currentVersionId != versionId {
+		log.Printf("[WARN] the current version (%s) of CSMS secret (%s) is not set on the instance (%s), skip "+
+			"deleting it", currentVersionId, secretName, d.Id())
+		return nil
+	}
+
+	if err = secrets.Delete(client, secretName); err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("error deleting CSMS secret (%s) of the master password: %s", secretName, err)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package common
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/chnsz/golangsdk"
+)
+
+// ParameterDefinition is the definition of a database parameter in the parameter catalog of an engine version.
+type ParameterDefinition struct {
+	Name            string
+	Type            string
+	ValueRange      string
+	RestartRequired bool
+	Readonly        bool
+}
+
+// ParameterCatalog is the collection of the parameter definitions of an engine version, the key is the parameter name.
+type ParameterCatalog map[string]ParameterDefinition
+
+// ParameterCatalogFetchFunc is the function to query the parameter catalog from the cloud service.
+type ParameterCatalogFetchFunc func() (ParameterCatalog, error)
+
+var (
+	parameterCatalogs     = make(map[string]ParameterCatalog)
+	parameterCatalogMutex sync.Mutex
+
+	parameterRangeRegex = regexp.MustCompile(`^(-?\d+(?:\.\d+)?)\s*-\s*(-?\d+(?:\.\d+)?)$`)
+)
+
+// GetParameterCatalog returns the parameter catalog of the engine version. The catalog is fetched only once per run
+// for each service endpoint, project, engine and version, and the cached one is returned for the later plans.
+func GetParameterCatalog(client *golangsdk.ServiceClient, engine, version string,
+	fetchFunc ParameterCatalogFetchFunc) (ParameterCatalog, error) {
+	key := strings.Join([]string{client.Endpoint, client.ProjectID, strings.ToLower(engine), version}, "/")
+
+	parameterCatalogMutex.Lock()
+	defer parameterCatalogMutex.Unlock()
+
+	if catalog, ok := parameterCatalogs[key]; ok {
+		return catalog, nil
+	}
+	catalog, err := fetchFunc()
+	if err != nil {
+		return nil, err
+	}
+	if len(catalog) == 0 {
+		return nil, fmt.Errorf("no parameter is found for the engine %s (%s)", engine, version)
+	}
+	parameterCatalogs[key] = catalog
+	return catalog, nil
+}
+
+func isNumericParameterType(paramType string) bool {
+	switch paramType {
+	case "integer", "int", "float", "real", "double", "numeric", "number":
+		return true
+	}
+	return false
+}
+
+func isEnumParameterType(paramType string) bool {
+	switch paramType {
+	case "boolean", "bool", "enum", "list":
+		return true
+	}
+	return false
+}
+
+func splitParameterValueRange(valueRange string) []string {
+	sep := ","
+	if strings.Contains(valueRange, "|") {
+		sep = "|"
+	}
+
+	result := make([]string, 0)
+	for _, v := range strings.Split(valueRange, sep) {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// isNumberInValueRange checks whether the number matches one of the ranges (such as '1-100') or the discrete values.
+// The ranges which can not be parsed are treated as unlimited.
+func isNumberInValueRange(number float64, valueRange string) bool {
+	parsed := false
+	for _, v := range splitParameterValueRange(valueRange) {
+		if matches := parameterRangeRegex.FindStringSubmatch(v); len(matches) == 3 {
+			minVal, minErr := strconv.ParseFloat(matches[1], 64)
+			maxVal, maxErr := strconv.ParseFloat(matches[2], 64)
+			if minErr != nil || maxErr != nil {
+				continue
+			}
+			parsed = true
+			if number >= minVal && number <= maxVal {
+				return true
+			}
+			continue
+		}
+		if discrete, err := strconv.ParseFloat(v, 64); err == nil {
+			parsed = true
+			if number == discrete {
+				return true
+			}
+		}
+	}
+	return !parsed
+}
+
+// ValidateParameterValue checks whether the value matches the type and the value range of the parameter definition.
+// The values of the string parameters are not checked because their value ranges are descriptions.
+func ValidateParameterValue(definition ParameterDefinition, value string) error {
+	paramType := strings.ToLower(definition.Type)
+	valueRange := strings.TrimSpace(definition.ValueRange)
+
+	if isNumericParameterType(paramType) {
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("the value (%s) of parameter %s must be a number", value, definition.Name)
+		}
+		if strings.HasPrefix(paramType, "int") && number != math.Trunc(number) {
+			return fmt.Errorf("the value (%s) of parameter %s must be an integer", value, definition.Name)
+		}
+		if valueRange != "" && !isNumberInValueRange(number, valueRange) {
+			return fmt.Errorf("the value (%s) of parameter %s is out of range, the valid range is %s",
+				value, definition.Name, valueRange)
+		}
+		return nil
+	}
+
+	if !isEnumParameterType(paramType) || valueRange == "" {
+		return nil
+	}
+	values := []string{value}
+	if paramType == "list" {
+		values = strings.Split(value, ",")
+	}
+	options := splitParameterValueRange(valueRange)
+	for _, v := range values {
+		found := false
+		for _, option := range options {
+			if strings.EqualFold(strings.TrimSpace(v), option) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("the value (%s) of parameter %s is invalid, the valid values are %s",
+				value, definition.Name, valueRange)
+		}
+	}
+	return nil
+}
+
+// ValidateParameters validates the parameters against the parameter catalog and returns the names of the parameters
+// which require a restart of the instance to take effect.
+func ValidateParameters(catalog ParameterCatalog, params map[string]string) ([]string, error) {
+	if len(catalog) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		mErr         *multierror.Error
+		restartNames = make([]string, 0)
+	)
+	for _, name := range names {
+		definition, ok := catalog[name]
+		if !ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("parameter %s is not supported by the engine version", name))
+			continue
+		}
+		if definition.Readonly {
+			mErr = multierror.Append(mErr, fmt.Errorf("parameter %s is read-only", name))
+			continue
+		}
+		if err := ValidateParameterValue(definition, params[name]); err != nil {
+			mErr = multierror.Append(mErr, err)
+			continue
+		}
+		if definition.RestartRequired {
+			restartNames = append(restartNames, name)
+		}
+	}
+	return restartNames, mErr.ErrorOrNil()
+}
+
+// GetChangedParameters returns the parameters whose values are added or changed in the new parameter map.
+func GetChangedParameters(oldParams, newParams map[string]interface{}) map[string]string {
+	result := make(map[string]string)
+	for name, value := range newParams {
+		if oldValue, ok := oldParams[name]; !ok || oldValue != value {
+			result[name] = fmt.Sprint(value)
+		}
+	}
+	return result
+}
+
+// BuildParameterRestartWarning returns the warning of the parameters which require a restart of the instances to
+// take effect, the restart resource is recommended to restart the instances.
+func BuildParameterRestartWarning(restartNames []string, restartResource string) diag.Diagnostics {
+	if len(restartNames) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Parameters Changed",
+			Detail: fmt.Sprintf("Parameters %v changed which needs reboot, please restart the instances by the "+
+				"%s resource to make them take effect.", restartNames, restartResource),
+		},
+	}
+}
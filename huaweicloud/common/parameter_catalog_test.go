@@ -0,0 +1,85 @@
+package common_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+)
+
+func TestParameterCatalogFunc_ValidateParameterValue(t *testing.T) {
+	var (
+		validCases = map[string]common.ParameterDefinition{
+			"100":                         {Name: "max_connections", Type: "integer", ValueRange: "10-100000"},
+			"-1":                          {Name: "lock_timeout", Type: "integer", ValueRange: "-1-3600"},
+			"0.5":                         {Name: "ratio", Type: "float", ValueRange: "0-1"},
+			"3":                           {Name: "level", Type: "integer", ValueRange: "1,3,5"},
+			"OFF":                         {Name: "autocommit", Type: "boolean", ValueRange: "ON|OFF"},
+			"STRICT_TRANS_TABLES,ANSI":    {Name: "sql_mode", Type: "list", ValueRange: "STRICT_TRANS_TABLES,ANSI,TRADITIONAL"},
+			"any string is accepted here": {Name: "time_zone", Type: "string", ValueRange: "a string of time zone"},
+		}
+		invalidCases = map[string]common.ParameterDefinition{
+			"100001":    {Name: "max_connections", Type: "integer", ValueRange: "10-100000"},
+			"abc":       {Name: "max_connections", Type: "integer", ValueRange: "10-100000"},
+			"1.5":       {Name: "max_connections", Type: "integer", ValueRange: "10-100000"},
+			"4":         {Name: "level", Type: "integer", ValueRange: "1,3,5"},
+			"yes":       {Name: "autocommit", Type: "boolean", ValueRange: "ON|OFF"},
+			"ANSI,NONE": {Name: "sql_mode", Type: "list", ValueRange: "STRICT_TRANS_TABLES,ANSI,TRADITIONAL"},
+		}
+	)
+
+	for value, definition := range validCases {
+		if err := common.ValidateParameterValue(definition, value); err != nil {
+			t.Fatalf("The value (%s) of parameter %s is expected to be valid, but got an error: %s",
+				value, definition.Name, err)
+		}
+	}
+	for value, definition := range invalidCases {
+		if err := common.ValidateParameterValue(definition, value); err == nil {
+			t.Fatalf("The value (%s) of parameter %s is expected to be invalid, but no error is returned",
+				value, definition.Name)
+		}
+	}
+}
+
+func TestParameterCatalogFunc_ValidateParameters(t *testing.T) {
+	catalog := common.ParameterCatalog{
+		"max_connections": {Name: "max_connections", Type: "integer", ValueRange: "10-100000", RestartRequired: true},
+		"autocommit":      {Name: "autocommit", Type: "boolean", ValueRange: "ON|OFF"},
+		"version":         {Name: "version", Type: "string", Readonly: true},
+	}
+
+	// Step1: Check whether the function returns the names of the parameters which require a restart.
+	restartNames, err := common.ValidateParameters(catalog, map[string]string{
+		"max_connections": "200",
+		"autocommit":      "ON",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s", err)
+	}
+	if !reflect.DeepEqual(restartNames, []string{"max_connections"}) {
+		t.Fatalf("The parameters which require a restart are expected to be [max_connections], but got %v",
+			restartNames)
+	}
+	// Step2: Check whether the function rejects the unknown and the read-only parameters.
+	if _, err = common.ValidateParameters(catalog, map[string]string{"unknown": "1"}); err == nil {
+		t.Fatalf("The unknown parameter is expected to be rejected")
+	}
+	if _, err = common.ValidateParameters(catalog, map[string]string{"version": "1"}); err == nil {
+		t.Fatalf("The read-only parameter is expected to be rejected")
+	}
+	// Step3: Check whether the function skips the validation if the catalog is empty.
+	if _, err = common.ValidateParameters(nil, map[string]string{"unknown": "1"}); err != nil {
+		t.Fatalf("The validation is expected to be skipped for the empty catalog, but got an error: %s", err)
+	}
+}
+
+func TestParameterCatalogFunc_GetChangedParameters(t *testing.T) {
+	changed := common.GetChangedParameters(
+		map[string]interface{}{"a": "1", "b": "2"},
+		map[string]interface{}{"a": "1", "b": "3", "c": "4"},
+	)
+	if !reflect.DeepEqual(changed, map[string]string{"b": "3", "c": "4"}) {
+		t.Fatalf("The changed parameters are expected to be map[b:3 c:4], but got %v", changed)
+	}
+}
@@ -49,6 +49,43 @@ func TagsComputedSchema(description ...string) *schema.Schema {
 	return &schemaObj
 }
 
+// PeerAssumeRoleSchema returns the schema of the agency used to operate the resources of the peer account.
+func PeerAssumeRoleSchema(description ...string) *schema.Schema {
+	schemaObj := schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"agency_name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the agency created by the peer account.",
+				},
+				"domain_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The name of the peer account which creates the agency.",
+				},
+				"domain_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The ID of the peer account which creates the agency, used for the v5 assume role.",
+				},
+				"duration": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Description: "The duration of the temporary credentials, in seconds.",
+				},
+			},
+		},
+	}
+	if len(description) > 0 {
+		schemaObj.Description = description[0]
+	}
+	return &schemaObj
+}
+
 func SchemaChargingMode(conflicts []string) *schema.Schema {
 	resourceSchema := schema.Schema{
 		Type:     schema.TypeString,
@@ -140,3 +177,92 @@ func ValidatePrePaidChargeInfo(d *schema.ResourceData) error {
 	}
 	return nil
 }
+
+// ManageMasterPasswordSchema returns the schema to use for the master password which is generated by the provider and
+// stored in CSMS.
+func ManageMasterPasswordSchema(description ...string) *schema.Schema {
+	schemaObj := schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"secret_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					Description: `The name of the CSMS secret which stores the master password.`,
+				},
+				"rotation_days": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  `The rotation period of the master password, in days.`,
+				},
+				"secret_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: `The ID of the CSMS secret which stores the master password.`,
+				},
+				"rotated_at": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: `The time when the master password was last generated.`,
+				},
+			},
+		},
+	}
+	if len(description) > 0 {
+		schemaObj.Description = description[0]
+	}
+	return &schemaObj
+}
+
+// MasterPasswordVersionSchema returns the schema to use for the CSMS secret version of the managed master password.
+func MasterPasswordVersionSchema(description ...string) *schema.Schema {
+	schemaObj := schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	if len(description) > 0 {
+		schemaObj.Description = description[0]
+	}
+	return &schemaObj
+}
+
+// RestoreFromSchema returns the schema to use for creating a new instance from the backup or the point-in-time of the
+// source instance.
+func RestoreFromSchema(conflicts []string) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ForceNew:      true,
+		MaxItems:      1,
+		ConflictsWith: conflicts,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"source_instance_id": {
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+					Description: `The ID of the source instance to be restored from.`,
+				},
+				"backup_id": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					ExactlyOneOf: []string{"restore_from.0.backup_id", "restore_from.0.restore_time"},
+					Description:  `The ID of the backup to be restored from.`,
+				},
+				"restore_time": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					ValidateFunc: validateRestoreTime,
+					Description:  `The point in time to be restored to, in RFC3339 format or UNIX timestamp in milliseconds.`,
+				},
+			},
+		},
+		Description: `The source of the data from which the new instance is restored.`,
+	}
+}
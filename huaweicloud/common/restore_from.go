@@ -0,0 +1,175 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// RestoreTimeRange is the restorable time window of the instance, the start time and the end time are the UNIX
+// timestamps in milliseconds.
+type RestoreTimeRange struct {
+	StartTime int64
+	EndTime   int64
+}
+
+func (r RestoreTimeRange) String() string {
+	return fmt.Sprintf("[%s, %s]", time.UnixMilli(r.StartTime).UTC().Format(time.RFC3339),
+		time.UnixMilli(r.EndTime).UTC().Format(time.RFC3339))
+}
+
+// ParseRestoreTime parses the restore time in RFC3339 format or the UNIX timestamp in milliseconds.
+func ParseRestoreTime(restoreTime string) (time.Time, error) {
+	if milliseconds, err := strconv.ParseInt(restoreTime, 10, 64); err == nil {
+		return time.UnixMilli(milliseconds), nil
+	}
+	result, err := time.Parse(time.RFC3339, restoreTime)
+	if err != nil {
+		return result, fmt.Errorf("the restore time (%s) must be in RFC3339 format or the UNIX timestamp in "+
+			"milliseconds", restoreTime)
+	}
+	return result, nil
+}
+
+func validateRestoreTime(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := ParseRestoreTime(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q is invalid: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// CheckRestoreTimeInRanges checks whether the restore time is inside one of the restorable time windows.
+func CheckRestoreTimeInRanges(restoreTime time.Time, ranges []RestoreTimeRange) error {
+	milliseconds := restoreTime.UnixMilli()
+	for _, r := range ranges {
+		if milliseconds >= r.StartTime && milliseconds <= r.EndTime {
+			return nil
+		}
+	}
+
+	if len(ranges) == 0 {
+		return fmt.Errorf("the restore time (%s) is not restorable, no restorable time window is found",
+			restoreTime.UTC().Format(time.RFC3339))
+	}
+	windows := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		windows = append(windows, r.String())
+	}
+	return fmt.Errorf("the restore time (%s) is outside the restorable time windows: %s",
+		restoreTime.UTC().Format(time.RFC3339), strings.Join(windows, ", "))
+}
+
+// ListRestoreTimeRanges queries the restorable time windows of the instance on the specified date (yyyy-mm-dd), the
+// rangesKey is the key of the time windows in the response body.
+func ListRestoreTimeRanges(client *golangsdk.ServiceClient, instanceId, date, rangesKey string) ([]RestoreTimeRange,
+	error) {
+	listPath := client.Endpoint + "v3/{project_id}/instances/{instance_id}/restore-time"
+	listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+	listPath = strings.ReplaceAll(listPath, "{instance_id}", instanceId)
+	listPath += fmt.Sprintf("?date=%s", date)
+
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+	}
+	listResp, err := client.Request("GET", listPath, &listOpt)
+	if err != nil {
+		return nil, err
+	}
+	listRespBody, err := utils.FlattenResponse(listResp)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := utils.PathSearch(rangesKey, listRespBody, make([]interface{}, 0)).([]interface{})
+	result := make([]RestoreTimeRange, 0, len(ranges))
+	for _, v := range ranges {
+		result = append(result, RestoreTimeRange{
+			StartTime: int64(utils.PathSearch("start_time", v, float64(0)).(float64)),
+			EndTime:   int64(utils.PathSearch("end_time", v, float64(0)).(float64)),
+		})
+	}
+	return result, nil
+}
+
+// CheckRestoreFromTime returns a CustomizeDiff function which validates the restore time of the restore_from block
+// against the restorable time windows of the source instance. The windows of the day before and after are also
+// queried, because the dates of the API may be in the local time zone. The validation is skipped if the windows are
+// unavailable, such as the source instance is created in the same plan or the API request fails.
+func CheckRestoreFromTime(product, rangesKey string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if !d.HasChange("restore_from") || !d.NewValueKnown("restore_from.0.source_instance_id") ||
+			!d.NewValueKnown("restore_from.0.restore_time") {
+			return nil
+		}
+		rawTime := d.Get("restore_from.0.restore_time").(string)
+		if rawTime == "" {
+			return nil
+		}
+		restoreTime, err := ParseRestoreTime(rawTime)
+		if err != nil {
+			return err
+		}
+
+		cfg := meta.(*config.Config)
+		region := d.Get("region").(string)
+		if region == "" {
+			region = cfg.Region
+		}
+		client, err := cfg.NewServiceClient(product, region)
+		if err != nil {
+			return fmt.Errorf("error creating %s client: %s", product, err)
+		}
+
+		sourceInstanceId := d.Get("restore_from.0.source_instance_id").(string)
+		ranges := make([]RestoreTimeRange, 0)
+		for _, offset := range []int{-1, 0, 1} {
+			date := restoreTime.UTC().AddDate(0, 0, offset).Format("2006-01-02")
+			dateRanges, err := ListRestoreTimeRanges(client, sourceInstanceId, date, rangesKey)
+			if err != nil {
+				log.Printf("[WARN] unable to query the restorable time windows of the instance (%s), skip validating "+
+					"the restore time: %s", sourceInstanceId, err)
+				return nil
+			}
+			ranges = append(ranges, dateRanges...)
+		}
+
+		if err = CheckRestoreTimeInRanges(restoreTime, ranges); err != nil {
+			return fmt.Errorf("invalid restore_from of the source instance (%s): %s", sourceInstanceId, err)
+		}
+		return nil
+	}
+}
+
+// BuildRestorePointBodyParams builds the restore point of the request body which creates a new instance from the
+// restore_from block.
+func BuildRestorePointBodyParams(d *schema.ResourceData) map[string]interface{} {
+	if len(d.Get("restore_from").([]interface{})) == 0 {
+		return nil
+	}
+
+	bodyParams := map[string]interface{}{
+		"instance_id": d.Get("restore_from.0.source_instance_id"),
+	}
+	if backupId := d.Get("restore_from.0.backup_id").(string); backupId != "" {
+		bodyParams["type"] = "backup"
+		bodyParams["backup_id"] = backupId
+		return bodyParams
+	}
+
+	// The format of the restore time has been validated by the schema.
+	restoreTime, _ := ParseRestoreTime(d.Get("restore_from.0.restore_time").(string))
+	bodyParams["type"] = "timestamp"
+	bodyParams["restore_time"] = restoreTime.UnixMilli()
+	return bodyParams
+}
@@ -0,0 +1,57 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+)
+
+func TestRestoreFromFunc_ParseRestoreTime(t *testing.T) {
+	expected := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	validCases := []string{
+		"2024-05-15T10:00:00Z",
+		"2024-05-15T18:00:00+08:00",
+		"1715767200000",
+	}
+	for _, v := range validCases {
+		result, err := common.ParseRestoreTime(v)
+		if err != nil {
+			t.Fatalf("The restore time (%s) is expected to be valid, but got an error: %s", v, err)
+		}
+		if !result.Equal(expected) {
+			t.Fatalf("The restore time (%s) is expected to be %s, but got %s", v, expected, result)
+		}
+	}
+
+	invalidCases := []string{"", "2024-05-15", "2024-05-15 10:00:00"}
+	for _, v := range invalidCases {
+		if _, err := common.ParseRestoreTime(v); err == nil {
+			t.Fatalf("The restore time (%s) is expected to be invalid, but no error is returned", v)
+		}
+	}
+}
+
+func TestRestoreFromFunc_CheckRestoreTimeInRanges(t *testing.T) {
+	ranges := []common.RestoreTimeRange{
+		{StartTime: 1715760000000, EndTime: 1715763600000},
+		{StartTime: 1715767200000, EndTime: 1715770800000},
+	}
+
+	// Step1: Check whether the times inside the windows (including the boundaries) are accepted.
+	for _, v := range []int64{1715760000000, 1715761000000, 1715767200000, 1715770800000} {
+		if err := common.CheckRestoreTimeInRanges(time.UnixMilli(v), ranges); err != nil {
+			t.Fatalf("The restore time (%d) is expected to be restorable, but got an error: %s", v, err)
+		}
+	}
+	// Step2: Check whether the times outside the windows are rejected.
+	for _, v := range []int64{1715759999999, 1715765000000, 1715770800001} {
+		if err := common.CheckRestoreTimeInRanges(time.UnixMilli(v), ranges); err == nil {
+			t.Fatalf("The restore time (%d) is expected to be rejected, but no error is returned", v)
+		}
+	}
+	// Step3: Check whether all times are rejected if no window is found.
+	if err := common.CheckRestoreTimeInRanges(time.UnixMilli(1715761000000), nil); err == nil {
+		t.Fatalf("The restore time is expected to be rejected without the restorable windows")
+	}
+}
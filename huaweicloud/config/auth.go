@@ -11,6 +11,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/chnsz/golangsdk"
@@ -424,6 +425,9 @@ func getTemporaryAKSKByAgency(c *Config, role AssumeRole) error {
 	request := &iam_model.CreateTemporaryAccessKeyByAgencyRequest{}
 	domainNameAssumeRoleIdentityAssumerole := role.RoleDomain
 	durationSecondsAssumeRoleIdentityAssumerole := assumeRoleDuration
+	if role.RoleDuration != 0 {
+		durationSecondsAssumeRoleIdentityAssumerole = int32(role.RoleDuration)
+	}
 	assumeRoleIdentity := &iam_model.IdentityAssumerole{
 		AgencyName:      role.RoleAgency,
 		DomainName:      &domainNameAssumeRoleIdentityAssumerole,
@@ -452,6 +456,19 @@ func getTemporaryAKSKByAgency(c *Config, role AssumeRole) error {
 }
 
 func getTemporaryAKSKByAgencyV5(c *Config, role AssumeRole) error {
+	if err := assumeAgencyV5(c, role); err != nil {
+		return err
+	}
+
+	// set project map to empty, to use the project id of another account
+	c.RegionProjectIDMap = map[string]string{}
+
+	// rebuild the client to use new AK, SK and security_token
+	return buildClientByAKSK(c)
+}
+
+// assumeAgencyV5 replaces the AK, SK and security_token of the configuration with the temporary ones of the agency.
+func assumeAgencyV5(c *Config, role AssumeRole) error {
 	client, err := c.NewServiceClient("sts", c.Region)
 	if err != nil {
 		return fmt.Errorf("error creating Huaweicloud IAM V5 client: %s", err)
@@ -493,12 +510,43 @@ func getTemporaryAKSKByAgencyV5(c *Config, role AssumeRole) error {
 		log.Printf("[DEBUG] unable to find the security token of the assume credential from the API response")
 	}
 	c.AccessKey, c.SecretKey, c.SecurityToken = accessKey, secretKey, securityToken
+	return nil
+}
 
-	// set project map to empty, to use the project id of another account
-	c.RegionProjectIDMap = map[string]string{}
+// NewConfigByAssumeRole returns a copy of the configuration which uses the temporary credentials of the agency, it is
+// used to manage the resources of another account in the same configuration, e.g. accepting a peering connection.
+func (c *Config) NewConfigByAssumeRole(role AssumeRole) (*Config, error) {
+	assumed := *c
+	assumed.RegionProjectIDMap = make(map[string]string)
+	assumed.RPLock = new(sync.Mutex)
+	assumed.SecurityKeyLock = new(sync.Mutex)
 
-	// rebuild the client to use new AK, SK and security_token
-	return buildClientByAKSK(c)
+	var err error
+	if role.RoleDomainID != "" {
+		err = assumeAgencyV5(&assumed, role)
+	} else {
+		err = getTemporaryAKSKByAgency(&assumed, role)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The project and domain of the current account are not used by another account.
+	assumed.TenantID, assumed.DomainID, assumed.DomainName = "", "", ""
+	assumed.AgencyName, assumed.AgencyDomainName, assumed.DelegatedProject = "", "", ""
+	assumed.AssumeRoleAgency, assumed.AssumeRoleDomain, assumed.AssumeRoleDomainID = "", "", ""
+	assumed.AssumeRoleList = nil
+	if err = buildClientByAKSK(&assumed); err != nil {
+		return nil, err
+	}
+
+	if domainID, err := assumed.getDomainID(); err == nil {
+		assumed.DomainID = domainID
+		assumed.DomainClient.AKSKAuthOptions.DomainID = domainID
+	} else {
+		log.Printf("[WARN] get domain id of the assumed account failed: %s", err)
+	}
+	return &assumed, nil
 }
 
 func (c *Config) reloadSecurityKey() error {
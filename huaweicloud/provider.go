@@ -57,6 +57,7 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/cts"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/das"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/dataarts"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/database"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/dbss"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/dc"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/dcs"
@@ -1311,6 +1312,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_dms_kafka_volume_auto_expand_configuration": kafka.DataSourceVolumeAutoExpandConfiguration(),
 
 			"huaweicloud_dms_rabbitmq_background_tasks":  rabbitmq.DataSourceDmsRabbitMQBackgroundTasks(),
+			"huaweicloud_dms_rabbitmq_definitions":       rabbitmq.DataSourceDmsRabbitmqDefinitions(),
 			"huaweicloud_dms_rabbitmq_exchanges":         rabbitmq.DataSourceDmsRabbitmqExchanges(),
 			"huaweicloud_dms_rabbitmq_extend_flavors":    rabbitmq.DataSourceDmsRabbitmqExtendFlavors(),
 			"huaweicloud_dms_rabbitmq_flavors":           rabbitmq.DataSourceRabbitMQFlavors(),
@@ -1334,6 +1336,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_dms_rocketmq_instance_nodes":              rocketmq.DataSourceDmsRocketMQInstanceNodes(),
 			"huaweicloud_dms_rocketmq_instance_diagnoses":          rocketmq.DataSourceInstanceDiagnoses(),
 			"huaweicloud_dms_rocketmq_instances":                   rocketmq.DataSourceDmsRocketMQInstances(),
+			"huaweicloud_dms_rocketmq_metadata":                    rocketmq.DataSourceDmsRocketMQMetadata(),
 			"huaweicloud_dms_rocketmq_message_traces":              rocketmq.DataSourceDmsRocketmqMessageTraces(),
 			"huaweicloud_dms_rocketmq_messages":                    rocketmq.DataSourceDmsRocketMQMessages(),
 			"huaweicloud_dms_rocketmq_migration_tasks":             rocketmq.DataSourceDmsRocketmqMigrationTasks(),
@@ -1355,6 +1358,8 @@ func Provider() *schema.Provider {
 			"huaweicloud_dns_system_lines":                 dns.DataSourceSystemLines(),
 			"huaweicloud_dns_zones":                        dns.DataSourceZones(),
 			"huaweicloud_dns_zone_nameservers":             dns.DataSourceZoneNameservers(),
+			"huaweicloud_dns_zone_file":                    dns.DataSourceZoneFile(),
+			"huaweicloud_dns_zone_file_records":            dns.DataSourceZoneFileRecords(),
 			"huaweicloud_dns_public_zone_detection_status": dns.DataSourcePublicZoneDetectionStatus(),
 			"huaweicloud_dns_public_zone_lines":            dns.DataSourceDNSPublicZoneLines(),
 			"huaweicloud_dns_public_zone_recordsets":       dns.DataSourcePublicZoneRecordsets(),
@@ -1513,6 +1518,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_er_attachments":        er.DataSourceAttachments(),
 			"huaweicloud_er_available_routes":   er.DataSourceErAvailableRoutes(),
 			"huaweicloud_er_availability_zones": er.DataSourceAvailabilityZones(),
+			"huaweicloud_er_effective_routes":   er.DataSourceEffectiveRoutes(),
 			"huaweicloud_er_flow_logs":          er.DataSourceFlowLogs(),
 			"huaweicloud_er_instances":          er.DataSourceInstances(),
 			"huaweicloud_er_propagations":       er.DataSourcePropagations(),
@@ -2010,6 +2016,8 @@ func Provider() *schema.Provider {
 			"huaweicloud_identityv5_policies":                 iam.DataSourceIdentityV5Policies(),
 			"huaweicloud_identityv5_policy_versions":          iam.DataSourceIdentityV5PolicyVersions(),
 			"huaweicloud_identityv5_policy_attached_entities": iam.DataSourceIdentityV5PolicyAttachedEntities(),
+			"huaweicloud_identityv5_policy_document":          iam.DataSourceV5PolicyDocument(),
+			"huaweicloud_identityv5_policy_simulation":        iam.DataSourceV5PolicySimulation(),
 			"huaweicloud_identityv5_agency_attached_policies": iam.DataSourceV5AgencyAttachedPolicies(),
 			"huaweicloud_identityv5_group_attached_policies":  iam.DataSourceV5GroupAttachedPolicies(),
 			"huaweicloud_identityv5_authorization_schema":     iam.DataSourceV5AuthorizationSchema(),
@@ -2189,6 +2197,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_nat_private_transit_subnets":     nat.DataSourceNatPrivateTransitSubnets(),
 			"huaweicloud_nat_private_transit_ips_by_tags": nat.DataSourcePrivateTransitIpsByTags(),
 
+			"huaweicloud_network_path_analysis":        vpc.DataSourceNetworkPathAnalysis(),
 			"huaweicloud_networking_port":              vpc.DataSourceNetworkingPortV2(),
 			"huaweicloud_networking_secgroup":          vpc.DataSourceNetworkingSecGroup(),
 			"huaweicloud_networking_secgroups":         vpc.DataSourceNetworkingSecGroups(),
@@ -2335,6 +2344,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_rds_instance_configurations":            rds.DataSourceRdsInstanceConfigurations(),
 			"huaweicloud_rds_wal_log_replay_delay_status":        rds.DataSourceRdsWalLogReplayDelayStatus(),
 			"huaweicloud_rds_wal_log_recovery_time_window":       rds.DataSourceRdsWalLogRecoveryTimeWindow(),
+			"huaweicloud_rds_major_version_upgrade_check":        rds.DataSourceRdsMajorVersionUpgradeCheck(),
 			"huaweicloud_rds_read_replica_restorable_databases":  rds.DataSourceRdsReadReplicaRestorableDatabases(),
 			"huaweicloud_rds_backup_databases":                   rds.DataSourceRdsBackupDatabases(),
 			"huaweicloud_rds_business_partners":                  rds.DataSourceRdsBusinessPartners(),
@@ -2786,6 +2796,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_vpc": vpc.DataSourceVpcV1(),
 			"huaweicloud_vpc_address_group_associated_resources": vpc.DataSourceVpcAddressGroupAssociatedResources(),
 			"huaweicloud_vpc_address_groups":                     vpc.DataSourceVpcAddressGroups(),
+			"huaweicloud_vpc_flow_log_records":                   vpc.DataSourceVpcFlowLogRecords(),
 			"huaweicloud_vpc_flow_logs":                          vpc.DataSourceVpcFlowLogs(),
 			"huaweicloud_vpc_network_acls":                       vpc.DataSourceNetworkAcls(),
 			"huaweicloud_vpc_network_acl_tags":                   vpc.DataSourceVpcNetworkAclTags(),
@@ -3550,6 +3561,7 @@ func Provider() *schema.Provider {
 
 			"huaweicloud_compute_template":                   ecs.ResourceComputeTemplate(),
 			"huaweicloud_compute_instance":                   ecs.ResourceComputeInstance(),
+			"huaweicloud_compute_instance_group":             ecs.ResourceComputeInstanceGroup(),
 			"huaweicloud_compute_instance_redeploy":          ecs.ResourceComputeInstanceRedeploy(),
 			"huaweicloud_compute_interface_attach":           ecs.ResourceComputeInterfaceAttach(),
 			"huaweicloud_compute_keypair":                    ResourceComputeKeypairV2(),
@@ -3725,6 +3737,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_dcs_diagnosis_task":                     dcs.ResourceDiagnosisTask(),
 			"huaweicloud_dcs_backup_import_task":                 dcs.ResourceDcsBackupImportTask(),
 			"huaweicloud_dcs_online_data_migration_task":         dcs.ResourceDcsOnlineDataMigrationTask(),
+			"huaweicloud_dcs_online_migration":                   dcs.ResourceDcsOnlineMigration(),
 			"huaweicloud_dcs_migration_task_stop":                dcs.ResourceDcsMigrationTaskStop(),
 			"huaweicloud_dcs_node_status_change":                 dcs.ResourceDcsNodeStatusChange(),
 			"huaweicloud_dcs_online_data_migration_task_restart": dcs.ResourceDcsOnlineDataMigrationTaskRestart(),
@@ -3734,6 +3747,8 @@ func Provider() *schema.Provider {
 			"huaweicloud_dcs_sessions_query":                     dcs.ResourceDcsSessionsQuery(),
 			"huaweicloud_dcs_redis_run_log_collect":              dcs.ResourceDcsRedisRunLogCollect(),
 
+			"huaweicloud_database_backup_export": database.ResourceDatabaseBackupExport(),
+
 			"huaweicloud_dds_database_role":                 dds.ResourceDatabaseRole(),
 			"huaweicloud_dds_database_user":                 dds.ResourceDatabaseUser(),
 			"huaweicloud_dds_instance":                      dds.ResourceDdsInstanceV3(),
@@ -3807,6 +3822,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_dli_sql_template":                    dli.ResourceSQLTemplate(),
 			"huaweicloud_dli_table":                           dli.ResourceDliTable(),
 
+			"huaweicloud_dms_kafka_acl_set":                           kafka.ResourceAclSet(),
 			"huaweicloud_dms_kafka_background_task_delete":            kafka.ResourceDmsKafkaBackgroundTaskDelete(),
 			"huaweicloud_dms_kafka_consumer_group_topic_batch_delete": kafka.ResourceConsumerGroupTopicBatchDelete(),
 			"huaweicloud_dms_kafka_consumer_group":                    kafka.ResourceDmsKafkaConsumerGroup(),
@@ -3829,6 +3845,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_dms_kafka_smart_connect_task_action":         kafka.ResourceDmsKafkaSmartConnectTaskAction(),
 			"huaweicloud_dms_kafka_smart_connector_validate":          kafka.ResourceSmartConnectorValidate(),
 			"huaweicloud_dms_kafka_topic":                             kafka.ResourceTopic(),
+			"huaweicloud_dms_kafka_topics":                            kafka.ResourceTopics(),
 			"huaweicloud_dms_kafka_topic_message_batch_delete":        kafka.ResourceTopicMessageBatchDelete(),
 			"huaweicloud_dms_kafka_topic_quota":                       kafka.ResourceTopicQuota(),
 			"huaweicloud_dms_kafka_user":                              kafka.ResourceDmsKafkaUser(),
@@ -3837,6 +3854,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_dms_kafka_volume_auto_expand_configuration":  kafka.ResourceVolumeAutoExpandConfiguration(),
 
 			"huaweicloud_dms_rabbitmq_background_task_delete":           rabbitmq.ResourceDmsRabbitMQBackgroundTaskDelete(),
+			"huaweicloud_dms_rabbitmq_definitions":                      rabbitmq.ResourceDmsRabbitmqDefinitions(),
 			"huaweicloud_dms_rabbitmq_exchange":                         rabbitmq.ResourceDmsRabbitmqExchange(),
 			"huaweicloud_dms_rabbitmq_exchange_associate":               rabbitmq.ResourceDmsRabbitmqExchangeAssociate(),
 			"huaweicloud_dms_rabbitmq_instance":                         rabbitmq.ResourceDmsRabbitmqInstance(),
@@ -3854,6 +3872,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_dms_rocketmq_instance":                         rocketmq.ResourceDmsRocketMQInstance(),
 			"huaweicloud_dms_rocketmq_instance_diagnosis":               rocketmq.ResourceInstanceDiagnosis(),
 			"huaweicloud_dms_rocketmq_message_offset_reset":             rocketmq.ResourceDmsRocketMQMessageOffsetReset(),
+			"huaweicloud_dms_rocketmq_metadata":                         rocketmq.ResourceDmsRocketMQMetadata(),
 			"huaweicloud_dms_rocketmq_message_send":                     rocketmq.ResourceRocketMQMessageSend(),
 			"huaweicloud_dms_rocketmq_migration_task":                   rocketmq.ResourceDmsRocketmqMigrationTask(),
 			"huaweicloud_dms_rocketmq_node_batch_restart":               rocketmq.ResourceNodeBatchRestart(),
@@ -3867,6 +3886,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_dnsv21_ptrrecord":              dns.ResourceDNSV21PtrRecord(),
 			"huaweicloud_dns_recordset":                 dns.ResourceDNSRecordset(),
 			"huaweicloud_dns_zone":                      dns.ResourceDNSZone(),
+			"huaweicloud_dns_zone_records":              dns.ResourceZoneRecords(),
 			"huaweicloud_dns_private_zone_associate":    dns.ResourceDNSPrivateZoneAssociate(),
 			"huaweicloud_dns_endpoint_assignment":       dns.ResourceEndpointAssignment(),
 			"huaweicloud_dns_endpoint":                  dns.ResourceDNSEndpoint(),
@@ -3961,8 +3981,10 @@ func Provider() *schema.Provider {
 			"huaweicloud_elb_active_standby_pool":              elb.ResourceActiveStandbyPool(),
 			"huaweicloud_elb_member":                           elb.ResourceMemberV3(),
 			"huaweicloud_elb_member_check_task":                elb.ResourceMemberCheckTask(),
+			"huaweicloud_elb_member_set":                       elb.ResourceMemberSet(),
 			"huaweicloud_elb_logtank":                          elb.ResourceLogTank(),
 			"huaweicloud_elb_security_policy":                  elb.ResourceSecurityPolicy(),
+			"huaweicloud_elb_traffic_shift":                    elb.ResourceTrafficShift(),
 			"huaweicloud_elb_recycle_bin":                      elb.ResourceElbRecycleBin(),
 			"huaweicloud_elb_recycle_bin_loadbalancer_recover": elb.ResourceElbRecycleBinLoadBalancerRecover(),
 			"huaweicloud_elb_recycle_bin_loadbalancer_delete":  elb.ResourceElbRecycleBinLoadBalancerDelete(),
@@ -4486,21 +4508,26 @@ func Provider() *schema.Provider {
 			"huaweicloud_mpc_transcoding_template":       mpc.ResourceTranscodingTemplate(),
 			"huaweicloud_mpc_transcoding_template_group": mpc.ResourceTranscodingTemplateGroup(),
 
-			"huaweicloud_nat_dnat_rule": nat.ResourcePublicDnatRule(),
-			"huaweicloud_nat_gateway":   nat.ResourcePublicGateway(),
-			"huaweicloud_nat_snat_rule": nat.ResourcePublicSnatRule(),
-			"huaweicloud_natv3_gateway": nat.ResourcePublicGatewayV3(),
+			"huaweicloud_nat_dnat_rule":     nat.ResourcePublicDnatRule(),
+			"huaweicloud_nat_dnat_rule_set": nat.ResourceDnatRuleSet(),
+			"huaweicloud_nat_gateway":       nat.ResourcePublicGateway(),
+			"huaweicloud_nat_snat_rule":     nat.ResourcePublicSnatRule(),
+			"huaweicloud_nat_snat_rule_set": nat.ResourceSnatRuleSet(),
+			"huaweicloud_natv3_gateway":     nat.ResourcePublicGatewayV3(),
 
 			"huaweicloud_nat_private_dnat_rule":      nat.ResourcePrivateDnatRule(),
+			"huaweicloud_nat_private_dnat_rule_set":  nat.ResourcePrivateDnatRuleSet(),
 			"huaweicloud_nat_private_gateway":        nat.ResourcePrivateGateway(),
 			"huaweicloud_nat_private_snat_rule":      nat.ResourcePrivateSnatRule(),
+			"huaweicloud_nat_private_snat_rule_set":  nat.ResourcePrivateSnatRuleSet(),
 			"huaweicloud_nat_private_transit_ip":     nat.ResourcePrivateTransitIp(),
 			"huaweicloud_nat_private_transit_subnet": nat.ResourcePrivateTransitSubnet(),
 
-			"huaweicloud_networking_secgroup":      vpc.ResourceNetworkingSecGroup(),
-			"huaweicloud_networking_secgroup_rule": vpc.ResourceNetworkingSecGroupRule(),
-			"huaweicloud_networking_vip":           vpc.ResourceNetworkingVip(),
-			"huaweicloud_networking_vip_associate": vpc.ResourceNetworkingVIPAssociateV2(),
+			"huaweicloud_networking_secgroup":          vpc.ResourceNetworkingSecGroup(),
+			"huaweicloud_networking_secgroup_rule":     vpc.ResourceNetworkingSecGroupRule(),
+			"huaweicloud_networking_secgroup_rule_set": vpc.ResourceNetworkingSecGroupRuleSet(),
+			"huaweicloud_networking_vip":               vpc.ResourceNetworkingVip(),
+			"huaweicloud_networking_vip_associate":     vpc.ResourceNetworkingVIPAssociateV2(),
 
 			"huaweicloud_obs_bucket":                obs.ResourceObsBucket(),
 			"huaweicloud_obs_bucket_acl":            obs.ResourceOBSBucketAcl(),
@@ -4572,6 +4599,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_rds_extend_log_link":                 rds.ResourceRdsExtendLogLink(),
 			"huaweicloud_rds_instant_task_delete":             rds.ResourceRdsInstantTaskDelete(),
 			"huaweicloud_rds_instance_minor_version_upgrade":  rds.ResourceRdsInstanceMinorVersionUpgrade(),
+			"huaweicloud_rds_instance_major_version_upgrade":  rds.ResourceRdsInstanceMajorVersionUpgrade(),
 			"huaweicloud_rds_unlock_node_readonly_status":     rds.ResourceUnlockNodeReadonlyStatus(),
 			"huaweicloud_rds_wal_log_replay_switch":           rds.ResourceRdsWalLogReplaySwitch(),
 			"huaweicloud_rds_restore_read_replica_database":   rds.ResourceRdsRestoreReadReplicaDatabase(),
@@ -4834,6 +4862,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_vpc_eip_update_publicip_pool": eip.ResourceEipUpdatePublicipPool(),
 			"huaweicloud_vpc_eip":                      eip.ResourceVpcEIPV1(),
 			"huaweicloud_vpc_eip_associate":            eip.ResourceEIPAssociate(),
+			"huaweicloud_vpc_eip_adopt":                eip.ResourceVpcEipAdopt(),
 			"huaweicloud_vpc_eipv3_associate":          eip.ResourceEipv3Associate(),
 			"huaweicloud_vpc_internet_gateway":         eip.ResourceVPCInternetGateway(),
 
@@ -31,6 +31,12 @@ var (
 
 	HW_DEDICATED_HOST_ID = os.Getenv("HW_DEDICATED_HOST_ID")
 
+	// The agency created by the peer account, which is used to accept the cross-account requests.
+	HW_PEER_AGENCY_NAME = os.Getenv("HW_PEER_AGENCY_NAME")
+	HW_PEER_DOMAIN_NAME = os.Getenv("HW_PEER_DOMAIN_NAME")
+	HW_PEER_PROJECT_ID  = os.Getenv("HW_PEER_PROJECT_ID")
+	HW_PEER_VPC_ID      = os.Getenv("HW_PEER_VPC_ID")
+
 	HW_DOMAIN_ID                          = os.Getenv("HW_DOMAIN_ID")
 	HW_DOMAIN_NAME                        = os.Getenv("HW_DOMAIN_NAME")
 	HW_ENTERPRISE_PROJECT_ID_TEST         = os.Getenv("HW_ENTERPRISE_PROJECT_ID_TEST")
@@ -905,6 +911,7 @@ var (
 	HW_RDS_CROSS_REGION_BACKUP_INSTANCE_ID = os.Getenv("HW_RDS_CROSS_REGION_BACKUP_INSTANCE_ID")
 	HW_RDS_INSTANCE_FLAVOR                 = os.Getenv("HW_RDS_INSTANCE_FLAVOR")
 	HW_RDS_INSTANCE_ID                     = os.Getenv("HW_RDS_INSTANCE_ID")
+	HW_RDS_UPGRADE_TARGET_VERSION          = os.Getenv("HW_RDS_UPGRADE_TARGET_VERSION")
 	HW_RDS_TARGET_INSTANCE_ID              = os.Getenv("HW_RDS_TARGET_INSTANCE_ID")
 	HW_RDS_TARGET_PROJECT_ID               = os.Getenv("HW_RDS_TARGET_PROJECT_ID")
 	HW_RDS_TARGET_REGION                   = os.Getenv("HW_RDS_TARGET_REGION")
@@ -2912,6 +2919,13 @@ func TestAccPreCheckERRoutePolicyIDs(t *testing.T, min int) {
 	}
 }
 
+// lintignore:AT003
+func TestAccPreCheckErSharedInstance(t *testing.T) {
+	if HW_ER_SHARED_INSTANCE_ID == "" {
+		t.Skip("HW_ER_SHARED_INSTANCE_ID must be set for the acceptance test")
+	}
+}
+
 // lintignore:AT003
 func TestAccPreCheckErSharedAttachmentAccepter(t *testing.T) {
 	if HW_ER_SHARED_INSTANCE_ID == "" || HW_ER_SHARED_ATTACHMENT_ID == "" {
@@ -2919,6 +2933,20 @@ func TestAccPreCheckErSharedAttachmentAccepter(t *testing.T) {
 	}
 }
 
+// lintignore:AT003
+func TestAccPreCheckPeerAssumeRole(t *testing.T) {
+	if HW_PEER_AGENCY_NAME == "" || HW_PEER_DOMAIN_NAME == "" {
+		t.Skip("HW_PEER_AGENCY_NAME and HW_PEER_DOMAIN_NAME must be set for the acceptance test")
+	}
+}
+
+// lintignore:AT003
+func TestAccPreCheckPeerVpc(t *testing.T) {
+	if HW_PEER_PROJECT_ID == "" || HW_PEER_VPC_ID == "" {
+		t.Skip("HW_PEER_PROJECT_ID and HW_PEER_VPC_ID must be set for the acceptance test")
+	}
+}
+
 // lintignore:AT003
 func TestAccPreCheckRfArchives(t *testing.T) {
 	if HW_RF_TEMPLATE_ARCHIVE_NO_VARS_URI == "" || HW_RF_TEMPLATE_ARCHIVE_URI == "" ||
@@ -4900,6 +4928,13 @@ func TestAccPreCheckRdsInstanceId(t *testing.T) {
 	}
 }
 
+// lintignore:AT003
+func TestAccPreCheckRdsUpgradeTargetVersion(t *testing.T) {
+	if HW_RDS_UPGRADE_TARGET_VERSION == "" {
+		t.Skip("HW_RDS_UPGRADE_TARGET_VERSION must be set for RDS major version upgrade acceptance tests")
+	}
+}
+
 // lintignore:AT003
 func TestAccPreCheckRdsTargetInstanceId(t *testing.T) {
 	if HW_RDS_TARGET_INSTANCE_ID == "" {
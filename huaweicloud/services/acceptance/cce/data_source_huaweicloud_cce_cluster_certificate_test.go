@@ -2,6 +2,7 @@ package cce
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -44,3 +45,50 @@ data "huaweicloud_cce_cluster_certificate" "test" {
   duration   = 30
 }`, testAccCluster_basic(name))
 }
+
+func TestAccClusterCertificateDataSource_exec(t *testing.T) {
+	rName := acceptance.RandomAccResourceNameWithDash()
+	datasourceName := "data.huaweicloud_cce_cluster_certificate.test"
+	dc := acceptance.InitDataSourceCheck(datasourceName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testClousterCertificate_exec(rName),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(datasourceName, "mode", "exec"),
+					resource.TestCheckResourceAttr(datasourceName, "users.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "users.0.client_key_data", ""),
+					resource.TestCheckResourceAttrSet(datasourceName, "clusters.0.server"),
+					resource.TestCheckResourceAttrPair(datasourceName, "clusters.0.certificate_authority_data",
+						"huaweicloud_cce_cluster.test", "certificate_clusters.0.certificate_authority_data"),
+					resource.TestMatchResourceAttr(datasourceName, "kube_config_raw", regexp.MustCompile(`"exec"`)),
+				),
+			},
+		},
+	})
+}
+
+func testClousterCertificate_exec(name string) string {
+	return fmt.Sprintf(`
+%s
+
+data "huaweicloud_cce_cluster_certificate" "test" {
+  cluster_id = huaweicloud_cce_cluster.test.id
+  mode       = "exec"
+
+  exec {
+    command                    = "cce-token-helper"
+    args                       = ["--cluster-id", huaweicloud_cce_cluster.test.id]
+    certificate_authority_data = huaweicloud_cce_cluster.test.certificate_clusters[0].certificate_authority_data
+
+    env = {
+      HUAWEICLOUD_REGION = "%s"
+    }
+  }
+}`, testAccCluster_basic(name), acceptance.HW_REGION_NAME)
+}
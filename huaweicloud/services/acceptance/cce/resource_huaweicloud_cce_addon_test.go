@@ -2,6 +2,8 @@ package cce
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -264,3 +266,83 @@ resource "huaweicloud_cce_addon" "test" {
 }
 `, testAccAddon_values_base(rName), acceptance.HW_PROJECT_ID)
 }
+
+func TestAccAddon_valuesValidation(t *testing.T) {
+	var (
+		addon addons.Addon
+
+		name         = acceptance.RandomAccResourceNameWithDash()
+		resourceName = "huaweicloud_cce_addon.test"
+
+		rc = acceptance.InitResourceCheck(
+			resourceName,
+			&addon,
+			getAddonFunc,
+		)
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckProjectID(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				// The type of logLevel is different from the template default.
+				Config:      testAccAddon_valuesValidation(name, `logLevel = "3"`),
+				ExpectError: regexp.MustCompile(`the values do not match the template of the add-on`),
+			},
+			{
+				// The parameter which is not defined by the template is only reported as a warning.
+				Config: testAccAddon_valuesValidation(name, "logLevel = 3\n        not_defined_item = true"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestMatchResourceAttr(resourceName, "effective_values.0.custom_json",
+						regexp.MustCompile(`"logLevel":3`)),
+				),
+			},
+			{
+				// The parameter removed from the configuration is restored to the template default.
+				Config: testAccAddon_valuesValidation(name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrWith(resourceName, "effective_values.0.custom_json",
+						func(value string) error {
+							if strings.Contains(value, `"logLevel":3`) || strings.Contains(value, "not_defined_item") {
+								return fmt.Errorf("the removed values are still applied: %s", value)
+							}
+							return nil
+						}),
+				),
+			},
+		},
+	})
+}
+
+func testAccAddon_valuesValidation(name, customValues string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_cce_addon" "test" {
+  depends_on = [
+    huaweicloud_cce_node_pool.test,
+  ]
+
+  cluster_id    = huaweicloud_cce_cluster.test.id
+  template_name = "autoscaler"
+  version       = "1.25.21"
+
+  values {
+    basic       = jsondecode(data.huaweicloud_cce_addon_template.test.spec).basic
+    custom_json = jsonencode({
+      cluster_id = huaweicloud_cce_cluster.test.id
+      tenant_id  = "%[2]s"
+      %[3]s
+    })
+    flavor_json = jsonencode(jsondecode(data.huaweicloud_cce_addon_template.test.spec).parameters.flavor1)
+  }
+}
+`, testAccAddon_values_base(name), acceptance.HW_PROJECT_ID, customValues)
+}
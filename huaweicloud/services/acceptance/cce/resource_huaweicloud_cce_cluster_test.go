@@ -61,6 +61,9 @@ func TestAccCluster_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "agency_name", "CCEAutoClusterAgency"),
 					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar_update"),
 					resource.TestCheckResourceAttr(resourceName, "tags.key_update", "value_update"),
+					resource.TestCheckResourceAttr(resourceName, "kube_config_raw", ""),
+					resource.TestCheckResourceAttr(resourceName, "certificate_users.#", "0"),
+					resource.TestCheckResourceAttrSet(resourceName, "certificate_clusters.0.certificate_authority_data"),
 				),
 			},
 		},
@@ -524,6 +527,7 @@ resource "huaweicloud_cce_cluster" "test" {
   timezone               = "Asia/Shanghai"
   description            = ""
   agency_name            = "CCEAutoClusterAgency"
+  skip_kube_config       = true
 
   tags = {
     foo        = "bar_update"
@@ -1465,3 +1465,83 @@ resource "huaweicloud_cce_node_pool" "test" {
 }
 `, baseConfig, name)
 }
+
+func TestAccNodePool_rollingUpdate(t *testing.T) {
+	var (
+		nodePool nodepools.NodePool
+
+		name         = acceptance.RandomAccResourceNameWithDash()
+		resourceName = "huaweicloud_cce_node_pool.test"
+
+		baseConfig = testAccNodePool_base(name)
+
+		rc = acceptance.InitResourceCheck(
+			resourceName,
+			&nodePool,
+			getNodePoolFunc,
+		)
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNodePool_rollingUpdate(name, baseConfig, "EulerOS 2.9"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "os", "EulerOS 2.9"),
+					resource.TestCheckResourceAttr(resourceName, "current_node_count", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rolling_update.0.max_surge", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rolling_update.0.max_unavailable", "0"),
+				),
+			},
+			{
+				Config: testAccNodePool_rollingUpdate(name, baseConfig, "Huawei Cloud EulerOS 2.0"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "os", "Huawei Cloud EulerOS 2.0"),
+					resource.TestCheckResourceAttr(resourceName, "current_node_count", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNodePool_rollingUpdate(name, baseConfig, osName string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_cce_node_pool" "test" {
+  cluster_id               = huaweicloud_cce_cluster.test.id
+  name                     = "%[2]s"
+  os                       = "%[3]s"
+  flavor_id                = local.flavor_specifications
+  availability_zone        = try(data.huaweicloud_availability_zones.test.names[0], null)
+  key_pair                 = huaweicloud_kps_keypair.test.name
+  initial_node_count       = 2
+  type                     = "vm"
+  enterprise_project_id    = var.enterprise_project_id != "" ? var.enterprise_project_id : null
+
+  root_volume {
+    size       = 40
+    volumetype = "SSD"
+  }
+  data_volumes {
+    size       = 100
+    volumetype = "SSD"
+  }
+
+  rolling_update {
+    max_surge       = 1
+    max_unavailable = 0
+    drain_timeout   = 300
+  }
+
+  timeouts {
+    update = "60m"
+  }
+}
+`, baseConfig, name, osName)
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDatabaseBackupExport_rds(t *testing.T) {
+	rName := acceptance.RandomAccResourceNameWithDash()
+	resourceName := "huaweicloud_database_backup_export.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckRdsInstanceId(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		// The exported objects are kept in the bucket after the resource is deleted, and the bucket is destroyed
+		// with all objects.
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseBackupExport_rds(rName, 7),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "backup_id", "huaweicloud_rds_backup.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "engine", "rds"),
+					resource.TestCheckResourceAttr(resourceName, "retention_days", "7"),
+					resource.TestMatchResourceAttr(resourceName, "exported_objects.0.key",
+						regexp.MustCompile(`^audit/rds/`)),
+					resource.TestCheckResourceAttrSet(resourceName, "exported_objects.0.source_file"),
+					resource.TestCheckResourceAttrSet(resourceName, "exported_objects.0.size"),
+					resource.TestMatchResourceAttr(resourceName, "exported_objects.0.checksum",
+						regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttrSet(resourceName, "exported_at"),
+				),
+			},
+			{
+				Config: testAccDatabaseBackupExport_rds(rName, 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "retention_days", "3"),
+					resource.TestCheckResourceAttrSet(resourceName, "exported_objects.0.key"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabaseBackupExport_rds(name string, retentionDays int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_obs_bucket" "test" {
+  bucket        = "%[1]s"
+  acl           = "private"
+  force_destroy = true
+}
+
+resource "huaweicloud_rds_backup" "test" {
+  instance_id = "%[2]s"
+  name        = "%[1]s"
+}
+
+resource "huaweicloud_database_backup_export" "test" {
+  engine         = "rds"
+  backup_id      = huaweicloud_rds_backup.test.id
+  bucket         = huaweicloud_obs_bucket.test.bucket
+  prefix         = "audit"
+  retention_days = %[3]d
+}
+`, name, acceptance.HW_RDS_INSTANCE_ID, retentionDays)
+}
@@ -0,0 +1,92 @@
+package dcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDcsOnlineMigration_basic(t *testing.T) {
+	var obj interface{}
+
+	name := acceptance.RandomAccResourceName()
+	rName := "huaweicloud_dcs_online_migration.test"
+
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getOnlineDataMigrationTaskResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testDcsOnlineMigration_basic(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "task_name", name),
+					resource.TestCheckResourceAttr(rName, "status", "INCRMIGEATING"),
+					resource.TestCheckResourceAttr(rName, "ip_exchange_status", "EXCHANGE_SUCCESS"),
+					resource.TestCheckResourceAttr(rName, "full_sync_progress", "100"),
+					resource.TestCheckResourceAttrSet(rName, "incremental_lag"),
+					resource.TestCheckResourceAttrSet(rName, "ecs_tenant_private_ip"),
+					resource.TestCheckResourceAttrSet(rName, "created_at"),
+				),
+			},
+			{
+				Config: testDcsOnlineMigration_basic(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "ip_exchange_status", "ROLLBACK_SUCCESS"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"source_instance.0.password", "target_instance.0.password", "max_incremental_lag", "exchange_ip",
+				},
+			},
+		},
+	})
+}
+
+func testDcsOnlineMigration_basic(name string, exchangeIp bool) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_dcs_online_migration" "test" {
+  task_name           = "%[2]s"
+  vpc_id              = data.huaweicloud_vpc.test.id
+  subnet_id           = data.huaweicloud_vpc_subnet.test.id
+  security_group_id   = huaweicloud_networking_secgroup.test.id
+  max_incremental_lag = 100
+  exchange_ip         = %[3]t
+
+  source_instance {
+    id       = huaweicloud_dcs_instance.test[0].id
+    password = "Huawei_test"
+  }
+
+  target_instance {
+    id       = huaweicloud_dcs_instance.test[1].id
+    password = "Huawei_test"
+  }
+
+  lifecycle {
+    ignore_changes = [
+      source_instance.0.addrs, target_instance.0.addrs,
+    ]
+  }
+}
+`, testDcsOnlineDataMigrationTask_base(name), name, exchangeIp)
+}
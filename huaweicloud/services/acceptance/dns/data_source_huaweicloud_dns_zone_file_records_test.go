@@ -0,0 +1,59 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDataZoneFileRecords_basic(t *testing.T) {
+	var (
+		dataSource = "data.huaweicloud_dns_zone_file_records.test"
+		dc         = acceptance.InitDataSourceCheck(dataSource)
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataZoneFileRecords_basic,
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(dataSource, "recordsets.#", "4"),
+					resource.TestCheckResourceAttr(dataSource, "recordsets.0.name", "www.example.com."),
+					resource.TestCheckResourceAttr(dataSource, "recordsets.0.type", "A"),
+					resource.TestCheckResourceAttr(dataSource, "recordsets.0.ttl", "3600"),
+					resource.TestCheckResourceAttr(dataSource, "recordsets.0.records.#", "2"),
+					resource.TestCheckResourceAttr(dataSource, "recordsets.1.ttl", "600"),
+					resource.TestCheckResourceAttr(dataSource, "recordsets.1.records.0", "10 mx1.example.com."),
+					resource.TestCheckResourceAttr(dataSource, "recordsets.2.records.0", `"v=spf1 -all"`),
+					resource.TestCheckResourceAttr(dataSource, "recordsets.3.records.0", "www.example.com."),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataZoneFileRecords_basic = `
+data "huaweicloud_dns_zone_file_records" "test" {
+  content = <<EOT
+$ORIGIN example.com.
+$TTL 1h
+@        IN SOA ns1.huaweicloud-dns.com. admin.example.com. (
+           1       ; serial
+           7200    ; refresh
+           900     ; retry
+           1209600 ; expire
+           300 )   ; minimum
+@        IN NS    ns1.huaweicloud-dns.com.
+www      IN A     192.168.0.1
+         IN A     192.168.0.2
+mail 600 IN MX    10 mx1
+@        IN TXT   "v=spf1 -all"
+alias    IN CNAME www
+EOT
+}
+`
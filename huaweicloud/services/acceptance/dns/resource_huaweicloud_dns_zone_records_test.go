@@ -0,0 +1,178 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func getZoneRecordsResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	client, err := cfg.NewServiceClient("dns", acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("error creating DNS client: %s", err)
+	}
+
+	listPath := client.Endpoint + "v2.1/zones/{zone_id}/recordsets"
+	listPath = strings.ReplaceAll(listPath, "{zone_id}", state.Primary.ID)
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	listResp, err := client.Request("GET", listPath, &listOpt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving DNS recordsets: %s", err)
+	}
+	listRespBody, err := utils.FlattenResponse(listResp)
+	if err != nil {
+		return nil, err
+	}
+
+	// The default recordsets (SOA and NS) are not managed by the resource.
+	recordsets := utils.PathSearch("recordsets[?default==`false`]", listRespBody, make([]interface{}, 0)).([]interface{})
+	if len(recordsets) == 0 {
+		return nil, golangsdk.ErrDefault404{}
+	}
+	return recordsets, nil
+}
+
+func TestAccZoneRecords_basic(t *testing.T) {
+	var (
+		obj interface{}
+
+		rName = "huaweicloud_dns_zone_records.test"
+		rc    = acceptance.InitResourceCheck(rName, &obj, getZoneRecordsResourceFunc)
+
+		name = fmt.Sprintf("acpttest-zone-%s.com", acctest.RandString(5))
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneRecords_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(rName, "zone_id", "huaweicloud_dns_zone.test", "id"),
+					resource.TestCheckResourceAttr(rName, "recordsets.#", "4"),
+					resource.TestCheckResourceAttr("data.huaweicloud_dns_zone_file_records.test", "recordsets.#", "4"),
+				),
+			},
+			{
+				Config: testAccZoneRecords_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "recordsets.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(rName, "recordsets.*", map[string]string{
+						"name":      fmt.Sprintf("www.%s.", name),
+						"type":      "A",
+						"ttl":       "600",
+						"records.#": "1",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(rName, "recordsets.*", map[string]string{
+						"name":    fmt.Sprintf("api.%s.", name),
+						"type":    "A",
+						"line_id": "Dianxin",
+						"weight":  "10",
+					}),
+					resource.TestCheckResourceAttrSet("data.huaweicloud_dns_zone_file.test", "content"),
+					resource.TestCheckResourceAttr("data.huaweicloud_dns_zone_file.test", "zone_name",
+						fmt.Sprintf("%s.", name)),
+				),
+			},
+			{
+				ResourceName:            rName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"recordsets"},
+			},
+		},
+	})
+}
+
+func testAccZoneRecords_basic(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_dns_zone" "test" {
+  name = "%[1]s"
+  ttl  = 300
+}
+
+data "huaweicloud_dns_zone_file_records" "test" {
+  origin  = huaweicloud_dns_zone.test.name
+  content = <<EOT
+$TTL 300
+@        IN SOA ns1.huaweicloud-dns.com. admin.%[1]s. (1 7200 900 1209600 300)
+@        IN NS  ns1.huaweicloud-dns.com.
+www      IN A   192.168.0.1
+         IN A   192.168.0.2
+mail 600 IN MX  10 mx1
+@        IN TXT "v=spf1 -all"
+alias    IN CNAME www
+EOT
+}
+
+resource "huaweicloud_dns_zone_records" "test" {
+  zone_id = huaweicloud_dns_zone.test.id
+
+  dynamic "recordsets" {
+    for_each = data.huaweicloud_dns_zone_file_records.test.recordsets
+
+    content {
+      name    = recordsets.value.name
+      type    = recordsets.value.type
+      ttl     = recordsets.value.ttl
+      records = recordsets.value.records
+    }
+  }
+}
+`, name)
+}
+
+func testAccZoneRecords_update(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_dns_zone" "test" {
+  name = "%[1]s"
+  ttl  = 300
+}
+
+resource "huaweicloud_dns_zone_records" "test" {
+  zone_id = huaweicloud_dns_zone.test.id
+
+  recordsets {
+    name    = "www.%[1]s."
+    type    = "A"
+    ttl     = 600
+    records = ["192.168.0.3"]
+  }
+  recordsets {
+    name        = "api.%[1]s."
+    type        = "A"
+    records     = ["192.168.0.4"]
+    description = "default line"
+  }
+  recordsets {
+    name    = "api.%[1]s."
+    type    = "A"
+    records = ["192.168.0.5"]
+    line_id = "Dianxin"
+    weight  = 10
+  }
+}
+
+data "huaweicloud_dns_zone_file" "test" {
+  zone_id = huaweicloud_dns_zone.test.id
+
+  depends_on = [huaweicloud_dns_zone_records.test]
+}
+`, name)
+}
@@ -0,0 +1,132 @@
+package ecs
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccComputeInstanceGroup_basic(t *testing.T) {
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_compute_instance_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckComputeInstanceGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeInstanceGroup_basic(rName, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeInstanceGroupMembersExist(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "instance_count", "3"),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.index", "1"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.name", rName+"-worker-1"),
+					resource.TestCheckResourceAttr(resourceName, "members.2.name", rName+"-worker-3"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.status", "ACTIVE"),
+					resource.TestCheckResourceAttrSet(resourceName, "members.0.id"),
+					resource.TestCheckResourceAttrSet(resourceName, "members.0.private_ip"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+				),
+			},
+			{
+				Config: testAccComputeInstanceGroup_basic(rName, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeInstanceGroupMembersExist(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "instance_count", "5"),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "5"),
+					resource.TestCheckResourceAttr(resourceName, "members.4.index", "5"),
+					resource.TestCheckResourceAttr(resourceName, "members.4.name", rName+"-worker-5"),
+				),
+			},
+			{
+				Config: testAccComputeInstanceGroup_basic(rName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeInstanceGroupMembersExist(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "instance_count", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.1.name", rName+"-worker-2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckComputeInstanceGroupDestroy(s *terraform.State) error {
+	cfg := acceptance.TestAccProvider.Meta().(*config.Config)
+	computeClient, err := cfg.ComputeV1Client(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("error creating compute client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "huaweicloud_compute_instance_group" {
+			continue
+		}
+
+		count, _ := strconv.Atoi(rs.Primary.Attributes["members.#"])
+		for i := 0; i < count; i++ {
+			serverId := rs.Primary.Attributes[fmt.Sprintf("members.%d.id", i)]
+			server, err := cloudservers.Get(computeClient, serverId).Extract()
+			if err == nil && server.Status != "DELETED" {
+				return fmt.Errorf("server (%s) of the instance group still exists", serverId)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckComputeInstanceGroupMembersExist(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		cfg := acceptance.TestAccProvider.Meta().(*config.Config)
+		computeClient, err := cfg.ComputeV1Client(acceptance.HW_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("error creating compute client: %s", err)
+		}
+
+		count, _ := strconv.Atoi(rs.Primary.Attributes["members.#"])
+		for i := 0; i < count; i++ {
+			serverId := rs.Primary.Attributes[fmt.Sprintf("members.%d.id", i)]
+			if _, err := cloudservers.Get(computeClient, serverId).Extract(); err != nil {
+				return fmt.Errorf("error retrieving server (%s) of the instance group: %s", serverId, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccComputeInstanceGroup_basic(rName string, count int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_compute_instance_group" "test" {
+  name               = "%[2]s-worker-{index}"
+  instance_count     = %[3]d
+  image_id           = data.huaweicloud_images_image.test.id
+  flavor_id          = data.huaweicloud_compute_flavors.test.ids[0]
+  availability_zone  = data.huaweicloud_availability_zones.test.names[0]
+  subnet_id          = data.huaweicloud_vpc_subnet.test.id
+  security_group_ids = [data.huaweicloud_networking_secgroup.test.id]
+
+  tags = {
+    foo = "bar"
+  }
+}
+`, testAccCompute_data, rName, count)
+}
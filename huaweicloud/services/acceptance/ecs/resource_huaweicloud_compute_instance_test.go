@@ -2,6 +2,7 @@ package ecs
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -1241,3 +1242,70 @@ resource "huaweicloud_compute_instance" "test" {
 }
 `, testAccComputeInstance_reorderSecurityGroups_base(name), name)
 }
+
+func TestAccComputeInstance_resize(t *testing.T) {
+	var instance cloudservers.CloudServer
+
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_compute_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckComputeInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeInstance_resize(rName, "data.huaweicloud_compute_flavors.test.ids[0]", 40),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeInstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttrPair(resourceName, "flavor_id",
+						"data.huaweicloud_compute_flavors.test", "ids.0"),
+					resource.TestCheckResourceAttr(resourceName, "system_disk_size", "40"),
+					resource.TestCheckResourceAttr(resourceName, "resize_strategy", "offline"),
+				),
+			},
+			{
+				Config: testAccComputeInstance_resize(rName, "data.huaweicloud_compute_flavors.resize.ids[0]", 60),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeInstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttrPair(resourceName, "flavor_id",
+						"data.huaweicloud_compute_flavors.resize", "ids.0"),
+					resource.TestCheckResourceAttr(resourceName, "system_disk_size", "60"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+				),
+			},
+			{
+				Config:      testAccComputeInstance_resize(rName, "\"invalid-flavor\"", 60),
+				ExpectError: regexp.MustCompile(`can not be resized to flavor \(invalid-flavor\)`),
+			},
+		},
+	})
+}
+
+func testAccComputeInstance_resize(rName, flavorId string, diskSize int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "huaweicloud_compute_flavors" "resize" {
+  availability_zone = data.huaweicloud_availability_zones.test.names[0]
+  performance_type  = "normal"
+  cpu_core_count    = 2
+  memory_size       = 8
+}
+
+resource "huaweicloud_compute_instance" "test" {
+  name               = "%[2]s"
+  image_id           = data.huaweicloud_images_image.test.id
+  flavor_id          = %[3]s
+  security_group_ids = [data.huaweicloud_networking_secgroup.test.id]
+  availability_zone  = data.huaweicloud_availability_zones.test.names[0]
+  resize_strategy    = "offline"
+  system_disk_type   = "SAS"
+  system_disk_size   = %[4]d
+
+  network {
+    uuid = data.huaweicloud_vpc_subnet.test.id
+  }
+}
+`, testAccCompute_data, rName, flavorId, diskSize)
+}
@@ -0,0 +1,109 @@
+package eip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/chnsz/golangsdk/openstack/networking/v1/eips"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance/common"
+)
+
+func TestAccVpcEipAdopt_basic(t *testing.T) {
+	var (
+		eip eips.PublicIp
+
+		resourceName = "huaweicloud_vpc_eip_adopt.test"
+		randName     = acceptance.RandomAccResourceName()
+	)
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&eip,
+		getEipResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcEipAdopt_basic(randName, "huaweicloud_networking_vip.test.id"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(resourceName, "id", "huaweicloud_vpc_eip.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "address", "huaweicloud_vpc_eip.test", "address"),
+					resource.TestCheckResourceAttrPair(resourceName, "port_id", "huaweicloud_networking_vip.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "status", "BOUND"),
+					resource.TestCheckResourceAttr(resourceName, "bandwidth_share_type", "PER"),
+					resource.TestCheckResourceAttrSet(resourceName, "bandwidth_id"),
+				),
+			},
+			{
+				Config: testAccVpcEipAdopt_basic(randName, "huaweicloud_networking_vip.test_update.id"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(resourceName, "port_id",
+						"huaweicloud_networking_vip.test_update", "id"),
+					resource.TestCheckResourceAttr(resourceName, "status", "BOUND"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"release_on_destroy"},
+			},
+		},
+	})
+}
+
+func testAccVpcEipAdopt_basic(name, portId string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_networking_vip" "test" {
+  name       = "%[2]s"
+  network_id = huaweicloud_vpc_subnet.test.id
+}
+
+resource "huaweicloud_networking_vip" "test_update" {
+  name       = "%[2]s_update"
+  network_id = huaweicloud_vpc_subnet.test.id
+}
+
+# The EIP is retained when it is destroyed, and is managed by the adopt resource.
+resource "huaweicloud_vpc_eip" "test" {
+  name = "%[2]s"
+
+  publicip {
+    type = "5_bgp"
+  }
+
+  bandwidth {
+    share_type  = "PER"
+    name        = "%[2]s"
+    size        = 5
+    charge_mode = "traffic"
+  }
+
+  retain_on_destroy = true
+
+  lifecycle {
+    ignore_changes = [
+      publicip,
+    ]
+  }
+}
+
+resource "huaweicloud_vpc_eip_adopt" "test" {
+  address            = huaweicloud_vpc_eip.test.address
+  port_id            = %[3]s
+  release_on_destroy = true
+}
+`, common.TestVpc(name), name, portId)
+}
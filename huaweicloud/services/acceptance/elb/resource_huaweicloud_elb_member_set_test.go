@@ -0,0 +1,162 @@
+package elb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func getELBMemberSetResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	var (
+		httpUrl = "v3/{project_id}/elb/pools/{pool_id}/members"
+		product = "elb"
+	)
+	client, err := cfg.NewServiceClient(product, acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, err
+	}
+
+	getPath := client.Endpoint + httpUrl
+	getPath = strings.ReplaceAll(getPath, "{project_id}", client.ProjectID)
+	getPath = strings.ReplaceAll(getPath, "{pool_id}", state.Primary.ID)
+
+	getOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+
+	getResp, err := client.Request("GET", getPath, &getOpt)
+	if err != nil {
+		return nil, err
+	}
+	getRespBody, err := utils.FlattenResponse(getResp)
+	if err != nil {
+		return nil, err
+	}
+
+	members := utils.PathSearch("members", getRespBody, make([]interface{}, 0)).([]interface{})
+	if len(members) == 0 {
+		return nil, golangsdk.ErrDefault404{}
+	}
+	return members, nil
+}
+
+func TestAccElbMemberSet_basic(t *testing.T) {
+	var obj interface{}
+	rName := acceptance.RandomAccResourceNameWithDash()
+	resourceName := "huaweicloud_elb_member_set.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&obj,
+		getELBMemberSetResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElbMemberSetConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(resourceName, "pool_id", "huaweicloud_elb_pool.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "member_statuses.#", "3"),
+					resource.TestCheckResourceAttrSet(resourceName, "member_statuses.0.id"),
+					resource.TestCheckResourceAttrSet(resourceName, "member_statuses.0.operating_status"),
+				),
+			},
+			{
+				Config: testAccElbMemberSetConfig_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "member_statuses.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "members.*", map[string]string{
+						"address": "192.168.0.10",
+						"weight":  "50",
+						"name":    rName,
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "members.*", map[string]string{
+						"address": "192.168.0.40",
+						"weight":  "0",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccElbMemberSetConfig_base(rName string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_elb_pool" "test" {
+  name            = "%[2]s"
+  protocol        = "HTTP"
+  lb_method       = "ROUND_ROBIN"
+  loadbalancer_id = huaweicloud_elb_loadbalancer.test.id
+}
+`, testAccElbV3LoadBalancerConfig_basic(rName), rName)
+}
+
+func testAccElbMemberSetConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_elb_member_set" "test" {
+  pool_id = huaweicloud_elb_pool.test.id
+
+  dynamic "members" {
+    for_each = ["192.168.0.10", "192.168.0.20", "192.168.0.30"]
+
+    content {
+      address       = members.value
+      protocol_port = 8080
+      subnet_id     = huaweicloud_vpc_subnet.test.ipv4_subnet_id
+      weight        = 10
+    }
+  }
+}
+`, testAccElbMemberSetConfig_base(rName))
+}
+
+func testAccElbMemberSetConfig_update(rName string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_elb_member_set" "test" {
+  pool_id = huaweicloud_elb_pool.test.id
+
+  members {
+    address       = "192.168.0.10"
+    protocol_port = 8080
+    subnet_id     = huaweicloud_vpc_subnet.test.ipv4_subnet_id
+    weight        = 50
+    name          = "%[2]s"
+  }
+
+  members {
+    address       = "192.168.0.40"
+    protocol_port = 8080
+    subnet_id     = huaweicloud_vpc_subnet.test.ipv4_subnet_id
+    weight        = 0
+  }
+}
+`, testAccElbMemberSetConfig_base(rName), rName)
+}
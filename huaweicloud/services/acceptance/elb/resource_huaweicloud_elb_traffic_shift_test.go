@@ -0,0 +1,68 @@
+package elb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccElbTrafficShift_basic(t *testing.T) {
+	rName := acceptance.RandomAccResourceNameWithDash()
+	resourceName := "huaweicloud_elb_traffic_shift.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccElbTrafficShiftConfig_basic(rName, 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "huaweicloud_elb_pool.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "percentage", "30"),
+					resource.TestCheckResourceAttr(resourceName, "current_percentage", "30"),
+					resource.TestCheckResourceAttr(resourceName, "original_weights.%", "3"),
+				),
+			},
+			{
+				Config: testAccElbTrafficShiftConfig_basic(rName, 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "percentage", "100"),
+					resource.TestCheckResourceAttr(resourceName, "current_percentage", "100"),
+				),
+			},
+		},
+	})
+}
+
+func testAccElbTrafficShiftConfig_basic(rName string, percentage int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_elb_member_set" "test" {
+  pool_id = huaweicloud_elb_pool.test.id
+
+  dynamic "members" {
+    for_each = ["192.168.0.10", "192.168.0.20", "192.168.0.30"]
+
+    content {
+      address       = members.value
+      protocol_port = 8080
+      subnet_id     = huaweicloud_vpc_subnet.test.ipv4_subnet_id
+    }
+  }
+}
+
+resource "huaweicloud_elb_traffic_shift" "test" {
+  pool_id          = huaweicloud_elb_member_set.test.pool_id
+  source_addresses = ["192.168.0.10", "192.168.0.20"]
+  target_addresses = ["192.168.0.30"]
+  percentage       = %[2]d
+  step_percentage  = 50
+  step_interval    = 10
+}
+`, testAccElbMemberSetConfig_base(rName), percentage)
+}
@@ -0,0 +1,141 @@
+package er
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance/common"
+)
+
+func TestAccDataSourceEffectiveRoutes_basic(t *testing.T) {
+	var (
+		name     = acceptance.RandomAccResourceName()
+		bgpAsNum = acctest.RandIntRange(64512, 65534)
+
+		all = "data.huaweicloud_er_effective_routes.test"
+		dc  = acceptance.InitDataSourceCheck(all)
+
+		byOrigin   = "data.huaweicloud_er_effective_routes.filter_by_origin"
+		dcByOrigin = acceptance.InitDataSourceCheck(byOrigin)
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceEffectiveRoutes_basic(name, bgpAsNum),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestMatchResourceAttr(all, "routes.#", regexp.MustCompile(`^[1-9]([0-9]*)?$`)),
+					resource.TestCheckResourceAttrSet(all, "routes.0.id"),
+					resource.TestCheckResourceAttrSet(all, "routes.0.destination"),
+					resource.TestCheckResourceAttrSet(all, "routes.0.origin"),
+					resource.TestCheckResourceAttrSet(all, "routes.0.state"),
+					resource.TestCheckOutput("is_blackhole_reported", "true"),
+					resource.TestCheckOutput("is_overlapping_reported", "true"),
+					resource.TestCheckOutput("is_next_hop_reported", "true"),
+
+					dcByOrigin.CheckResourceExists(),
+					resource.TestCheckOutput("is_origin_filter_useful", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceEffectiveRoutes_basic(name string, bgpAsNum int) string {
+	return fmt.Sprintf(`
+data "huaweicloud_er_availability_zones" "test" {}
+
+%[1]s
+
+resource "huaweicloud_er_instance" "test" {
+  availability_zones = slice(data.huaweicloud_er_availability_zones.test.names, 0, 1)
+
+  name = "%[2]s"
+  asn  = %[3]d
+}
+
+resource "huaweicloud_er_route_table" "test" {
+  instance_id = huaweicloud_er_instance.test.id
+  name        = "%[2]s"
+}
+
+resource "huaweicloud_er_vpc_attachment" "test" {
+  instance_id = huaweicloud_er_instance.test.id
+  vpc_id      = huaweicloud_vpc.test.id
+  subnet_id   = huaweicloud_vpc_subnet.test.id
+  name        = "%[2]s"
+}
+
+resource "huaweicloud_er_propagation" "test" {
+  instance_id    = huaweicloud_er_instance.test.id
+  route_table_id = huaweicloud_er_route_table.test.id
+  attachment_id  = huaweicloud_er_vpc_attachment.test.id
+}
+
+# The static route overlaps the propagated route of the VPC.
+resource "huaweicloud_er_static_route" "test" {
+  depends_on = [huaweicloud_er_propagation.test]
+
+  route_table_id = huaweicloud_er_route_table.test.id
+  destination    = cidrsubnet(huaweicloud_vpc.test.cidr, 8, 1)
+  attachment_id  = huaweicloud_er_vpc_attachment.test.id
+}
+
+resource "huaweicloud_er_static_route" "blackhole" {
+  route_table_id = huaweicloud_er_route_table.test.id
+  destination    = "10.0.0.0/8"
+  is_blackhole   = true
+}
+
+data "huaweicloud_er_effective_routes" "test" {
+  depends_on = [
+    huaweicloud_er_static_route.test,
+    huaweicloud_er_static_route.blackhole,
+  ]
+
+  route_table_id = huaweicloud_er_route_table.test.id
+}
+
+output "is_blackhole_reported" {
+  value = contains(data.huaweicloud_er_effective_routes.test.blackhole_destinations, "10.0.0.0/8")
+}
+
+output "is_overlapping_reported" {
+  value = contains(data.huaweicloud_er_effective_routes.test.overlapping_routes[*].destination,
+  huaweicloud_er_static_route.test.destination)
+}
+
+output "is_next_hop_reported" {
+  value = contains(data.huaweicloud_er_effective_routes.test.routes[*].next_hop_attachment_id,
+  huaweicloud_er_vpc_attachment.test.id)
+}
+
+data "huaweicloud_er_effective_routes" "filter_by_origin" {
+  depends_on = [
+    huaweicloud_er_static_route.test,
+    huaweicloud_er_static_route.blackhole,
+  ]
+
+  route_table_id = huaweicloud_er_route_table.test.id
+  origin         = "static"
+}
+
+locals {
+  origin_filter_result = [for v in data.huaweicloud_er_effective_routes.filter_by_origin.routes[*].origin : v == "static"]
+}
+
+output "is_origin_filter_useful" {
+  value = length(local.origin_filter_result) == 2 && alltrue(local.origin_filter_result)
+}
+`, common.TestVpc(name), name, bgpAsNum)
+}
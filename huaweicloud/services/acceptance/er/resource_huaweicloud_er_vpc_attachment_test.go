@@ -175,3 +175,68 @@ resource "huaweicloud_er_vpc_attachment" "test" {
 }
 `, testVpcAttachment_base(name, bgpAsNum), name)
 }
+
+func TestAccVpcAttachment_peerAssumeRole(t *testing.T) {
+	var (
+		obj   vpcattachments.Attachment
+		rName = "huaweicloud_er_vpc_attachment.test"
+		name  = acceptance.RandomAccResourceName()
+	)
+
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getVpcAttachmentResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckPeerAssumeRole(t)
+			acceptance.TestAccPreCheckErSharedInstance(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testVpcAttachment_peerAssumeRole(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "instance_id", acceptance.HW_ER_SHARED_INSTANCE_ID),
+					resource.TestCheckResourceAttr(rName, "name", name),
+					resource.TestCheckResourceAttr(rName, "status", "available"),
+					resource.TestCheckResourceAttr(rName, "peer_status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func testVpcAttachment_peerAssumeRole(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "192.168.0.0/16"
+}
+
+resource "huaweicloud_vpc_subnet" "test" {
+  vpc_id = huaweicloud_vpc.test.id
+
+  name       = "%[1]s"
+  cidr       = cidrsubnet(huaweicloud_vpc.test.cidr, 4, 1)
+  gateway_ip = cidrhost(cidrsubnet(huaweicloud_vpc.test.cidr, 4, 1), 1)
+}
+
+resource "huaweicloud_er_vpc_attachment" "test" {
+  instance_id = "%[2]s"
+  vpc_id      = huaweicloud_vpc.test.id
+  subnet_id   = huaweicloud_vpc_subnet.test.id
+  name        = "%[1]s"
+
+  peer_assume_role {
+    agency_name = "%[3]s"
+    domain_name = "%[4]s"
+  }
+}
+`, name, acceptance.HW_ER_SHARED_INSTANCE_ID, acceptance.HW_PEER_AGENCY_NAME, acceptance.HW_PEER_DOMAIN_NAME)
+}
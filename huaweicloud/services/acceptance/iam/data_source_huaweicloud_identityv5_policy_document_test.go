@@ -0,0 +1,121 @@
+package iam
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDataV5PolicyDocument_basic(t *testing.T) {
+	var (
+		dataSource = "data.huaweicloud_identityv5_policy_document.test"
+		dc         = acceptance.InitDataSourceCheck(dataSource)
+
+		merged   = "data.huaweicloud_identityv5_policy_document.merged"
+		dcMerged = acceptance.InitDataSourceCheck(merged)
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataV5PolicyDocument_basic,
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(dataSource, "json",
+						`{"Version":"5.0","Statement":[{"Sid":"AllowList","Effect":"Allow",`+
+							`"Action":["ecs:cloudServers:get","ecs:cloudServers:list"],"Resource":["*"],`+
+							`"Condition":{"StringEquals":{"g:ResourceTag/env":["prod"]}}}]}`),
+					dcMerged.CheckResourceExists(),
+					resource.TestCheckResourceAttr(merged, "json",
+						`{"Version":"5.0","Statement":[{"Sid":"AllowList","Effect":"Allow",`+
+							`"Action":["ecs:cloudServers:get","ecs:cloudServers:list"],"Resource":["*"],`+
+							`"Condition":{"StringEquals":{"g:ResourceTag/env":["prod"]}}},`+
+							`{"Sid":"DenyDelete","Effect":"Deny","Action":["ecs:cloudServers:delete","ecs:cloudServers:stop"]},`+
+							`{"Effect":"Allow","NotAction":["iam:*"]}]}`),
+				),
+			},
+			{
+				Config:      testAccDataV5PolicyDocument_invalidAction,
+				ExpectError: regexp.MustCompile(`the action \(ecs:cloudServers:notExist\) does not match any action`),
+			},
+		},
+	})
+}
+
+const testAccDataV5PolicyDocument_basic = `
+data "huaweicloud_identityv5_policy_document" "test" {
+  statement {
+    sid      = "AllowList"
+    action   = ["ecs:cloudServers:list", "ecs:cloudServers:get"]
+    resource = ["*"]
+
+    condition {
+      operator = "StringEquals"
+      key      = "g:ResourceTag/env"
+      values   = ["prod"]
+    }
+  }
+}
+
+data "huaweicloud_identityv5_policy_document" "merged" {
+  source_policy_documents = [
+    jsonencode({
+      Version = "5.0"
+      Statement = [
+        {
+          Sid    = "AllowList"
+          Effect = "Allow"
+          Action = "ecs:*:get*"
+        },
+        {
+          Sid    = "DenyDelete"
+          Effect = "Deny"
+          Action = ["ecs:cloudServers:delete"]
+        },
+      ]
+    })
+  ]
+
+  override_policy_documents = [
+    jsonencode({
+      Version = "5.0"
+      Statement = [
+        {
+          Sid    = "DenyDelete"
+          Effect = "Deny"
+          Action = ["ecs:cloudServers:stop", "ecs:cloudServers:delete"]
+        },
+        {
+          Effect    = "Allow"
+          NotAction = ["iam:*"]
+        },
+      ]
+    })
+  ]
+
+  statement {
+    sid      = "AllowList"
+    action   = ["ecs:cloudServers:list", "ecs:cloudServers:get"]
+    resource = ["*"]
+
+    condition {
+      operator = "StringEquals"
+      key      = "g:ResourceTag/env"
+      values   = ["prod"]
+    }
+  }
+}
+`
+
+const testAccDataV5PolicyDocument_invalidAction = `
+data "huaweicloud_identityv5_policy_document" "test" {
+  statement {
+    action = ["ecs:cloudServers:notExist"]
+  }
+}
+`
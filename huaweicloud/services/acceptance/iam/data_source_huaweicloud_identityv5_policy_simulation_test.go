@@ -0,0 +1,121 @@
+package iam
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDataV5PolicySimulation_basic(t *testing.T) {
+	var (
+		rName = acceptance.RandomAccResourceName()
+
+		byDocuments   = "data.huaweicloud_identityv5_policy_simulation.by_documents"
+		dcByDocuments = acceptance.InitDataSourceCheck(byDocuments)
+
+		byUser   = "data.huaweicloud_identityv5_policy_simulation.by_user"
+		dcByUser = acceptance.InitDataSourceCheck(byUser)
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataV5PolicySimulation_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					dcByDocuments.CheckResourceExists(),
+					resource.TestCheckResourceAttr(byDocuments, "evaluated_policies.#", "1"),
+					resource.TestCheckResourceAttr(byDocuments, "evaluated_policies.0", "document:0"),
+					resource.TestCheckResourceAttr(byDocuments, "results.#", "3"),
+					resource.TestCheckResourceAttr(byDocuments, "results.0.decision", "allowed"),
+					resource.TestCheckResourceAttr(byDocuments, "results.0.allowed", "true"),
+					resource.TestCheckResourceAttr(byDocuments, "results.0.policy_source", "document:0"),
+					resource.TestCheckResourceAttr(byDocuments, "results.0.statement_index", "0"),
+					resource.TestCheckResourceAttr(byDocuments, "results.1.decision", "explicit_deny"),
+					resource.TestCheckResourceAttr(byDocuments, "results.1.allowed", "false"),
+					resource.TestCheckResourceAttr(byDocuments, "results.1.statement_sid", "DenyProdDelete"),
+					resource.TestCheckResourceAttrSet(byDocuments, "results.1.statement"),
+					resource.TestCheckResourceAttr(byDocuments, "results.2.decision", "implicit_deny"),
+					resource.TestCheckResourceAttr(byDocuments, "results.2.statement_index", "-1"),
+					dcByUser.CheckResourceExists(),
+					resource.TestCheckResourceAttr(byUser, "evaluated_policies.0", fmt.Sprintf("identity:%s", rName)),
+					resource.TestCheckResourceAttr(byUser, "results.0.decision", "allowed"),
+					resource.TestCheckResourceAttr(byUser, "results.1.decision", "implicit_deny"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataV5PolicySimulation_basic(name string) string {
+	return fmt.Sprintf(`
+data "huaweicloud_identityv5_policy_document" "test" {
+  statement {
+    action = ["ecs:cloudServers:*"]
+  }
+
+  statement {
+    sid    = "DenyProdDelete"
+    effect = "Deny"
+    action = ["ecs:cloudServers:delete"]
+
+    condition {
+      operator = "StringEquals"
+      key      = "g:ResourceTag/env"
+      values   = ["prod"]
+    }
+  }
+}
+
+data "huaweicloud_identityv5_policy_simulation" "by_documents" {
+  policy_documents = [data.huaweicloud_identityv5_policy_document.test.json]
+
+  request {
+    action = "ecs:cloudServers:list"
+  }
+
+  request {
+    action = "ecs:cloudServers:delete"
+
+    context {
+      key    = "g:ResourceTag/env"
+      values = ["prod"]
+    }
+  }
+
+  request {
+    action = "iam:users:listUsersV5"
+  }
+}
+
+resource "huaweicloud_identityv5_user" "test" {
+  name = "%[1]s"
+}
+
+resource "huaweicloud_identity_policy" "test" {
+  name            = "%[1]s"
+  policy_document = data.huaweicloud_identityv5_policy_document.test.json
+}
+
+resource "huaweicloud_identityv5_policy_user_attach" "test" {
+  policy_id = huaweicloud_identity_policy.test.id
+  user_id   = huaweicloud_identityv5_user.test.id
+}
+
+data "huaweicloud_identityv5_policy_simulation" "by_user" {
+  user_id = huaweicloud_identityv5_policy_user_attach.test.user_id
+
+  request {
+    action = "ecs:cloudServers:list"
+  }
+
+  request {
+    action = "iam:users:listUsersV5"
+  }
+}
+`, name)
+}
@@ -0,0 +1,143 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/kafka"
+)
+
+func getAclSetFunc(topicName string) func(*config.Config, *terraform.ResourceState) (interface{}, error) {
+	return func(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+		client, err := cfg.DmsV2Client(acceptance.HW_REGION_NAME)
+		if err != nil {
+			return nil, fmt.Errorf("error creating DMS client: %s", err)
+		}
+
+		return kafka.GetDmsKafkaPermissions(client, state.Primary.ID, topicName)
+	}
+}
+
+// Before running this test, please ensure that the Kafka instance has SSL authentication enabled and is dedicated to
+// this test, because all permissions which are not managed by the resource will be revoked.
+func TestAccAclSet_basic(t *testing.T) {
+	var (
+		obj      interface{}
+		rName    = acceptance.RandomAccResourceNameWithDash()
+		password = acceptance.RandomPassword()
+
+		resourceName = "huaweicloud_dms_kafka_acl_set.test"
+		rc           = acceptance.InitResourceCheck(resourceName, &obj, getAclSetFunc(rName))
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckDMSKafkaInstanceID(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAclSet_basic_step1(rName, password),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "acls.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "acls.*", map[string]string{
+						"topic_name":    rName,
+						"user_name":     rName + "-1",
+						"access_policy": "all",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "unmanaged_acls.#", "0"),
+				),
+			},
+			{
+				Config: testAccAclSet_basic_step2(rName, password),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "acls.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "acls.*", map[string]string{
+						"topic_name":    rName,
+						"user_name":     rName + "-1",
+						"access_policy": "pub",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "acls.*", map[string]string{
+						"topic_name":    rName,
+						"user_name":     rName + "-2",
+						"access_policy": "sub",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAclSet_base(rName, password string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_dms_kafka_topic" "test" {
+  instance_id = "%[1]s"
+  name        = "%[2]s"
+  partitions  = 3
+}
+
+resource "huaweicloud_dms_kafka_user" "test1" {
+  instance_id = "%[1]s"
+  name        = "%[2]s-1"
+  password    = "%[3]s"
+}
+
+resource "huaweicloud_dms_kafka_user" "test2" {
+  instance_id = "%[1]s"
+  name        = "%[2]s-2"
+  password    = "%[3]s"
+}
+`, acceptance.HW_DMS_KAFKA_INSTANCE_ID, rName, password)
+}
+
+func testAccAclSet_basic_step1(rName, password string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_dms_kafka_acl_set" "test" {
+  instance_id = "%[2]s"
+
+  acls {
+    topic_name    = huaweicloud_dms_kafka_topic.test.name
+    user_name     = huaweicloud_dms_kafka_user.test1.name
+    access_policy = "all"
+  }
+}
+`, testAccAclSet_base(rName, password), acceptance.HW_DMS_KAFKA_INSTANCE_ID)
+}
+
+func testAccAclSet_basic_step2(rName, password string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_dms_kafka_acl_set" "test" {
+  instance_id = "%[2]s"
+
+  acls {
+    topic_name    = huaweicloud_dms_kafka_topic.test.name
+    user_name     = huaweicloud_dms_kafka_user.test1.name
+    access_policy = "pub"
+  }
+
+  acls {
+    topic_name    = huaweicloud_dms_kafka_topic.test.name
+    user_name     = huaweicloud_dms_kafka_user.test2.name
+    access_policy = "sub"
+  }
+}
+`, testAccAclSet_base(rName, password), acceptance.HW_DMS_KAFKA_INSTANCE_ID)
+}
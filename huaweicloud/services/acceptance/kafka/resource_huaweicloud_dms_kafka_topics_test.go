@@ -0,0 +1,128 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/kafka"
+)
+
+func getResourceTopicsFunc(name string) func(*config.Config, *terraform.ResourceState) (interface{}, error) {
+	return func(c *config.Config, state *terraform.ResourceState) (interface{}, error) {
+		client, err := c.NewServiceClient("dms", acceptance.HW_REGION_NAME)
+		if err != nil {
+			return nil, fmt.Errorf("error creating DMS client: %s", err)
+		}
+
+		return kafka.GetTopicByName(client, state.Primary.ID, name)
+	}
+}
+
+func TestAccTopics_basic(t *testing.T) {
+	var (
+		name = acceptance.RandomAccResourceNameWithDash()
+
+		obj   interface{}
+		rName = "huaweicloud_dms_kafka_topics.test"
+		rc    = acceptance.InitResourceCheck(rName, &obj, getResourceTopicsFunc(name+"-1"))
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckDMSKafkaInstanceID(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTopics_basic_step1(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "instance_id", acceptance.HW_DMS_KAFKA_INSTANCE_ID),
+					resource.TestCheckResourceAttr(rName, "topics.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(rName, "topics.*", map[string]string{
+						"name":       name + "-1",
+						"partitions": "3",
+						"aging_time": "36",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(rName, "topics.*", map[string]string{
+						"name":        name + "-2",
+						"partitions":  "1",
+						"description": "Created by Terraform script",
+					}),
+				),
+			},
+			{
+				Config: testAccTopics_basic_step2(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "topics.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(rName, "topics.*", map[string]string{
+						"name":       name + "-1",
+						"partitions": "4",
+						"aging_time": "72",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(rName, "topics.*", map[string]string{
+						"name":       name + "-3",
+						"partitions": "2",
+					}),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// All topics are unmanaged after the import.
+				ImportStateVerifyIgnore: []string{
+					"topics",
+					"unmanaged_topics",
+				},
+			},
+		},
+	})
+}
+
+func testAccTopics_basic_step1(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_dms_kafka_topics" "test" {
+  instance_id = "%[1]s"
+
+  topics {
+    name       = "%[2]s-1"
+    partitions = 3
+    aging_time = 36
+  }
+
+  topics {
+    name        = "%[2]s-2"
+    partitions  = 1
+    description = "Created by Terraform script"
+  }
+}
+`, acceptance.HW_DMS_KAFKA_INSTANCE_ID, name)
+}
+
+func testAccTopics_basic_step2(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_dms_kafka_topics" "test" {
+  instance_id = "%[1]s"
+
+  topics {
+    name       = "%[2]s-1"
+    partitions = 4
+    aging_time = 72
+  }
+
+  topics {
+    name       = "%[2]s-3"
+    partitions = 2
+  }
+}
+`, acceptance.HW_DMS_KAFKA_INSTANCE_ID, name)
+}
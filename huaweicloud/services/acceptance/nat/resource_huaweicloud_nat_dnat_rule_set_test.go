@@ -0,0 +1,181 @@
+package nat
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// getNatRuleSetResourceFunc returns the rules of the gateway, the rule set is considered deleted if there are no rules.
+func getNatRuleSetResourceFunc(httpUrl, gatewayKey, rulesKey string) acceptance.ServiceFunc {
+	return func(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+		client, err := cfg.NewServiceClient("nat", acceptance.HW_REGION_NAME)
+		if err != nil {
+			return nil, fmt.Errorf("error creating NAT client: %s", err)
+		}
+
+		listPath := client.Endpoint + httpUrl
+		listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+		listPath += fmt.Sprintf("?%s=%s", gatewayKey, state.Primary.ID)
+		listOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+		}
+		listResp, err := client.Request("GET", listPath, &listOpt)
+		if err != nil {
+			return nil, err
+		}
+		listRespBody, err := utils.FlattenResponse(listResp)
+		if err != nil {
+			return nil, err
+		}
+
+		rules := utils.PathSearch(rulesKey, listRespBody, make([]interface{}, 0)).([]interface{})
+		if len(rules) == 0 {
+			return nil, golangsdk.ErrDefault404{}
+		}
+		return rules, nil
+	}
+}
+
+func TestAccDnatRuleSet_basic(t *testing.T) {
+	var (
+		obj   interface{}
+		rName = "huaweicloud_nat_dnat_rule_set.test"
+		name  = acceptance.RandomAccResourceNameWithDash()
+	)
+
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getNatRuleSetResourceFunc("v2/{project_id}/dnat_rules", "nat_gateway_id", "dnat_rules"),
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDnatRuleSet_conflict(),
+				ExpectError: regexp.MustCompile("the external port ranges overlap"),
+			},
+			{
+				Config: testAccDnatRuleSet_basic_step_1(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(rName, "nat_gateway_id", "huaweicloud_nat_gateway.test", "id"),
+					resource.TestCheckResourceAttr(rName, "rules.#", "2"),
+				),
+			},
+			{
+				Config: testAccDnatRuleSet_basic_step_2(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "rules.#", "3"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// All rules are unmanaged after the import.
+				ImportStateVerifyIgnore: []string{"rules"},
+			},
+		},
+	})
+}
+
+// The external port 8085 of the second rule falls into the range 8080-8090 of the first rule on the same EIP.
+func testAccDnatRuleSet_conflict() string {
+	return `
+resource "huaweicloud_nat_dnat_rule_set" "test" {
+  nat_gateway_id = "00000000-0000-0000-0000-000000000000"
+
+  rules {
+    floating_ip_id              = "11111111-1111-1111-1111-111111111111"
+    private_ip                  = "192.168.0.10"
+    protocol                    = "tcp"
+    internal_service_port_range = "80-90"
+    external_service_port_range = "8080-8090"
+  }
+  rules {
+    floating_ip_id        = "11111111-1111-1111-1111-111111111111"
+    private_ip            = "192.168.0.11"
+    protocol              = "tcp"
+    internal_service_port = 22
+    external_service_port = 8085
+  }
+}
+`
+}
+
+func testAccDnatRuleSet_basic_step_1(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_nat_dnat_rule_set" "test" {
+  nat_gateway_id = huaweicloud_nat_gateway.test.id
+
+  rules {
+    floating_ip_id        = huaweicloud_vpc_eip.test.id
+    private_ip            = huaweicloud_compute_instance.test.network[0].fixed_ip_v4
+    protocol              = "tcp"
+    internal_service_port = 80
+    external_service_port = 8080
+    description           = "Created by acc test"
+  }
+  rules {
+    floating_ip_id        = huaweicloud_vpc_eip.test.id
+    port_id               = huaweicloud_compute_instance.test.network[0].port
+    protocol              = "udp"
+    internal_service_port = 80
+    external_service_port = 8080
+  }
+}
+`, testAccPublicDnatRule_base(name))
+}
+
+func testAccDnatRuleSet_basic_step_2(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_nat_dnat_rule_set" "test" {
+  nat_gateway_id = huaweicloud_nat_gateway.test.id
+
+  rules {
+    floating_ip_id        = huaweicloud_vpc_eip.test.id
+    private_ip            = huaweicloud_compute_instance.test.network[0].fixed_ip_v4
+    protocol              = "tcp"
+    internal_service_port = 81
+    external_service_port = 8080
+  }
+  rules {
+    floating_ip_id              = huaweicloud_vpc_eip.test.id
+    private_ip                  = huaweicloud_compute_instance.test.network[0].fixed_ip_v4
+    protocol                    = "tcp"
+    internal_service_port_range = "23-33"
+    external_service_port_range = "8023-8033"
+  }
+  rules {
+    floating_ip_id        = huaweicloud_vpc_eip.test.id
+    port_id               = huaweicloud_compute_instance.test.network[0].port
+    protocol              = "udp"
+    internal_service_port = 80
+    external_service_port = 8080
+    description           = "Updated by acc test"
+  }
+}
+`, testAccPublicDnatRule_base(name))
+}
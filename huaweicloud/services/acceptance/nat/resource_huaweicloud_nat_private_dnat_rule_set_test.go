@@ -0,0 +1,136 @@
+package nat
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccPrivateDnatRuleSet_basic(t *testing.T) {
+	var (
+		obj   interface{}
+		rName = "huaweicloud_nat_private_dnat_rule_set.test"
+		name  = acceptance.RandomAccResourceNameWithDash()
+	)
+
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getNatRuleSetResourceFunc("v3/{project_id}/private-nat/dnat-rules", "gateway_id", "dnat_rules"),
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPrivateDnatRuleSet_conflict(),
+				ExpectError: regexp.MustCompile("the transit IP used by the rule with any protocol"),
+			},
+			{
+				Config: testAccPrivateDnatRuleSet_basic_step_1(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(rName, "gateway_id", "huaweicloud_nat_private_gateway.test", "id"),
+					resource.TestCheckResourceAttr(rName, "rules.#", "2"),
+				),
+			},
+			{
+				Config: testAccPrivateDnatRuleSet_basic_step_2(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "rules.#", "2"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// All rules are unmanaged after the import.
+				ImportStateVerifyIgnore: []string{"rules"},
+			},
+		},
+	})
+}
+
+// The rule with any protocol takes all ports of the transit IP, so no other rule can use the same transit IP.
+func testAccPrivateDnatRuleSet_conflict() string {
+	return `
+resource "huaweicloud_nat_private_dnat_rule_set" "test" {
+  gateway_id = "00000000-0000-0000-0000-000000000000"
+
+  rules {
+    transit_ip_id      = "11111111-1111-1111-1111-111111111111"
+    protocol           = "any"
+    backend_private_ip = "192.168.0.10"
+  }
+  rules {
+    transit_ip_id         = "11111111-1111-1111-1111-111111111111"
+    protocol              = "tcp"
+    transit_service_port  = 1000
+    backend_private_ip    = "192.168.0.11"
+    internal_service_port = 2000
+  }
+}
+`
+}
+
+func testAccPrivateDnatRuleSet_basic_step_1(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+%[2]s
+
+resource "huaweicloud_nat_private_dnat_rule_set" "test" {
+  gateway_id = huaweicloud_nat_private_gateway.test.id
+
+  rules {
+    transit_ip_id         = huaweicloud_nat_private_transit_ip.test.id
+    protocol              = "tcp"
+    transit_service_port  = 1000
+    backend_interface_id  = huaweicloud_compute_instance.test.network[0].port
+    internal_service_port = 2000
+    description           = "Created by acc test"
+  }
+  rules {
+    transit_ip_id         = huaweicloud_nat_private_transit_ip.test.id
+    protocol              = "udp"
+    transit_service_port  = 1000
+    backend_private_ip    = huaweicloud_compute_instance.test.network[0].fixed_ip_v4
+    internal_service_port = 2000
+  }
+}
+`, testAccPrivateDnatRule_ecsPart(name), testAccPrivateDnatRule_transitIpConfig(name))
+}
+
+func testAccPrivateDnatRuleSet_basic_step_2(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+%[2]s
+
+resource "huaweicloud_nat_private_dnat_rule_set" "test" {
+  gateway_id = huaweicloud_nat_private_gateway.test.id
+
+  rules {
+    transit_ip_id         = huaweicloud_nat_private_transit_ip.test.id
+    protocol              = "tcp"
+    transit_service_port  = 1000
+    backend_interface_id  = huaweicloud_compute_instance.test.network[0].port
+    internal_service_port = 3000
+  }
+  rules {
+    transit_ip_id         = huaweicloud_nat_private_transit_ip.test.id
+    protocol              = "tcp"
+    transit_service_port  = 1001
+    backend_private_ip    = huaweicloud_compute_instance.test.network[0].fixed_ip_v4
+    internal_service_port = 2001
+  }
+}
+`, testAccPrivateDnatRule_ecsPart(name), testAccPrivateDnatRule_transitIpConfig(name))
+}
@@ -0,0 +1,112 @@
+package nat
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccPrivateSnatRuleSet_basic(t *testing.T) {
+	var (
+		obj   interface{}
+		rName = "huaweicloud_nat_private_snat_rule_set.test"
+		name  = acceptance.RandomAccResourceNameWithDash()
+	)
+
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getNatRuleSetResourceFunc("v3/{project_id}/private-nat/snat-rules", "gateway_id", "snat_rules"),
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPrivateSnatRuleSet_conflict(),
+				ExpectError: regexp.MustCompile("is specified more than once"),
+			},
+			{
+				Config: testAccPrivateSnatRuleSet_basic_step_1(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(rName, "gateway_id", "huaweicloud_nat_private_gateway.test", "id"),
+					resource.TestCheckResourceAttr(rName, "rules.#", "2"),
+				),
+			},
+			{
+				Config: testAccPrivateSnatRuleSet_basic_step_2(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "rules.#", "1"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// All rules are unmanaged after the import.
+				ImportStateVerifyIgnore: []string{"rules"},
+			},
+		},
+	})
+}
+
+// The same transit IP is listed twice in one rule.
+func testAccPrivateSnatRuleSet_conflict() string {
+	return `
+resource "huaweicloud_nat_private_snat_rule_set" "test" {
+  gateway_id = "00000000-0000-0000-0000-000000000000"
+
+  rules {
+    transit_ip_ids = ["11111111-1111-1111-1111-111111111111", "11111111-1111-1111-1111-111111111111"]
+    cidr           = "192.168.0.0/24"
+  }
+}
+`
+}
+
+func testAccPrivateSnatRuleSet_basic_step_1(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+%[2]s
+
+resource "huaweicloud_nat_private_snat_rule_set" "test" {
+  gateway_id = huaweicloud_nat_private_gateway.test.id
+
+  rules {
+    transit_ip_ids = [huaweicloud_nat_private_transit_ip.test1.id]
+    subnet_id      = huaweicloud_vpc_subnet.test.id
+    description    = "Created by acc test"
+  }
+  rules {
+    transit_ip_ids = [huaweicloud_nat_private_transit_ip.test2.id]
+    cidr           = "10.10.0.0/24"
+  }
+}
+`, testAccPrivateSnatRule_base(name), testAccPrivateSnatRule_transitIpConfig(name))
+}
+
+func testAccPrivateSnatRuleSet_basic_step_2(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+%[2]s
+
+resource "huaweicloud_nat_private_snat_rule_set" "test" {
+  gateway_id = huaweicloud_nat_private_gateway.test.id
+
+  rules {
+    transit_ip_ids = [huaweicloud_nat_private_transit_ip.test1.id, huaweicloud_nat_private_transit_ip.test2.id]
+    subnet_id      = huaweicloud_vpc_subnet.test.id
+  }
+}
+`, testAccPrivateSnatRule_base(name), testAccPrivateSnatRule_transitIpConfig(name))
+}
@@ -0,0 +1,117 @@
+package nat
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccSnatRuleSet_basic(t *testing.T) {
+	var (
+		obj   interface{}
+		rName = "huaweicloud_nat_snat_rule_set.test"
+		name  = acceptance.RandomAccResourceNameWithDash()
+	)
+
+	rc := acceptance.InitResourceCheck(
+		rName,
+		&obj,
+		getNatRuleSetResourceFunc("v2/{project_id}/snat_rules", "nat_gateway_id", "snat_rules"),
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSnatRuleSet_conflict(),
+				ExpectError: regexp.MustCompile("the CIDR blocks are the same network"),
+			},
+			{
+				Config: testAccSnatRuleSet_basic_step_1(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(rName, "nat_gateway_id", "huaweicloud_nat_gateway.test", "id"),
+					resource.TestCheckResourceAttr(rName, "rules.#", "2"),
+				),
+			},
+			{
+				Config: testAccSnatRuleSet_basic_step_2(name),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(rName, "rules.#", "1"),
+				),
+			},
+			{
+				ResourceName:      rName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// All rules are unmanaged after the import.
+				ImportStateVerifyIgnore: []string{"rules"},
+			},
+		},
+	})
+}
+
+// The CIDR 192.168.10.1/24 is the same network as 192.168.10.0/24 after masking the host bits.
+func testAccSnatRuleSet_conflict() string {
+	return `
+resource "huaweicloud_nat_snat_rule_set" "test" {
+  nat_gateway_id = "00000000-0000-0000-0000-000000000000"
+
+  rules {
+    floating_ip_id = "11111111-1111-1111-1111-111111111111"
+    cidr           = "192.168.10.0/24"
+    source_type    = 1
+  }
+  rules {
+    floating_ip_id = "22222222-2222-2222-2222-222222222222"
+    cidr           = "192.168.10.1/24"
+    source_type    = 1
+  }
+}
+`
+}
+
+func testAccSnatRuleSet_basic_step_1(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_nat_snat_rule_set" "test" {
+  nat_gateway_id = huaweicloud_nat_gateway.test.id
+
+  rules {
+    subnet_id      = huaweicloud_vpc_subnet.test.id
+    floating_ip_id = huaweicloud_vpc_eip.test[0].id
+    description    = "Created by acc test"
+  }
+  rules {
+    floating_ip_id = huaweicloud_vpc_eip.test[1].id
+    cidr           = "10.10.0.0/24"
+    source_type    = 1
+  }
+}
+`, testAccPublicSnatRule_base(name))
+}
+
+func testAccSnatRuleSet_basic_step_2(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_nat_snat_rule_set" "test" {
+  nat_gateway_id = huaweicloud_nat_gateway.test.id
+
+  rules {
+    subnet_id      = huaweicloud_vpc_subnet.test.id
+    floating_ip_id = join(",", huaweicloud_vpc_eip.test[*].id)
+  }
+}
+`, testAccPublicSnatRule_base(name))
+}
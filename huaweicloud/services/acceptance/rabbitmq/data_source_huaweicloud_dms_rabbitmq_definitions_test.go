@@ -0,0 +1,50 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDataSourceDmsRabbitmqDefinitions_basic(t *testing.T) {
+	dataSource := "data.huaweicloud_dms_rabbitmq_definitions.test"
+	rName := acceptance.RandomAccResourceNameWithDash()
+	dc := acceptance.InitDataSourceCheck(dataSource)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckDMSRabbitMQInstanceId(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceDmsRabbitmqDefinitions_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttrSet(dataSource, "definitions"),
+					resource.TestCheckOutput("is_vhost_exported", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceDmsRabbitmqDefinitions_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+data "huaweicloud_dms_rabbitmq_definitions" "test" {
+  depends_on = [huaweicloud_dms_rabbitmq_definitions.test]
+
+  instance_id = "%s"
+}
+
+output "is_vhost_exported" {
+  value = contains(jsondecode(data.huaweicloud_dms_rabbitmq_definitions.test.definitions).vhosts[*].name, "%s")
+}
+`, testRabbitmqDefinitions_basic(name, "direct", false), acceptance.HW_DMS_RABBITMQ_INSTANCE_ID, name)
+}
@@ -0,0 +1,131 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/rabbitmq"
+)
+
+func getRabbitmqDefinitionsResourceFunc(vhost string) func(*config.Config,
+	*terraform.ResourceState) (interface{}, error) {
+	return func(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+		client, err := cfg.NewServiceClient("dmsv2", acceptance.HW_REGION_NAME)
+		if err != nil {
+			return nil, fmt.Errorf("error creating DMS client: %s", err)
+		}
+
+		// The managed vhost is deleted together with the definitions.
+		return rabbitmq.GetRabbitmqVhost(client, state.Primary.ID, vhost)
+	}
+}
+
+func TestAccRabbitmqDefinitions_basic(t *testing.T) {
+	var obj interface{}
+	rName := acceptance.RandomAccResourceNameWithDash()
+	resourceName := "huaweicloud_dms_rabbitmq_definitions.test"
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&obj,
+		getRabbitmqDefinitionsResourceFunc(rName),
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckDMSRabbitMQInstanceId(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testRabbitmqDefinitions_basic(rName, "direct", false),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "instance_id", acceptance.HW_DMS_RABBITMQ_INSTANCE_ID),
+					resource.TestCheckResourceAttrSet(resourceName, "definitions"),
+				),
+			},
+			{
+				Config:      testRabbitmqDefinitions_basic(rName, "topic", false),
+				ExpectError: regexp.MustCompile("set recreate_on_change to true"),
+			},
+			{
+				Config: testRabbitmqDefinitions_basic(rName, "topic", true),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "recreate_on_change", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "definitions"),
+				),
+			},
+		},
+	})
+}
+
+func testRabbitmqDefinitions_basic(name, exchangeType string, recreateOnChange bool) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_dms_rabbitmq_definitions" "test" {
+  instance_id        = "%[1]s"
+  recreate_on_change = %[4]v
+  definitions = jsonencode({
+    vhosts = [
+      {
+        name = "%[2]s"
+      }
+    ]
+    exchanges = [
+      {
+        name        = "%[2]s"
+        vhost       = "%[2]s"
+        type        = "%[3]s"
+        durable     = true
+        auto_delete = false
+        internal    = false
+        arguments   = {}
+      }
+    ]
+    queues = [
+      {
+        name        = "%[2]s"
+        vhost       = "%[2]s"
+        durable     = true
+        auto_delete = false
+        arguments   = {
+          "x-message-ttl" = 60000
+        }
+      }
+    ]
+    bindings = [
+      {
+        source           = "%[2]s"
+        vhost            = "%[2]s"
+        destination      = "%[2]s"
+        destination_type = "queue"
+        routing_key      = "test"
+      }
+    ]
+    users = [
+      {
+        name     = "%[2]s"
+        password = "Terraform@123"
+      }
+    ]
+    permissions = [
+      {
+        user      = "%[2]s"
+        vhost     = "%[2]s"
+        configure = ".*"
+        write     = ".*"
+        read      = ".*"
+      }
+    ]
+  })
+}
+`, acceptance.HW_DMS_RABBITMQ_INSTANCE_ID, name, exchangeType, recreateOnChange)
+}
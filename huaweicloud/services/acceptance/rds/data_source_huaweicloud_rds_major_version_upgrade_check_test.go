@@ -0,0 +1,44 @@
+package rds
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDataSourceMajorVersionUpgradeCheck_basic(t *testing.T) {
+	dataSource := "data.huaweicloud_rds_major_version_upgrade_check.test"
+	dc := acceptance.InitDataSourceCheck(dataSource)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckRdsInstanceId(t)
+			acceptance.TestAccPreCheckRdsUpgradeTargetVersion(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceMajorVersionUpgradeCheck_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttrSet(dataSource, "available_versions.#"),
+					resource.TestCheckResourceAttrSet(dataSource, "check_result"),
+					resource.TestCheckResourceAttrSet(dataSource, "check_time"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceMajorVersionUpgradeCheck_basic() string {
+	return fmt.Sprintf(`
+data "huaweicloud_rds_major_version_upgrade_check" "test" {
+  instance_id    = "%[1]s"
+  target_version = "%[2]s"
+}
+`, acceptance.HW_RDS_INSTANCE_ID, acceptance.HW_RDS_UPGRADE_TARGET_VERSION)
+}
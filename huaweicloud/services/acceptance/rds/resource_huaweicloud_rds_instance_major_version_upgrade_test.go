@@ -0,0 +1,148 @@
+package rds
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccRdsUpgradingMajorVersion_basic(t *testing.T) {
+	rName := "huaweicloud_rds_instance_major_version_upgrade.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckRdsInstanceId(t)
+			acceptance.TestAccPreCheckRdsUpgradeTargetVersion(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRdsUpgradingMajorVersion_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rName, "version", acceptance.HW_RDS_UPGRADE_TARGET_VERSION),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRdsUpgradingMajorVersion_changePrivateIp(t *testing.T) {
+	rName := "huaweicloud_rds_instance_major_version_upgrade.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckRdsInstanceId(t)
+			acceptance.TestAccPreCheckRdsUpgradeTargetVersion(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRdsUpgradingMajorVersion_changePrivateIp(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rName, "version", acceptance.HW_RDS_UPGRADE_TARGET_VERSION),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRdsUpgradingMajorVersion_instanceNoDiff(t *testing.T) {
+	var instance interface{}
+	name := acceptance.RandomAccResourceName()
+	rName := "huaweicloud_rds_instance_major_version_upgrade.test"
+	instanceName := "huaweicloud_rds_instance.test"
+
+	rc := acceptance.InitResourceCheck(
+		instanceName,
+		&instance,
+		getResourceInstance,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRdsUpgradingMajorVersion_instance(name, "5.7"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestMatchResourceAttr(rName, "version", regexp.MustCompile(`^8\.0`)),
+				),
+			},
+			{
+				// The instance declares the upgraded major version, whose newer minor version in the state is
+				// not a diff, and the instance must not be recreated.
+				Config:   testAccRdsUpgradingMajorVersion_instance(name, "8.0"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccRdsUpgradingMajorVersion_basic() string {
+	return fmt.Sprintf(`
+resource "huaweicloud_rds_instance_major_version_upgrade" "test" {
+  instance_id    = "%[1]s"
+  target_version = "%[2]s"
+}
+`, acceptance.HW_RDS_INSTANCE_ID, acceptance.HW_RDS_UPGRADE_TARGET_VERSION)
+}
+
+func testAccRdsUpgradingMajorVersion_changePrivateIp() string {
+	return fmt.Sprintf(`
+resource "huaweicloud_rds_instance_major_version_upgrade" "test" {
+  instance_id          = "%[1]s"
+  target_version       = "%[2]s"
+  is_change_private_ip = true
+}
+`, acceptance.HW_RDS_INSTANCE_ID, acceptance.HW_RDS_UPGRADE_TARGET_VERSION)
+}
+
+func testAccRdsUpgradingMajorVersion_instance(name, version string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "huaweicloud_rds_flavors" "test" {
+  db_type       = "MySQL"
+  db_version    = "5.7"
+  instance_mode = "single"
+  group_type    = "dedicated"
+  vcpus         = 2
+}
+
+resource "huaweicloud_rds_instance" "test" {
+  name              = "%[2]s"
+  flavor            = data.huaweicloud_rds_flavors.test.flavors[0].name
+  security_group_id = data.huaweicloud_networking_secgroup.test.id
+  subnet_id         = data.huaweicloud_vpc_subnet.test.id
+  vpc_id            = data.huaweicloud_vpc.test.id
+  availability_zone = slice(sort(data.huaweicloud_rds_flavors.test.flavors[0].availability_zones), 0, 1)
+
+  db {
+    type    = "MySQL"
+    version = "%[3]s"
+  }
+
+  volume {
+    type = "CLOUDSSD"
+    size = 40
+  }
+}
+
+resource "huaweicloud_rds_instance_major_version_upgrade" "test" {
+  instance_id    = huaweicloud_rds_instance.test.id
+  target_version = "8.0"
+}
+`, testAccRdsInstance_base, name, version)
+}
@@ -443,6 +443,46 @@ func TestAccRdsInstance_mariadb(t *testing.T) {
 	})
 }
 
+func TestAccRdsInstance_manageMasterPassword(t *testing.T) {
+	var instance interface{}
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_rds_instance.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&instance,
+		getResourceInstance,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRdsInstance_manageMasterPassword(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "db.0.password", ""),
+					resource.TestCheckResourceAttr(resourceName, "manage_master_password.0.secret_name",
+						fmt.Sprintf("%s-master-password", rName)),
+					resource.TestCheckResourceAttrSet(resourceName, "manage_master_password.0.secret_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "manage_master_password.0.rotated_at"),
+					resource.TestCheckResourceAttrSet(resourceName, "master_password_version_id"),
+				),
+			},
+			{
+				Config: testAccRdsInstance_manageMasterPassword(rName, 30),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "manage_master_password.0.rotation_days", "30"),
+					resource.TestCheckResourceAttrSet(resourceName, "master_password_version_id"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccRdsInstance_prePaid(t *testing.T) {
 	var instance interface{}
 	rName := acceptance.RandomAccResourceName()
@@ -519,6 +559,37 @@ func TestAccRdsInstance_restore_mysql(t *testing.T) {
 	})
 }
 
+func TestAccRdsInstance_restoreFrom_mysql(t *testing.T) {
+	var instance interface{}
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_rds_instance.test_backup"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&instance,
+		getResourceInstance,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRdsInstance_restoreFrom_mysql(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttrPair(resourceName, "restore_from.0.source_instance_id",
+						"huaweicloud_rds_backup.test", "instance_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "restore_from.0.restore_time"),
+					resource.TestCheckResourceAttr(resourceName, "db.0.port", "3306"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccRdsInstance_restore_sqlserver(t *testing.T) {
 	var instance interface{}
 	rName := acceptance.RandomAccResourceName()
@@ -1405,6 +1476,48 @@ resource "huaweicloud_rds_instance" "test" {
 `, testAccRdsInstance_base, name)
 }
 
+func testAccRdsInstance_manageMasterPassword(name string, rotationDays int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "huaweicloud_rds_flavors" "test" {
+  db_type       = "MariaDB"
+  db_version    = "10.5"
+  instance_mode = "single"
+  group_type    = "dedicated"
+}
+
+resource "huaweicloud_rds_instance" "test" {
+  name              = "%[2]s"
+  flavor            = data.huaweicloud_rds_flavors.test.flavors[0].name
+  security_group_id = data.huaweicloud_networking_secgroup.test.id
+  subnet_id         = data.huaweicloud_vpc_subnet.test.id
+  vpc_id            = data.huaweicloud_vpc.test.id
+  availability_zone = slice(sort(data.huaweicloud_rds_flavors.test.flavors[0].availability_zones), 0, 1)
+
+  db {
+    type    = "MariaDB"
+    version = "10.5"
+  }
+
+  volume {
+    type = "CLOUDSSD"
+    size = 40
+  }
+
+  manage_master_password {
+    rotation_days = %[3]d > 0 ? %[3]d : null
+  }
+
+  lifecycle {
+    ignore_changes = [
+      storage_used_space,
+    ]
+  }
+}
+`, testAccRdsInstance_base, name, rotationDays)
+}
+
 func testAccRdsInstance_prePaid(name string) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -1563,6 +1676,44 @@ resource "huaweicloud_rds_instance" "test_backup" {
 `, testBackup_mysql_basic(name), name)
 }
 
+func testAccRdsInstance_restoreFrom_mysql(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "huaweicloud_rds_restore_time_ranges" "test" {
+  depends_on = [huaweicloud_rds_backup.test]
+
+  instance_id = huaweicloud_rds_backup.test.instance_id
+}
+
+resource "huaweicloud_rds_instance" "test_backup" {
+  name              = "%[2]s"
+  flavor            = data.huaweicloud_rds_flavors.test.flavors[0].name
+  security_group_id = data.huaweicloud_networking_secgroup.test.id
+  subnet_id         = data.huaweicloud_vpc_subnet.test.id
+  vpc_id            = data.huaweicloud_vpc.test.id
+  availability_zone = slice(sort(data.huaweicloud_rds_flavors.test.flavors[0].availability_zones), 0, 1)
+
+  restore_from {
+    source_instance_id = huaweicloud_rds_backup.test.instance_id
+    restore_time       = tostring(data.huaweicloud_rds_restore_time_ranges.test.restore_time[0].end_time)
+  }
+
+  db {
+    password = "Huangwei!120521"
+    type     = "MySQL"
+    version  = "8.0"
+    port     = 3306
+  }
+
+  volume {
+    type = "CLOUDSSD"
+    size = 50
+  }
+}
+`, testBackup_mysql_basic(name), name)
+}
+
 func testAccRdsInstance_restore_sqlserver(name string) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -0,0 +1,59 @@
+package rocketmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDataSourceDmsRocketMQMetadata_basic(t *testing.T) {
+	dataSource := "data.huaweicloud_dms_rocketmq_metadata.test"
+	rName := acceptance.RandomAccResourceNameWithDash()
+	dc := acceptance.InitDataSourceCheck(dataSource)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckDMSRocketMQInstanceID(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceDmsRocketMQMetadata_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttrSet(dataSource, "metadata"),
+					resource.TestCheckOutput("is_topic_exported", "true"),
+					resource.TestCheckOutput("is_group_exported", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceDmsRocketMQMetadata_basic(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "huaweicloud_dms_rocketmq_metadata" "test" {
+  depends_on = [huaweicloud_dms_rocketmq_metadata.test]
+
+  instance_id = "%[2]s"
+}
+
+locals {
+  metadata = jsondecode(data.huaweicloud_dms_rocketmq_metadata.test.metadata)
+}
+
+output "is_topic_exported" {
+  value = contains(keys(local.metadata.topicConfigTable), "%[3]s")
+}
+
+output "is_group_exported" {
+  value = contains(keys(local.metadata.subscriptionGroupTable), "%[3]s")
+}
+`, testDmsRocketMQMetadata_basic(name, 6, 3), acceptance.HW_DMS_ROCKETMQ_INSTANCE_ID, name)
+}
@@ -0,0 +1,102 @@
+package rocketmq
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func getRocketmqMetadataResourceFunc(topic string) func(*config.Config, *terraform.ResourceState) (interface{}, error) {
+	return func(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+		client, err := cfg.NewServiceClient("dmsv2", acceptance.HW_REGION_NAME)
+		if err != nil {
+			return nil, fmt.Errorf("error creating DMS client: %s", err)
+		}
+
+		// The managed topic is deleted together with the metadata.
+		getPath := client.Endpoint + "v2/{project_id}/instances/{instance_id}/topics/{topic}"
+		getPath = strings.ReplaceAll(getPath, "{project_id}", client.ProjectID)
+		getPath = strings.ReplaceAll(getPath, "{instance_id}", state.Primary.ID)
+		getPath = strings.ReplaceAll(getPath, "{topic}", topic)
+		getOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+		}
+		getResp, err := client.Request("GET", getPath, &getOpt)
+		if err != nil {
+			return nil, err
+		}
+		return utils.FlattenResponse(getResp)
+	}
+}
+
+func TestAccDmsRocketMQMetadata_basic(t *testing.T) {
+	var obj interface{}
+	rName := acceptance.RandomAccResourceNameWithDash()
+	resourceName := "huaweicloud_dms_rocketmq_metadata.test"
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&obj,
+		getRocketmqMetadataResourceFunc(rName),
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckDMSRocketMQInstanceID(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testDmsRocketMQMetadata_basic(rName, 6, 3),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "instance_id", acceptance.HW_DMS_ROCKETMQ_INSTANCE_ID),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata"),
+				),
+			},
+			{
+				Config: testDmsRocketMQMetadata_basic(rName, 2, 5),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata"),
+				),
+			},
+		},
+	})
+}
+
+func testDmsRocketMQMetadata_basic(name string, perm, retryMaxTimes int) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_dms_rocketmq_metadata" "test" {
+  instance_id = "%[1]s"
+  brokers     = ["broker-0"]
+  metadata    = jsonencode({
+    topicConfigTable = {
+      "%[2]s" = {
+        perm           = %[3]d
+        readQueueNums  = 3
+        writeQueueNums = 3
+      }
+    }
+    subscriptionGroupTable = {
+      "%[2]s" = {
+        consumeEnable          = true
+        consumeBroadcastEnable = false
+        consumeMessageOrderly  = false
+        retryMaxTimes          = %[4]d
+      }
+    }
+  })
+}
+`, acceptance.HW_DMS_ROCKETMQ_INSTANCE_ID, name, perm, retryMaxTimes)
+}
@@ -0,0 +1,134 @@
+package vpc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDataSourceNetworkPathAnalysis_basic(t *testing.T) {
+	rName := acceptance.RandomAccResourceName()
+	dataSourceName := "data.huaweicloud_network_path_analysis.test"
+	dc := acceptance.InitDataSourceCheck(dataSourceName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceNetworkPathAnalysis_basic(rName, 22),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(dataSourceName, "result", "ALLOW"),
+					resource.TestCheckResourceAttr(dataSourceName, "hops.0.type", "SECURITY_GROUP_EGRESS"),
+					resource.TestCheckResourceAttr(dataSourceName, "hops.2.type", "VPC_ROUTE"),
+					resource.TestCheckResourceAttr(dataSourceName, "hops.2.decision", "ALLOW"),
+				),
+			},
+			{
+				Config: testAccDataSourceNetworkPathAnalysis_basic(rName, 3306),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(dataSourceName, "result", "DENY"),
+					resource.TestCheckResourceAttr(dataSourceName, "hops.4.type", "SECURITY_GROUP_INGRESS"),
+					resource.TestCheckResourceAttr(dataSourceName, "hops.4.decision", "DENY"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceNetworkPathAnalysis_base(rName string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "172.16.0.0/16"
+}
+
+resource "huaweicloud_vpc_subnet" "test" {
+  count = 2
+
+  name       = "%[1]s_${count.index}"
+  cidr       = cidrsubnet(huaweicloud_vpc.test.cidr, 8, count.index)
+  gateway_ip = cidrhost(cidrsubnet(huaweicloud_vpc.test.cidr, 8, count.index), 1)
+  vpc_id     = huaweicloud_vpc.test.id
+}
+
+resource "huaweicloud_networking_secgroup" "test" {
+  name                 = "%[1]s"
+  delete_default_rules = true
+}
+
+resource "huaweicloud_networking_secgroup_rule" "egress" {
+  security_group_id = huaweicloud_networking_secgroup.test.id
+  direction         = "egress"
+  ethertype         = "IPv4"
+  remote_ip_prefix  = "0.0.0.0/0"
+}
+
+resource "huaweicloud_networking_secgroup_rule" "ssh" {
+  security_group_id = huaweicloud_networking_secgroup.test.id
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  protocol          = "tcp"
+  ports             = "22"
+  remote_ip_prefix  = huaweicloud_vpc.test.cidr
+}
+
+data "huaweicloud_availability_zones" "test" {}
+
+data "huaweicloud_compute_flavors" "test" {
+  availability_zone = data.huaweicloud_availability_zones.test.names[0]
+  performance_type  = "normal"
+  cpu_core_count    = 2
+  memory_size       = 4
+}
+
+data "huaweicloud_images_image" "test" {
+  name        = "Ubuntu 22.04 server 64bit"
+  most_recent = true
+}
+
+resource "huaweicloud_compute_instance" "test" {
+  count = 2
+
+  name               = "%[1]s_${count.index}"
+  image_id           = data.huaweicloud_images_image.test.id
+  flavor_id          = data.huaweicloud_compute_flavors.test.ids[0]
+  security_group_ids = [huaweicloud_networking_secgroup.test.id]
+  availability_zone  = data.huaweicloud_availability_zones.test.names[0]
+
+  network {
+    uuid = huaweicloud_vpc_subnet.test[count.index].id
+  }
+
+  depends_on = [
+    huaweicloud_networking_secgroup_rule.egress,
+    huaweicloud_networking_secgroup_rule.ssh,
+  ]
+}
+`, rName)
+}
+
+func testAccDataSourceNetworkPathAnalysis_basic(rName string, port int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "huaweicloud_network_path_analysis" "test" {
+  protocol         = "tcp"
+  destination_port = %[2]d
+
+  source {
+    port_id = huaweicloud_compute_instance.test[0].network[0].port
+  }
+
+  destination {
+    ip_address = huaweicloud_compute_instance.test[1].network[0].fixed_ip_v4
+    vpc_id     = huaweicloud_vpc.test.id
+  }
+}
+`, testAccDataSourceNetworkPathAnalysis_base(rName), port)
+}
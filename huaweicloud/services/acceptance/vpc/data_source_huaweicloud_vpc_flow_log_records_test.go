@@ -0,0 +1,80 @@
+package vpc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccDataSourceVpcFlowLogRecords_basic(t *testing.T) {
+	var (
+		rName       = acceptance.RandomAccResourceName()
+		dataSource1 = "data.huaweicloud_vpc_flow_log_records.by_flow_log"
+		dataSource2 = "data.huaweicloud_vpc_flow_log_records.by_stream"
+		dc1         = acceptance.InitDataSourceCheck(dataSource1)
+		dc2         = acceptance.InitDataSourceCheck(dataSource2)
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVpcFlowLogRecords_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					dc1.CheckResourceExists(),
+					resource.TestCheckResourceAttrSet(dataSource1, "total_bytes"),
+					resource.TestCheckResourceAttrSet(dataSource1, "total_packets"),
+					resource.TestCheckResourceAttrSet(dataSource1, "accepted_count"),
+					resource.TestCheckResourceAttrSet(dataSource1, "rejected_count"),
+					resource.TestCheckResourceAttrSet(dataSource1, "truncated"),
+					dc2.CheckResourceExists(),
+					resource.TestCheckResourceAttr(dataSource2, "accepted_count", "0"),
+					resource.TestCheckOutput("is_action_filter_useful", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVpcFlowLogRecords_basic(name string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_vpc_flow_log" "flow_log" {
+  name          = "%[2]s"
+  resource_type = "network"
+  resource_id   = huaweicloud_vpc_subnet.subnet_1.id
+  log_group_id  = huaweicloud_lts_group.acc_group.id
+  log_stream_id = huaweicloud_lts_stream.acc_stream.id
+}
+
+locals {
+  end_time   = timestamp()
+  start_time = timeadd(local.end_time, "-1h")
+}
+
+data "huaweicloud_vpc_flow_log_records" "by_flow_log" {
+  flow_log_id = huaweicloud_vpc_flow_log.flow_log.id
+  start_time  = local.start_time
+  end_time    = local.end_time
+}
+
+data "huaweicloud_vpc_flow_log_records" "by_stream" {
+  log_group_id  = huaweicloud_vpc_flow_log.flow_log.log_group_id
+  log_stream_id = huaweicloud_vpc_flow_log.flow_log.log_stream_id
+  start_time    = local.start_time
+  end_time      = local.end_time
+  action        = "REJECT"
+}
+
+output "is_action_filter_useful" {
+  value = alltrue([for v in data.huaweicloud_vpc_flow_log_records.by_stream.records : v.action == "REJECT"])
+}
+`, testAccFlowLogConfigBase(name), name)
+}
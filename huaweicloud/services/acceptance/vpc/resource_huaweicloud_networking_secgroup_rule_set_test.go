@@ -0,0 +1,146 @@
+package vpc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	v3Rules "github.com/chnsz/golangsdk/openstack/networking/v3/security/rules"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getNetworkSecGroupRuleSetResourceFunc(cfg *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	client, err := cfg.NetworkingV3Client(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("error creating VPC network v3 client: %s", err)
+	}
+
+	rules, err := v3Rules.List(client, v3Rules.ListOpts{SecurityGroupId: state.Primary.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no rules found in security group (%s)", state.Primary.ID)
+	}
+	return rules, nil
+}
+
+func TestAccNetworkingSecGroupRuleSet_basic(t *testing.T) {
+	var rules []v3Rules.SecurityGroupRule
+	resourceName := "huaweicloud_networking_secgroup_rule_set.test"
+	rName := acceptance.RandomAccResourceNameWithDash()
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&rules,
+		getNetworkSecGroupRuleSetResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingSecGroupRuleSet_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttrPair(resourceName, "security_group_id",
+						"huaweicloud_networking_secgroup.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "3"),
+				),
+			},
+			{
+				Config: testAccNetworkingSecGroupRuleSet_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNetworkingSecGroupRuleSet_base(rName string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_networking_secgroup" "test" {
+  name                 = "%[1]s"
+  delete_default_rules = true
+}
+
+resource "huaweicloud_vpc_address_group" "test" {
+  name = "%[1]s"
+
+  addresses = [
+    "192.168.10.12",
+    "192.168.11.0-192.168.11.240",
+  ]
+}
+`, rName)
+}
+
+func testAccNetworkingSecGroupRuleSet_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_networking_secgroup_rule_set" "test" {
+  security_group_id = huaweicloud_networking_secgroup.test.id
+
+  rules {
+    direction        = "ingress"
+    protocol         = "tcp"
+    ports            = "22,80,443"
+    remote_ip_prefix = "0.0.0.0/0"
+    description      = "Allow web and ssh"
+  }
+
+  rules {
+    direction               = "ingress"
+    protocol                = "tcp"
+    ports                   = "8000-8080"
+    remote_address_group_id = huaweicloud_vpc_address_group.test.id
+    action                  = "deny"
+    priority                = 10
+  }
+
+  rules {
+    direction        = "egress"
+    remote_ip_prefix = "0.0.0.0/0"
+  }
+}
+`, testAccNetworkingSecGroupRuleSet_base(rName))
+}
+
+func testAccNetworkingSecGroupRuleSet_update(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_networking_secgroup_rule_set" "test" {
+  security_group_id = huaweicloud_networking_secgroup.test.id
+
+  rules {
+    direction        = "ingress"
+    protocol         = "tcp"
+    ports            = "22,80,443"
+    remote_ip_prefix = "0.0.0.0/0"
+    description      = "Allow web and ssh"
+  }
+
+  rules {
+    direction        = "ingress"
+    ethertype        = "IPv6"
+    protocol         = "icmpv6"
+    remote_ip_prefix = "::/0"
+  }
+}
+`, testAccNetworkingSecGroupRuleSet_base(rName))
+}
@@ -91,3 +91,61 @@ resource "huaweicloud_vpc_peering_connection" "test" {
 }
 `, vpcName, peerName, desc)
 }
+
+func TestAccVpcPeeringConnection_peerAssumeRole(t *testing.T) {
+	var peering peerings.Peering
+
+	randName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_vpc_peering_connection.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&peering,
+		getPeeringConnectionResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckPeerAssumeRole(t)
+			acceptance.TestAccPreCheckPeerVpc(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcPeeringConnection_peerAssumeRole(randName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", randName),
+					resource.TestCheckResourceAttr(resourceName, "peer_vpc_id", acceptance.HW_PEER_VPC_ID),
+					resource.TestCheckResourceAttr(resourceName, "peer_tenant_id", acceptance.HW_PEER_PROJECT_ID),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+					resource.TestCheckResourceAttr(resourceName, "peer_status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVpcPeeringConnection_peerAssumeRole(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "172.16.0.0/20"
+}
+
+resource "huaweicloud_vpc_peering_connection" "test" {
+  name           = "%[1]s"
+  vpc_id         = huaweicloud_vpc.test.id
+  peer_vpc_id    = "%[2]s"
+  peer_tenant_id = "%[3]s"
+
+  peer_assume_role {
+    agency_name = "%[4]s"
+    domain_name = "%[5]s"
+  }
+}
+`, name, acceptance.HW_PEER_VPC_ID, acceptance.HW_PEER_PROJECT_ID, acceptance.HW_PEER_AGENCY_NAME,
+		acceptance.HW_PEER_DOMAIN_NAME)
+}
@@ -3,6 +3,7 @@ package vpc
 import (
 	"errors"
 	"fmt"
+	"net"
 	"regexp"
 	"testing"
 
@@ -149,6 +150,37 @@ func TestAccVpcSubnetV1_dhcp(t *testing.T) {
 	})
 }
 
+func TestAccVpcSubnetV1_cidrAllocation(t *testing.T) {
+	var subnet subnets.Subnet
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "huaweicloud_vpc_subnet.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckVpcSubnetV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcSubnetV1_cidrAllocation(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcSubnetV1Exists(resourceName, &subnet),
+					resource.TestCheckResourceAttr("huaweicloud_vpc_subnet.fixed", "cidr", "192.168.0.0/24"),
+					resource.TestCheckResourceAttr("huaweicloud_vpc_subnet.fixed", "gateway_ip", "192.168.0.1"),
+					// The allocated CIDRs must not overlap with the fixed one and each other.
+					resource.TestCheckResourceAttr(resourceName, "cidr_prefix_length", "24"),
+					resource.TestMatchResourceAttr(resourceName, "cidr", regexp.MustCompile(`^192\.168\.[12]\.0/24$`)),
+					resource.TestMatchResourceAttr("huaweicloud_vpc_subnet.another", "cidr",
+						regexp.MustCompile(`^192\.168\.[12]\.0/24$`)),
+					resource.TestMatchResourceAttr(resourceName, "gateway_ip", regexp.MustCompile(`^192\.168\.[12]\.1$`)),
+					testAccCheckVpcSubnetCidrsNotOverlapped("huaweicloud_vpc_subnet.fixed", resourceName,
+						"huaweicloud_vpc_subnet.another"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckVpcSubnetV1Destroy(s *terraform.State) error {
 	cfg := acceptance.TestAccProvider.Meta().(*config.Config)
 	subnetClient, err := cfg.NetworkingV1Client(acceptance.HW_REGION_NAME)
@@ -202,6 +234,34 @@ func testAccCheckVpcSubnetV1Exists(n string, subnet *subnets.Subnet) resource.Te
 	}
 }
 
+// testAccCheckVpcSubnetCidrsNotOverlapped checks that the CIDRs of the subnets are different and do not overlap.
+func testAccCheckVpcSubnetCidrsNotOverlapped(names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		cidrs := make([]*net.IPNet, len(names))
+		for i, name := range names {
+			rs, ok := s.RootModule().Resources[name]
+			if !ok {
+				return fmt.Errorf("the subnet %s not found", name)
+			}
+			_, cidr, err := net.ParseCIDR(rs.Primary.Attributes["cidr"])
+			if err != nil {
+				return fmt.Errorf("invalid CIDR of the subnet %s: %s", name, err)
+			}
+			cidrs[i] = cidr
+		}
+
+		for i := 0; i < len(cidrs); i++ {
+			for j := i + 1; j < len(cidrs); j++ {
+				if cidrs[i].Contains(cidrs[j].IP) || cidrs[j].Contains(cidrs[i].IP) {
+					return fmt.Errorf("the CIDR (%s) of the subnet %s overlaps with the CIDR (%s) of the subnet %s",
+						cidrs[i], names[i], cidrs[j], names[j])
+				}
+			}
+		}
+		return nil
+	}
+}
+
 func testAccVpcSubnet_base(rName string) string {
 	return fmt.Sprintf(`
 data "huaweicloud_availability_zones" "test" {}
@@ -312,3 +372,32 @@ resource "huaweicloud_vpc_subnet" "test" {
 }
 `, testAccVpcSubnet_base(rName), rName)
 }
+
+func testAccVpcSubnetV1_cidrAllocation(rName string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "huaweicloud_vpc_subnet" "fixed" {
+  name       = "%[2]s-fixed"
+  cidr       = "192.168.0.0/24"
+  gateway_ip = "192.168.0.1"
+  vpc_id     = huaweicloud_vpc.test.id
+}
+
+resource "huaweicloud_vpc_subnet" "test" {
+  name               = "%[2]s"
+  cidr_prefix_length = 24
+  vpc_id             = huaweicloud_vpc.test.id
+
+  depends_on = [huaweicloud_vpc_subnet.fixed]
+}
+
+resource "huaweicloud_vpc_subnet" "another" {
+  name               = "%[2]s-another"
+  cidr_prefix_length = 24
+  vpc_id             = huaweicloud_vpc.test.id
+
+  depends_on = [huaweicloud_vpc_subnet.fixed]
+}
+`, testAccVpcSubnet_base(rName), rName)
+}
@@ -0,0 +1,393 @@
+package cce
+
+// This set of code handles the plan-time validation of the values of an huaweicloud_cce_addon resource.
+//
+// The input of the add-on template contains the default values of the add-on, the structure is as follows:
+//
+//	{
+//	  "basic": {...},
+//	  "parameters": {
+//	    "custom": {...},
+//	    "flavor1": {...},
+//	    "flavor2": {...}
+//	  }
+//	}
+//
+// The values configured by the user are checked against the default values (the type of a parameter must be the same
+// as the default value, and the parameters which are not defined by the template are reported as warnings), and then
+// merged with the default values to generate the effective values of the add-on. The effective values are always based
+// on the template defaults, so a parameter removed from the configuration is restored to its default value.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk/openstack/cce/v3/addons"
+	"github.com/chnsz/golangsdk/openstack/cce/v3/templates"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func resourceAddonEffectiveValuesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"basic_json": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"custom_json": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"flavor_json": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// addonValue is a part (basic, custom or flavor) of the add-on values configured by the user.
+type addonValue struct {
+	// The name of the parameter which the value comes from, e.g. values.0.basic_json.
+	Key   string
+	Value map[string]interface{}
+	// Whether the value is configured as a JSON string, the type of the map elements is always string.
+	IsJson bool
+}
+
+func parseAddonValue(valuesMap map[string]interface{}, name string) (addonValue, error) {
+	if raw := valuesMap[name].(map[string]interface{}); len(raw) != 0 {
+		return addonValue{Key: "values.0." + name, Value: raw}, nil
+	}
+
+	result := addonValue{Key: fmt.Sprintf("values.0.%s_json", name), IsJson: true}
+	if jsonRaw := valuesMap[name+"_json"].(string); jsonRaw != "" {
+		if err := json.Unmarshal([]byte(jsonRaw), &result.Value); err != nil {
+			return result, fmt.Errorf("error unmarshalling %s json: %s", name, err)
+		}
+	}
+	return result, nil
+}
+
+func parseAddonValues(values []interface{}) (basic, custom, flavor addonValue, err error) {
+	valuesMap := map[string]interface{}{
+		"basic": map[string]interface{}{}, "basic_json": "",
+		"custom": map[string]interface{}{}, "custom_json": "",
+		"flavor": map[string]interface{}{}, "flavor_json": "",
+	}
+	if len(values) > 0 && values[0] != nil {
+		valuesMap = values[0].(map[string]interface{})
+	}
+
+	if basic, err = parseAddonValue(valuesMap, "basic"); err != nil {
+		return
+	}
+	if custom, err = parseAddonValue(valuesMap, "custom"); err != nil {
+		return
+	}
+	flavor, err = parseAddonValue(valuesMap, "flavor")
+	return
+}
+
+func getAddonTemplateInput(cfg *config.Config, region, clusterId, name, version string) (map[string]interface{}, error) {
+	client, err := cfg.CceAddonV3Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating CCE v3 Client (without project): %s", err)
+	}
+
+	templateList, err := templates.List(client, clusterId).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve template list: %s", err)
+	}
+
+	template, err := getTemplateByNameAndVersion(templateList, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var input map[string]interface{}
+	if err = json.Unmarshal([]byte(template.Spec), &input); err != nil {
+		return nil, fmt.Errorf("error unmarshalling the input of the add-on template: %s", err)
+	}
+	return input, nil
+}
+
+func getJsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case float64, json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return reflect.TypeOf(v).String()
+	}
+}
+
+// validateAddonValue checks whether the types of the parameters are the same as the template defaults, and returns
+// the parameters which are not defined by the template as warnings. The template objects without any parameters
+// accept any keys.
+func validateAddonValue(path string, value, defaults map[string]interface{}, isJson bool) ([]string, error) {
+	var (
+		warnings []string
+		mErr     *multierror.Error
+	)
+	keys := make([]string, 0, len(value))
+	for k := range value {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		subPath := fmt.Sprintf("%s.%s", path, k)
+		defaultValue, ok := defaults[k]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("%s is not defined by the add-on template", subPath))
+			continue
+		}
+		if !isJson || defaultValue == nil || value[k] == nil {
+			continue
+		}
+
+		if getJsonTypeName(value[k]) != getJsonTypeName(defaultValue) {
+			mErr = multierror.Append(mErr, fmt.Errorf("%s must be of type %s, but got %s", subPath,
+				getJsonTypeName(defaultValue), getJsonTypeName(value[k])))
+			continue
+		}
+
+		subValue, isObject := value[k].(map[string]interface{})
+		subDefaults, _ := defaultValue.(map[string]interface{})
+		if isObject && len(subDefaults) > 0 {
+			subWarnings, err := validateAddonValue(subPath, subValue, subDefaults, isJson)
+			warnings = append(warnings, subWarnings...)
+			if err != nil {
+				mErr = multierror.Append(mErr, err)
+			}
+		}
+	}
+	return warnings, mErr.ErrorOrNil()
+}
+
+// validateAddonFlavor checks whether the flavor matches one of the flavors (flavor1, flavor2, ...) of the template,
+// the flavor with the fewest warnings is chosen.
+func validateAddonFlavor(flavor addonValue, parameters map[string]interface{}) ([]string, error) {
+	if len(flavor.Value) == 0 {
+		return nil, nil
+	}
+
+	var (
+		matched  bool
+		warnings []string
+		firstErr error
+	)
+	for k, v := range parameters {
+		flavorDefaults, ok := v.(map[string]interface{})
+		if !strings.HasPrefix(k, "flavor") || !ok {
+			continue
+		}
+		flavorWarnings, err := validateAddonValue(flavor.Key, flavor.Value, flavorDefaults, flavor.IsJson)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if !matched || len(flavorWarnings) < len(warnings) {
+			matched, warnings = true, flavorWarnings
+		}
+	}
+	if matched {
+		return warnings, nil
+	}
+	return nil, firstErr
+}
+
+// mergeAddonValue returns a copy of the defaults, which is deeply merged with the value.
+func mergeAddonValue(defaults, value map[string]interface{}) map[string]interface{} {
+	if defaults == nil && value == nil {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(defaults))
+	for k, v := range defaults {
+		result[k] = v
+	}
+	for k, v := range value {
+		subValue, isObject := v.(map[string]interface{})
+		subDefaults, isDefaultObject := result[k].(map[string]interface{})
+		if isObject && isDefaultObject {
+			result[k] = mergeAddonValue(subDefaults, subValue)
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+func flattenAddonEffectiveValues(values addons.Values) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"basic_json":  utils.JsonToString(values.Basic),
+			"custom_json": utils.JsonToString(values.Custom),
+			"flavor_json": utils.JsonToString(values.Flavor),
+		},
+	}
+}
+
+// buildAddonEffectiveValues returns the effective values planned by the CustomizeDiff, and returns nil if the
+// effective values are unknown.
+func buildAddonEffectiveValues(d *schema.ResourceData) (*addons.Values, error) {
+	effectiveValues := d.Get("effective_values").([]interface{})
+	if len(effectiveValues) == 0 || effectiveValues[0] == nil {
+		return nil, nil
+	}
+
+	var result addons.Values
+	valuesMap := effectiveValues[0].(map[string]interface{})
+	for k, v := range map[string]*map[string]interface{}{
+		"basic_json":  &result.Basic,
+		"custom_json": &result.Custom,
+		"flavor_json": &result.Flavor,
+	} {
+		jsonRaw := valuesMap[k].(string)
+		if jsonRaw == "" || jsonRaw == "null" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(jsonRaw), v); err != nil {
+			return nil, fmt.Errorf("error unmarshalling the effective values (%s): %s", k, err)
+		}
+	}
+	if result.Basic == nil {
+		result.Basic = make(map[string]interface{})
+	}
+	return &result, nil
+}
+
+// checkAddonValues validates the values against the template of the add-on version, and returns the parameters which
+// are not defined by the template as warnings.
+func checkAddonValues(cfg *config.Config, region, clusterId, templateName, version string, basic, custom,
+	flavor addonValue) (input map[string]interface{}, warnings []string, err error) {
+	input, err = getAddonTemplateInput(cfg, region, clusterId, templateName, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	basicDefaults := utils.PathSearch("basic", input, make(map[string]interface{})).(map[string]interface{})
+	parameters := utils.PathSearch("parameters", input, make(map[string]interface{})).(map[string]interface{})
+	customDefaults := utils.PathSearch("custom", parameters, make(map[string]interface{})).(map[string]interface{})
+
+	basicWarnings, basicErr := validateAddonValue(basic.Key, basic.Value, basicDefaults, basic.IsJson)
+	customWarnings, customErr := validateAddonValue(custom.Key, custom.Value, customDefaults, custom.IsJson)
+	flavorWarnings, flavorErr := validateAddonFlavor(flavor, parameters)
+	warnings = append(append(basicWarnings, customWarnings...), flavorWarnings...)
+	return input, warnings, multierror.Append(nil, basicErr, customErr, flavorErr).ErrorOrNil()
+}
+
+// buildAddonValuesWarnings returns the parameters which are not defined by the template of the add-on version as
+// warning diagnostics, the parameters are still sent to the API as they are.
+func buildAddonValuesWarnings(cfg *config.Config, d *schema.ResourceData) diag.Diagnostics {
+	basic, custom, flavor, err := parseAddonValues(d.Get("values").([]interface{}))
+	if err != nil {
+		return nil
+	}
+
+	templateName := d.Get("template_name").(string)
+	_, warnings, _ := checkAddonValues(cfg, cfg.GetRegion(d), d.Get("cluster_id").(string), templateName,
+		d.Get("version").(string), basic, custom, flavor)
+
+	var diags diag.Diagnostics
+	for _, warning := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The add-on value is not defined by the template",
+			Detail:   fmt.Sprintf("%s, it is sent to the add-on (%s) as it is.", warning, templateName),
+		})
+	}
+	return diags
+}
+
+// addonValuesKeys are the nested keys of the values block.
+var addonValuesKeys = []string{"basic", "basic_json", "custom", "custom_json", "flavor", "flavor_json"}
+
+// validateAddonValues validates the values against the template of the add-on version in plan time, and merges them
+// with the template defaults to plan the effective values. If the version is upgraded, the values are validated
+// against the template of the new version.
+func validateAddonValues(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("values", "version") {
+		return nil
+	}
+
+	version := d.Get("version").(string)
+	if !d.NewValueKnown("values") || !d.NewValueKnown("version") || !d.NewValueKnown("cluster_id") || version == "" {
+		// The template can not be determined, the values will be sent as they are.
+		return d.SetNewComputed("effective_values")
+	}
+	// The unknown nested values are read as empty, so the effective values can not be planned from them.
+	for _, key := range addonValuesKeys {
+		if !d.NewValueKnown("values.0." + key) {
+			return d.SetNewComputed("effective_values")
+		}
+	}
+
+	basic, custom, flavor, err := parseAddonValues(d.Get("values").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	cfg := meta.(*config.Config)
+	region := d.Get("region").(string)
+	if region == "" {
+		region = cfg.Region
+	}
+	templateName := d.Get("template_name").(string)
+	input, warnings, err := checkAddonValues(cfg, region, d.Get("cluster_id").(string), templateName, version,
+		basic, custom, flavor)
+	if input == nil {
+		// The validation is best-effort, the API will return the error if the values are invalid.
+		log.Printf("[WARN] unable to get the template of the add-on (%s) version (%s): %s", templateName, version, err)
+		return d.SetNewComputed("effective_values")
+	}
+	if err != nil {
+		if d.Id() != "" && d.HasChange("version") {
+			return fmt.Errorf("the values are not compatible with version (%s) of the add-on (%s): %s",
+				version, templateName, err)
+		}
+		return fmt.Errorf("the values do not match the template of the add-on (%s) version (%s): %s",
+			templateName, version, err)
+	}
+	for _, warning := range warnings {
+		log.Printf("[WARN] %s", warning)
+	}
+
+	basicDefaults := utils.PathSearch("basic", input, make(map[string]interface{})).(map[string]interface{})
+	customDefaults := utils.PathSearch("parameters.custom", input,
+		make(map[string]interface{})).(map[string]interface{})
+	effectiveValues := addons.Values{
+		Basic:  mergeAddonValue(basicDefaults, basic.Value),
+		Custom: mergeAddonValue(customDefaults, custom.Value),
+		Flavor: flavor.Value,
+	}
+	return d.SetNew("effective_values", flattenAddonEffectiveValues(effectiveValues))
+}
@@ -0,0 +1,443 @@
+package cce
+
+// This set of code handles the rolling replacement of the nodes of an huaweicloud_cce_node_pool resource.
+//
+// When the node template (OS, flavor, runtime or pre-install script) is changed, the node pool template is updated
+// first, which only affects new nodes. Then the existing nodes are replaced batch by batch:
+//  1. scale out the node pool by `max_surge` nodes, which are created with the new template;
+//  2. cordon and drain a batch of old nodes through the Kubernetes API, the eviction API respects the
+//     PodDisruptionBudgets, so the eviction is retried until the budget allows it or the drain timeout is reached;
+//  3. remove the drained nodes from the cluster;
+//  4. scale the node pool back to the original node count.
+//
+// The IDs of the old nodes which have not been replaced are recorded in `rolling_update_pending_nodes`, if a batch
+// fails, the next apply skips the replaced nodes and resumes the rolling update from the failed batch.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/cce/v3/addons"
+	"github.com/chnsz/golangsdk/openstack/cce/v3/nodes"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// nodePoolRollingUpdateParams are the parameters which can be updated by replacing the nodes when `rolling_update` is
+// configured, otherwise they are non-updatable.
+var nodePoolRollingUpdateParams = []string{"os", "flavor_id", "runtime", "preinstall", "extend_params.0.preinstall"}
+
+func resourceNodePoolRollingUpdateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_surge": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  1,
+				},
+				"max_unavailable": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  0,
+				},
+				"cordon_timeout": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  60,
+				},
+				"drain_timeout": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  600,
+				},
+			},
+		},
+	}
+}
+
+// nodePoolForceNew makes the node template parameters updatable when `rolling_update` is configured.
+func nodePoolForceNew() schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		keys := nodePoolNonUpdatableParams
+		if len(d.Get("rolling_update").([]interface{})) > 0 {
+			keys = make([]string, 0, len(nodePoolNonUpdatableParams))
+			for _, k := range nodePoolNonUpdatableParams {
+				if !utils.StrSliceContains(nodePoolRollingUpdateParams, k) {
+					keys = append(keys, k)
+				}
+			}
+
+			maxSurge := d.Get("rolling_update.0.max_surge").(int)
+			maxUnavailable := d.Get("rolling_update.0.max_unavailable").(int)
+			if maxSurge < 0 || maxUnavailable < 0 || maxSurge+maxUnavailable == 0 {
+				return fmt.Errorf("`max_surge` and `max_unavailable` of `rolling_update` must be non-negative and " +
+					"at least one of them must be greater than 0")
+			}
+			// Resume the rolling update which is interrupted by the last apply.
+			if len(d.Get("rolling_update_pending_nodes").([]interface{})) > 0 {
+				if err := d.SetNewComputed("rolling_update_pending_nodes"); err != nil {
+					return err
+				}
+			}
+		}
+		return config.FlexibleForceNew(keys, nodePoolSchema)(ctx, d, meta)
+	}
+}
+
+func isNodePoolRollingUpdateRequired(d *schema.ResourceData) bool {
+	if len(d.Get("rolling_update").([]interface{})) == 0 {
+		return false
+	}
+	pendingNodeIds, _ := d.GetChange("rolling_update_pending_nodes")
+	return d.HasChanges(nodePoolRollingUpdateParams...) || len(pendingNodeIds.([]interface{})) > 0
+}
+
+type nodePoolNode struct {
+	ID        string
+	Name      string
+	PrivateIP string
+}
+
+func listNodePoolNodes(client *golangsdk.ServiceClient, clusterId, nodePoolId string) ([]nodePoolNode, error) {
+	listNodesHttpUrl := "api/v3/projects/{project_id}/clusters/{cluster_id}/nodes"
+	listNodesPath := client.Endpoint + listNodesHttpUrl
+	listNodesPath = strings.ReplaceAll(listNodesPath, "{project_id}", client.ProjectID)
+	listNodesPath = strings.ReplaceAll(listNodesPath, "{cluster_id}", clusterId)
+
+	listNodesOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	listNodesResp, err := client.Request("GET", listNodesPath, &listNodesOpt)
+	if err != nil {
+		return nil, fmt.Errorf("error getting nodes: %s", err)
+	}
+
+	listNodesRespBody, err := utils.FlattenResponse(listNodesResp)
+	if err != nil {
+		return nil, err
+	}
+
+	jpath := fmt.Sprintf(`items[?metadata.annotations."kubernetes.io/node-pool.id"=='%s']`, nodePoolId)
+	nodeListRaw := utils.PathSearch(jpath, listNodesRespBody, make([]interface{}, 0)).([]interface{})
+	result := make([]nodePoolNode, 0, len(nodeListRaw))
+	for _, v := range nodeListRaw {
+		result = append(result, nodePoolNode{
+			ID:        utils.PathSearch("metadata.uid", v, "").(string),
+			Name:      utils.PathSearch("metadata.name", v, "").(string),
+			PrivateIP: utils.PathSearch("status.privateIP", v, "").(string),
+		})
+	}
+	return result, nil
+}
+
+func scaleNodePool(ctx context.Context, client *golangsdk.ServiceClient, clusterId, nodePoolId string, count int,
+	timeout time.Duration) error {
+	scaleHttpUrl := "api/v3/projects/{project_id}/clusters/{cluster_id}/nodepools/{nodepool_id}/operation/scale"
+	scalePath := client.Endpoint + scaleHttpUrl
+	scalePath = strings.ReplaceAll(scalePath, "{project_id}", client.ProjectID)
+	scalePath = strings.ReplaceAll(scalePath, "{cluster_id}", clusterId)
+	scalePath = strings.ReplaceAll(scalePath, "{nodepool_id}", nodePoolId)
+
+	scaleOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody: map[string]interface{}{
+			"kind":       "NodePool",
+			"apiVersion": "v3",
+			"spec": map[string]interface{}{
+				"desiredNodeCount": count,
+				"scaleGroups":      []string{"default"},
+			},
+		},
+	}
+	_, err := client.Request("POST", scalePath, &scaleOpt)
+	if err != nil {
+		return fmt.Errorf("error scaling CCE node pool (%s) to %d nodes: %s", nodePoolId, count, err)
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:      []string{"PENDING"},
+		Target:       []string{"COMPLETED"},
+		Refresh:      nodePoolStateRefreshFunc(client, clusterId, nodePoolId, []string{""}),
+		Timeout:      timeout,
+		Delay:        90 * time.Second,
+		PollInterval: 20 * time.Second,
+	}
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for CCE node pool (%s) to be scaled: %s", nodePoolId, err)
+	}
+	return nil
+}
+
+func removeNodePoolNodes(ctx context.Context, client *golangsdk.ServiceClient, clusterId string,
+	removedNodes []nodePoolNode, timeout time.Duration) error {
+	removeHttpUrl := "api/v3/projects/{project_id}/clusters/{cluster_id}/nodes/operation/remove"
+	removePath := client.Endpoint + removeHttpUrl
+	removePath = strings.ReplaceAll(removePath, "{project_id}", client.ProjectID)
+	removePath = strings.ReplaceAll(removePath, "{cluster_id}", clusterId)
+
+	nodeList := make([]map[string]interface{}, len(removedNodes))
+	for i, node := range removedNodes {
+		nodeList[i] = map[string]interface{}{
+			"uid": node.ID,
+		}
+	}
+	removeOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody: map[string]interface{}{
+			"kind":       "RemoveNodesTask",
+			"apiVersion": "v3",
+			"spec": map[string]interface{}{
+				"nodes": nodeList,
+			},
+		},
+	}
+	removeResp, err := client.Request("PUT", removePath, &removeOpt)
+	if err != nil {
+		return fmt.Errorf("error removing nodes: %s", err)
+	}
+
+	removeRespBody, err := utils.FlattenResponse(removeResp)
+	if err != nil {
+		return err
+	}
+
+	jobId := utils.PathSearch("status.jobID", removeRespBody, "").(string)
+	if jobId == "" {
+		return fmt.Errorf("error removing nodes: status.jobID is not found in API response")
+	}
+
+	stateJob := &retry.StateChangeConf{
+		Pending:      []string{"Initializing", "Running"},
+		Target:       []string{"Success"},
+		Refresh:      waitForJobStatus(client, jobId),
+		Timeout:      timeout,
+		Delay:        30 * time.Second,
+		PollInterval: 15 * time.Second,
+	}
+	v, err := stateJob.WaitForStateContext(ctx)
+	if err != nil {
+		if job, ok := v.(*nodes.Job); ok {
+			return fmt.Errorf("error waiting for job (%s) to become success: %s, reason: %s",
+				jobId, err, job.Status.Reason)
+		}
+		return fmt.Errorf("error waiting for job (%s) to become success: %s", jobId, err)
+	}
+	return nil
+}
+
+// cordonKubernetesNode marks the node as unschedulable through the Kubernetes API of the cluster.
+func cordonKubernetesNode(ctx context.Context, client *golangsdk.ServiceClient, clusterId, nodeName string,
+	timeout time.Duration) error {
+	cordonPath := addons.CCEServiceURL(client, clusterId, "nodes", nodeName)
+	cordonOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/merge-patch+json"},
+		JSONBody: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"unschedulable": true,
+			},
+		},
+	}
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		_, err := client.Request("PATCH", cordonPath, &cordonOpt)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		return nil
+	})
+}
+
+// drainKubernetesNode evicts all pods (except the DaemonSet and mirror pods) from the node. The eviction is rejected
+// with status code 429 when it would violate a PodDisruptionBudget, in which case it will be retried until the
+// timeout is reached.
+func drainKubernetesNode(ctx context.Context, client *golangsdk.ServiceClient, clusterId, nodeName string,
+	timeout time.Duration) error {
+	listPodsPath := addons.CCEServiceURL(client, clusterId, "pods")
+	listPodsPath += fmt.Sprintf("?fieldSelector=spec.nodeName=%s", nodeName)
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		listOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+		}
+		listResp, err := client.Request("GET", listPodsPath, &listOpt)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		listRespBody, err := utils.FlattenResponse(listResp)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		// Ignore the pods managed by DaemonSets, the mirror pods, and the pods that have been terminated.
+		pods := utils.PathSearch(`items[?!(metadata.ownerReferences[?kind=='DaemonSet']) && `+
+			`!(metadata.annotations."kubernetes.io/config.mirror") && `+
+			`status.phase!='Succeeded' && status.phase!='Failed']`, listRespBody, make([]interface{}, 0)).([]interface{})
+		if len(pods) == 0 {
+			return nil
+		}
+
+		for _, pod := range pods {
+			name := utils.PathSearch("metadata.name", pod, "").(string)
+			namespace := utils.PathSearch("metadata.namespace", pod, "").(string)
+			evictPath := addons.CCEServiceURL(client, clusterId, "namespaces", namespace, "pods", name, "eviction")
+			evictOpt := golangsdk.RequestOpts{
+				KeepResponseBody: true,
+				JSONBody: map[string]interface{}{
+					"apiVersion": "policy/v1",
+					"kind":       "Eviction",
+					"metadata": map[string]interface{}{
+						"name":      name,
+						"namespace": namespace,
+					},
+				},
+			}
+			_, err := client.Request("POST", evictPath, &evictOpt)
+			if err == nil {
+				continue
+			}
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				continue
+			}
+			if _, ok := err.(golangsdk.ErrDefault429); ok {
+				log.Printf("[DEBUG] the eviction of pod (%s/%s) is blocked by the PodDisruptionBudget", namespace, name)
+				continue
+			}
+			return retry.RetryableError(err)
+		}
+		return retry.RetryableError(fmt.Errorf("waiting for %d pods to be evicted from node (%s)", len(pods), nodeName))
+	})
+}
+
+// rollingUpdateNodePool replaces the stale nodes batch by batch. The IDs of the nodes which have not been replaced
+// are recorded in `rolling_update_pending_nodes` after each batch, so that the rolling update can be resumed from the
+// failed batch by the next apply.
+func rollingUpdateNodePool(ctx context.Context, d *schema.ResourceData, cfg *config.Config,
+	staleNodes []nodePoolNode, nodeCount int) diag.Diagnostics {
+	region := cfg.GetRegion(d)
+	cceClient, err := cfg.CceV3Client(region)
+	if err != nil {
+		return diag.Errorf("error creating CCE v3 client: %s", err)
+	}
+	k8sClient, err := cfg.CceV1Client(region)
+	if err != nil {
+		return diag.Errorf("error creating CCE v1 client: %s", err)
+	}
+
+	var (
+		clusterId      = d.Get("cluster_id").(string)
+		nodePoolId     = d.Id()
+		maxSurge       = d.Get("rolling_update.0.max_surge").(int)
+		maxUnavailable = d.Get("rolling_update.0.max_unavailable").(int)
+		cordonTimeout  = time.Duration(d.Get("rolling_update.0.cordon_timeout").(int)) * time.Second
+		drainTimeout   = time.Duration(d.Get("rolling_update.0.drain_timeout").(int)) * time.Second
+		timeout        = d.Timeout(schema.TimeoutUpdate)
+		total          = len(staleNodes)
+		replaced       = 0
+		diags          diag.Diagnostics
+	)
+
+	if err := setNodePoolPendingNodes(d, staleNodes); err != nil {
+		return diag.FromErr(err)
+	}
+	for len(staleNodes) > 0 {
+		batchSize := maxSurge + maxUnavailable
+		if batchSize > len(staleNodes) {
+			batchSize = len(staleNodes)
+		}
+		surge := maxSurge
+		if surge > batchSize {
+			surge = batchSize
+		}
+		batch := staleNodes[:batchSize]
+
+		if surge > 0 {
+			if err := scaleNodePool(ctx, cceClient, clusterId, nodePoolId, nodeCount+surge, timeout); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+
+		for _, node := range batch {
+			nodeName := node.PrivateIP
+			if nodeName == "" {
+				nodeName = node.Name
+			}
+			if err := cordonKubernetesNode(ctx, k8sClient, clusterId, nodeName, cordonTimeout); err != nil {
+				return append(diags, diag.Errorf("error cordoning node (%s): %s", nodeName, err)...)
+			}
+			if err := drainKubernetesNode(ctx, k8sClient, clusterId, nodeName, drainTimeout); err != nil {
+				return append(diags, diag.Errorf("error draining node (%s): %s", nodeName, err)...)
+			}
+		}
+
+		if err := removeNodePoolNodes(ctx, cceClient, clusterId, batch, timeout); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		// Restore the node count if the removed nodes are more than the surged nodes.
+		if batchSize > surge {
+			if err := scaleNodePool(ctx, cceClient, clusterId, nodePoolId, nodeCount, timeout); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+
+		staleNodes = staleNodes[batchSize:]
+		if err := setNodePoolPendingNodes(d, staleNodes); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		replaced += batchSize
+		progress := fmt.Sprintf("rolling update of CCE node pool (%s): %d/%d nodes replaced", nodePoolId, replaced, total)
+		log.Printf("[INFO] %s", progress)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  progress,
+		})
+	}
+
+	return diags
+}
+
+func setNodePoolPendingNodes(d *schema.ResourceData, pendingNodes []nodePoolNode) error {
+	nodeIds := make([]string, len(pendingNodes))
+	for i, node := range pendingNodes {
+		nodeIds[i] = node.ID
+	}
+	return d.Set("rolling_update_pending_nodes", nodeIds)
+}
+
+// listNodePoolStaleNodes returns all nodes of the node pool and the nodes which need to be replaced. When the node
+// template is changed, all existing nodes are stale, otherwise only the nodes left by the interrupted rolling update
+// need to be replaced.
+func listNodePoolStaleNodes(client *golangsdk.ServiceClient, d *schema.ResourceData) ([]nodePoolNode,
+	[]nodePoolNode, error) {
+	allNodes, err := listNodePoolNodes(client, d.Get("cluster_id").(string), d.Id())
+	if err != nil {
+		return nil, nil, err
+	}
+	if d.HasChanges(nodePoolRollingUpdateParams...) {
+		return allNodes, allNodes, nil
+	}
+
+	pendingNodeIds, _ := d.GetChange("rolling_update_pending_nodes")
+	staleNodes := make([]nodePoolNode, 0)
+	for _, node := range allNodes {
+		if utils.StrSliceContains(utils.ExpandToStringList(pendingNodeIds.([]interface{})), node.ID) {
+			staleNodes = append(staleNodes, node)
+		}
+	}
+	return allNodes, staleNodes, nil
+}
@@ -3,11 +3,14 @@ package cce
 import (
 	"context"
 	"log"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/chnsz/golangsdk/openstack/cce/v3/clusters"
 
@@ -15,7 +18,13 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
+const (
+	clusterCertModeCertificate = "certificate"
+	clusterCertModeExec        = "exec"
+)
+
 // @API CCE POST /api/v3/projects/{project_id}/clusters/{id}/clustercert
+// @API CCE GET /api/v3/projects/{project_id}/clusters/{id}
 func DataSourceCCEClusterCertificate() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceCCEClusterCertificateRead,
@@ -32,7 +41,46 @@ func DataSourceCCEClusterCertificate() *schema.Resource {
 			},
 			"duration": {
 				Type:     schema.TypeInt,
-				Required: true,
+				Optional: true,
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      clusterCertModeCertificate,
+				ValidateFunc: validation.StringInSlice([]string{clusterCertModeCertificate, clusterCertModeExec}, false),
+			},
+			"exec": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				RequiredWith: []string{"mode"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"args": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"env": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"api_version": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "client.authentication.k8s.io/v1beta1",
+						},
+						"certificate_authority_data": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
 			},
 			"kube_config_raw": {
 				Type:     schema.TypeString,
@@ -121,22 +169,46 @@ func dataSourceCCEClusterCertificateRead(_ context.Context, d *schema.ResourceDa
 	}
 
 	clusterID := d.Get("cluster_id").(string)
-
-	opts := clusters.GetCertOpts{
-		Duration: d.Get("duration").(int),
+	mode := d.Get("mode").(string)
+	hasExec := len(d.Get("exec").([]interface{})) > 0
+	if mode == clusterCertModeExec && !hasExec {
+		return diag.Errorf("the exec block must be specified when the mode is %s", clusterCertModeExec)
 	}
-	r := clusters.GetCert(cceClient, clusterID, opts)
-	kubeConfigRaw, err := utils.JsonMarshal(r.Body)
-
-	if err != nil {
-		log.Printf("error marshaling r.Body: %s", err)
+	if mode != clusterCertModeExec && hasExec {
+		return diag.Errorf("the exec block can only be specified when the mode is %s", clusterCertModeExec)
+	}
+	// The duration is only used to issue the client certificate.
+	if mode == clusterCertModeCertificate && d.Get("duration").(int) == 0 {
+		return diag.Errorf("the duration must be specified when the mode is %s", clusterCertModeCertificate)
 	}
 
-	mErr := multierror.Append(nil, d.Set("kube_config_raw", string(kubeConfigRaw)))
+	var (
+		cert       *clusters.Certificate
+		kubeConfig interface{}
+	)
+	if mode == clusterCertModeExec {
+		// No client certificate is issued, the kubeconfig calls out to the token helper to obtain the credentials.
+		cluster, err := clusters.Get(cceClient, clusterID).Extract()
+		if err != nil {
+			return diag.Errorf("error retrieving CCE cluster (%s): %s", clusterID, err)
+		}
+		cert = buildClusterExecCertificate(cluster, d.Get("exec.0.certificate_authority_data").(string))
+		kubeConfig = buildClusterCertExecKubeConfig(d, cert)
+	} else {
+		opts := clusters.GetCertOpts{
+			Duration: d.Get("duration").(int),
+		}
+		r := clusters.GetCert(cceClient, clusterID, opts)
+		cert, err = r.Extract()
+		if err != nil {
+			return diag.Errorf("unable to retrieve CCE cluster cert: %s", err)
+		}
+		kubeConfig = r.Body
+	}
 
-	cert, err := r.Extract()
+	kubeConfigRaw, err := utils.JsonMarshal(kubeConfig)
 	if err != nil {
-		return diag.Errorf("unable to retrieve CCE cluster cert: %s", err)
+		log.Printf("error marshaling r.Body: %s", err)
 	}
 
 	dataSourceId, err := uuid.GenerateUUID()
@@ -145,20 +217,95 @@ func dataSourceCCEClusterCertificateRead(_ context.Context, d *schema.ResourceDa
 	}
 	d.SetId(dataSourceId)
 
-	mErr = multierror.Append(mErr,
+	mErr := multierror.Append(nil,
+		d.Set("kube_config_raw", string(kubeConfigRaw)),
 		d.Set("current_context", cert.CurrentContext),
 		d.Set("clusters", flattenClusterCertClusters(cert)),
 		d.Set("users", flattenClusterCertUsers(cert)),
 		d.Set("contexts", flattenClusterCertContexts(cert)),
 	)
 
-	if mErr.ErrorOrNil() != nil {
+	if err = mErr.ErrorOrNil(); err != nil {
 		return diag.Errorf("error setting CCE clusters certificate: %s", err)
 	}
 
 	return nil
 }
 
+// buildClusterExecCertificate builds the clusters, users and contexts of the kubeconfig from the API server endpoints
+// of the cluster, the names are the same as the ones returned by the cluster certificate API.
+func buildClusterExecCertificate(cluster *clusters.Clusters, caData string) *clusters.Certificate {
+	cert := clusters.Certificate{
+		Kind:       "Config",
+		ApiVersion: "v1",
+		Users:      []clusters.CertUsers{{Name: "user"}},
+	}
+	for _, endpoint := range cluster.Status.Endpoints {
+		name := strings.ToLower(endpoint.Type)
+		if endpoint.Url == "" || (name != "internal" && name != "external") {
+			continue
+		}
+		cert.Clusters = append(cert.Clusters, clusters.CertClusters{
+			Name: name + "Cluster",
+			Cluster: clusters.CertCluster{
+				Server:            endpoint.Url,
+				CertAuthorityData: caData,
+			},
+		})
+		certContext := clusters.CertContexts{Name: name}
+		certContext.Context.Cluster = name + "Cluster"
+		certContext.Context.User = "user"
+		cert.Contexts = append(cert.Contexts, certContext)
+		// The external endpoint is preferred as the current context.
+		if cert.CurrentContext == "" || name == "external" {
+			cert.CurrentContext = name
+		}
+	}
+	return &cert
+}
+
+// buildClusterCertExecKubeConfig builds the kubeconfig whose users call out to the exec credential plugin.
+func buildClusterCertExecKubeConfig(d *schema.ResourceData, cert *clusters.Certificate) interface{} {
+	env := make([]map[string]interface{}, 0)
+	for k, v := range d.Get("exec.0.env").(map[string]interface{}) {
+		env = append(env, map[string]interface{}{
+			"name":  k,
+			"value": v,
+		})
+	}
+	sort.Slice(env, func(i, j int) bool {
+		return env[i]["name"].(string) < env[j]["name"].(string)
+	})
+
+	exec := map[string]interface{}{
+		"apiVersion":      d.Get("exec.0.api_version").(string),
+		"command":         d.Get("exec.0.command").(string),
+		"args":            utils.ExpandToStringList(d.Get("exec.0.args").([]interface{})),
+		"env":             env,
+		"interactiveMode": "Never",
+	}
+
+	users := make([]map[string]interface{}, len(cert.Users))
+	for i, user := range cert.Users {
+		users[i] = map[string]interface{}{
+			"name": user.Name,
+			"user": map[string]interface{}{
+				"exec": exec,
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"kind":            cert.Kind,
+		"apiVersion":      cert.ApiVersion,
+		"clusters":        cert.Clusters,
+		"users":           users,
+		"contexts":        cert.Contexts,
+		"current-context": cert.CurrentContext,
+		"preferences":     map[string]interface{}{},
+	}
+}
+
 func flattenClusterCertClusters(cert *clusters.Certificate) []map[string]interface{} {
 	certClusters := cert.Clusters
 	res := make([]map[string]interface{}, len(certClusters))
@@ -2,7 +2,6 @@ package cce
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -26,6 +25,7 @@ import (
 // @API CCE GET /api/v3/addons/{id}
 // @API CCE PUT /api/v3/addons/{id}
 // @API CCE POST /api/v3/addons
+// @API CCE GET /api/v3/addontemplates
 func ResourceAddon() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceAddonCreate,
@@ -33,6 +33,8 @@ func ResourceAddon() *schema.Resource {
 		UpdateContext: resourceAddonUpdate,
 		DeleteContext: resourceAddonDelete,
 
+		CustomizeDiff: validateAddonValues,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceAddonImport,
 		},
@@ -122,6 +124,7 @@ func ResourceAddon() *schema.Resource {
 					},
 				},
 			},
+			"effective_values": resourceAddonEffectiveValuesSchema(),
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -134,45 +137,28 @@ func ResourceAddon() *schema.Resource {
 	}
 }
 
-func buildAddonValues(d *schema.ResourceData) (basic, custom, flavor map[string]interface{}, err error) {
-	values := d.Get("values").([]interface{})
-	if len(values) == 0 || values[0] == nil {
-		basic = make(map[string]interface{})
-		return
+func buildAddonValues(d *schema.ResourceData) (addons.Values, error) {
+	effectiveValues, err := buildAddonEffectiveValues(d)
+	if err != nil {
+		return addons.Values{}, err
 	}
-
-	valuesMap := values[0].(map[string]interface{})
-	if basicRaw := valuesMap["basic"].(map[string]interface{}); len(basicRaw) != 0 {
-		basic = basicRaw
-	} else if basicJsonRaw := valuesMap["basic_json"].(string); basicJsonRaw != "" {
-		err = json.Unmarshal([]byte(basicJsonRaw), &basic)
-		if err != nil {
-			err = fmt.Errorf("error unmarshalling basic json: %s", err)
-			return
-		}
+	if effectiveValues != nil {
+		return *effectiveValues, nil
 	}
 
-	if customRaw := valuesMap["custom"].(map[string]interface{}); len(customRaw) != 0 {
-		custom = customRaw
-	} else if customJsonRaw := valuesMap["custom_json"].(string); customJsonRaw != "" {
-		err = json.Unmarshal([]byte(customJsonRaw), &custom)
-		if err != nil {
-			err = fmt.Errorf("error unmarshalling custom json: %s", err)
-			return
-		}
+	basic, custom, flavor, err := parseAddonValues(d.Get("values").([]interface{}))
+	if err != nil {
+		return addons.Values{}, err
 	}
-
-	if flavorRaw := valuesMap["flavor"].(map[string]interface{}); len(flavorRaw) != 0 {
-		flavor = flavorRaw
-	} else if flavorJsonRaw := valuesMap["flavor_json"].(string); flavorJsonRaw != "" {
-		err = json.Unmarshal([]byte(flavorJsonRaw), &flavor)
-		if err != nil {
-			err = fmt.Errorf("error unmarshalling flavor json %s", err)
-			return
-		}
+	result := addons.Values{
+		Basic:  basic.Value,
+		Custom: custom.Value,
+		Flavor: flavor.Value,
 	}
-
-	return
+	if result.Basic == nil {
+		result.Basic = make(map[string]interface{})
+	}
+	return result, nil
 }
 
 func resourceAddonCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -183,7 +169,7 @@ func resourceAddonCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	clusterID := d.Get("cluster_id").(string)
-	basic, custom, flavor, err := buildAddonValues(d)
+	values, err := buildAddonValues(d)
 	if err != nil {
 		return diag.Errorf("error getting values for CCE add-on: %s", err)
 	}
@@ -200,11 +186,7 @@ func resourceAddonCreate(ctx context.Context, d *schema.ResourceData, meta inter
 			Version:           d.Get("version").(string),
 			ClusterID:         clusterID,
 			AddonTemplateName: d.Get("template_name").(string),
-			Values: addons.Values{
-				Basic:  basic,
-				Custom: custom,
-				Flavor: flavor,
-			},
+			Values:            values,
 		},
 	}
 
@@ -231,7 +213,7 @@ func resourceAddonCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.Errorf("error waiting for CCE add-on (%s) to become available: %s", create.Metadata.Id, err)
 	}
 
-	return resourceAddonRead(ctx, d, meta)
+	return append(buildAddonValuesWarnings(cfg, d), resourceAddonRead(ctx, d, meta)...)
 }
 
 func resourceAddonRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -255,6 +237,7 @@ func resourceAddonRead(_ context.Context, d *schema.ResourceData, meta interface
 		d.Set("template_name", n.Spec.AddonTemplateName),
 		d.Set("status", n.Status.Status),
 		d.Set("description", n.Spec.Description),
+		d.Set("effective_values", flattenAddonEffectiveValues(n.Spec.Values)),
 	)
 	if err = mErr.ErrorOrNil(); err != nil {
 		return diag.Errorf("error setting CCE add-on (%s) fields: %s", addonID, err)
@@ -272,7 +255,7 @@ func resourceAddonUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 
 	clusterID := d.Get("cluster_id").(string)
 	addonID := d.Id()
-	basic, custom, flavor, err := buildAddonValues(d)
+	values, err := buildAddonValues(d)
 	if err != nil {
 		return diag.Errorf("error getting values for CCE add-on: %s", err)
 	}
@@ -289,11 +272,7 @@ func resourceAddonUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 			Version:           d.Get("version").(string),
 			ClusterID:         clusterID,
 			AddonTemplateName: d.Get("template_name").(string),
-			Values: addons.Values{
-				Basic:  basic,
-				Custom: custom,
-				Flavor: flavor,
-			},
+			Values:            values,
 		},
 	}
 
@@ -316,7 +295,7 @@ func resourceAddonUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.Errorf("error waiting for CCE add-on (%s) to become available: %s", addonID, err)
 	}
 
-	return resourceAddonRead(ctx, d, meta)
+	return append(buildAddonValuesWarnings(cfg, d), resourceAddonRead(ctx, d, meta)...)
 }
 
 func resourceAddonDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
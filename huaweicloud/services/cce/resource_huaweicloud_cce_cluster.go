@@ -416,6 +416,10 @@ func ResourceCluster() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"skip_kube_config": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"kube_config_raw": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -917,18 +921,46 @@ func resourceClusterRead(_ context.Context, d *schema.ResourceData, meta interfa
 		mErr = multierror.Append(mErr, d.Set("charging_mode", "prePaid"))
 	}
 
+	mErr = multierror.Append(mErr, setClusterCertificates(d, cceClient, d.Get("skip_kube_config").(bool)))
+
+	// Set masters
+	var masterList []map[string]interface{}
+	for _, masterObj := range n.Spec.Masters {
+		master := make(map[string]interface{})
+		master["availability_zone"] = masterObj.MasterAZ
+		masterList = append(masterList, master)
+	}
+	mErr = multierror.Append(mErr, d.Set("masters", masterList))
+
+	if err = mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting CCE cluster fields: %s", err)
+	}
+
+	return nil
+}
+
+// setClusterCertificates sets the certificates of the cluster. If skipKubeConfig is true, the credentials of the
+// cluster are not stored in the state, only the servers and the CA data of the clusters are kept.
+func setClusterCertificates(d *schema.ResourceData, cceClient *golangsdk.ServiceClient, skipKubeConfig bool) error {
 	// duration -1 is equal to the maximum value 1827 days
 	opts := clusters.GetCertOpts{Duration: -1}
+	if skipKubeConfig {
+		// The client certificate is discarded, so the shortest duration is used.
+		opts.Duration = 1
+	}
 	r := clusters.GetCert(cceClient, d.Id(), opts)
 
-	kubeConfigRaw, err := utils.JsonMarshal(r.Body)
-
-	if err != nil {
-		log.Printf("error marshaling r.Body: %s", err)
+	var mErr *multierror.Error
+	if skipKubeConfig {
+		mErr = multierror.Append(mErr, d.Set("kube_config_raw", nil))
+	} else {
+		kubeConfigRaw, err := utils.JsonMarshal(r.Body)
+		if err != nil {
+			log.Printf("error marshaling r.Body: %s", err)
+		}
+		mErr = multierror.Append(mErr, d.Set("kube_config_raw", string(kubeConfigRaw)))
 	}
 
-	mErr = multierror.Append(mErr, d.Set("kube_config_raw", string(kubeConfigRaw)))
-
 	cert, err := r.Extract()
 
 	if err != nil {
@@ -946,6 +978,11 @@ func resourceClusterRead(_ context.Context, d *schema.ResourceData, meta interfa
 	}
 	mErr = multierror.Append(mErr, d.Set("certificate_clusters", clusterList))
 
+	if skipKubeConfig {
+		mErr = multierror.Append(mErr, d.Set("certificate_users", nil))
+		return mErr.ErrorOrNil()
+	}
+
 	// Set Certificate Users
 	var userList []map[string]interface{}
 	for _, userObj := range cert.Users {
@@ -957,20 +994,7 @@ func resourceClusterRead(_ context.Context, d *schema.ResourceData, meta interfa
 	}
 	mErr = multierror.Append(mErr, d.Set("certificate_users", userList))
 
-	// Set masters
-	var masterList []map[string]interface{}
-	for _, masterObj := range n.Spec.Masters {
-		master := make(map[string]interface{})
-		master["availability_zone"] = masterObj.MasterAZ
-		masterList = append(masterList, master)
-	}
-	mErr = multierror.Append(mErr, d.Set("masters", masterList))
-
-	if err = mErr.ErrorOrNil(); err != nil {
-		return diag.Errorf("error setting CCE cluster fields: %s", err)
-	}
-
-	return nil
+	return mErr.ErrorOrNil()
 }
 
 func flattenContainerNetworkCidrs(containerNetwork clusters.ContainerNetworkSpec) string {
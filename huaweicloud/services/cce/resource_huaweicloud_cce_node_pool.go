@@ -30,6 +30,13 @@ import (
 // @API CCE GET /api/v3/projects/{project_id}/clusters/{cluster_id}/nodepools/{nodepool_id}
 // @API CCE PUT /api/v3/projects/{project_id}/clusters/{cluster_id}/nodepools/{nodepool_id}
 // @API CCE DELETE /api/v3/projects/{project_id}/clusters/{cluster_id}/nodepools/{nodepool_id}
+// @API CCE GET /api/v3/projects/{project_id}/clusters/{cluster_id}/nodes
+// @API CCE POST /api/v3/projects/{project_id}/clusters/{cluster_id}/nodepools/{nodepool_id}/operation/scale
+// @API CCE PUT /api/v3/projects/{project_id}/clusters/{cluster_id}/nodes/operation/remove
+// @API CCE GET /api/v3/projects/{project_id}/jobs/{job_id}
+// @API CCE PATCH /api/v1/nodes/{node_name}
+// @API CCE GET /api/v1/pods
+// @API CCE POST /api/v1/namespaces/{namespace}/pods/{pod_name}/eviction
 
 var nodePoolNonUpdatableParams = []string{
 	"cluster_id", "flavor_id", "type", "availability_zone", "charging_mode", "period_unit", "period", "runtime",
@@ -274,6 +281,12 @@ var nodePoolSchema = map[string]*schema.Schema{
 		Elem:        &schema.Schema{Type: schema.TypeString},
 		Description: "schema: Deprecated; This parameter has been replaced by the 'extend_params' parameter.",
 	},
+	"rolling_update": resourceNodePoolRollingUpdateSchema(),
+	"rolling_update_pending_nodes": {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
 }
 
 func ResourceNodePool() *schema.Resource {
@@ -284,7 +297,7 @@ func ResourceNodePool() *schema.Resource {
 		DeleteContext: resourceNodePoolDelete,
 
 		CustomizeDiff: customdiff.All(
-			config.FlexibleForceNew(nodePoolNonUpdatableParams, nodePoolSchema),
+			nodePoolForceNew(),
 			ignoreDiffIfScaleGroupsEqual(),
 			config.MergeDefaultTags(),
 		),
@@ -926,6 +939,15 @@ func buildNodePoolUpdateOpts(d *schema.ResourceData, cfg *config.Config) (*nodep
 			ExtensionScaleGroups:         buildExtensionScaleGroups(d),
 		},
 	}
+
+	if isNodePoolRollingUpdateRequired(d) {
+		updateOpts.Spec.NodeTemplate.Flavor = d.Get("flavor_id").(string)
+		if v, ok := d.GetOk("runtime"); ok {
+			updateOpts.Spec.NodeTemplate.RunTime = &nodes.RunTimeSpec{
+				Name: v.(string),
+			}
+		}
+	}
 	return &updateOpts, nil
 }
 
@@ -972,6 +994,17 @@ func resourceNodePoolUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 	clusterId := d.Get("cluster_id").(string)
 	nodePoolId := d.Id()
+
+	// The nodes created before the node template is updated will be replaced by the rolling update.
+	var allNodes, staleNodes []nodePoolNode
+	rollingUpdate := isNodePoolRollingUpdateRequired(d)
+	if rollingUpdate {
+		allNodes, staleNodes, err = listNodePoolStaleNodes(cceClient, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	_, err = nodepools.Update(cceClient, clusterId, nodePoolId, updateOpts).Extract()
 	if err != nil {
 		return diag.Errorf("error updating CCE node pool (%s): %s", nodePoolId, err)
@@ -991,7 +1024,15 @@ func resourceNodePoolUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.Errorf("error waiting for CCE node pool (%s) to become available: %s", nodePoolId, err)
 	}
 
-	return resourceNodePoolRead(ctx, d, meta)
+	var diags diag.Diagnostics
+	if rollingUpdate {
+		diags = rollingUpdateNodePool(ctx, d, cfg, staleNodes, len(allNodes))
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceNodePoolRead(ctx, d, meta)...)
 }
 
 func resourceNodePoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -0,0 +1,450 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const (
+	// The size of each part which is uploaded to the OBS bucket, the backup file is buffered part by part, so the
+	// memory usage of the export is limited to one part.
+	backupExportPartSize = 32 * 1024 * 1024
+	// The validity period (in seconds) of the download links of the DCS backup files.
+	dcsBackupLinkExpiration = 3600
+	// The name of the manifest object which records the objects of an export, only the objects recorded by the
+	// manifests are pruned.
+	backupExportManifestName = "export-manifest.json"
+)
+
+// The service client names of the database engines whose backups can be exported.
+var backupExportServiceNames = map[string]string{
+	"rds":     "rds",
+	"gaussdb": "opengauss",
+	"dds":     "dds",
+	"dcs":     "dcs",
+}
+
+// backupFile is the backup file which can be downloaded from the service-managed storage.
+type backupFile struct {
+	Name string
+	Link string
+}
+
+// backupExportManifest is the content of the manifest object of an export.
+type backupExportManifest struct {
+	Engine     string   `json:"engine"`
+	BackupId   string   `json:"backup_id"`
+	ExportedAt string   `json:"exported_at"`
+	Objects    []string `json:"objects"`
+}
+
+// exportedObject is the OBS object which the backup file is exported to.
+type exportedObject struct {
+	Key        string
+	SourceFile string
+	Size       int64
+	Checksum   string
+}
+
+func listRdsBackupFiles(client *golangsdk.ServiceClient, backupId string) ([]backupFile, error) {
+	httpUrl := "v3/{project_id}/backup-files?backup_id={backup_id}"
+	listPath := client.Endpoint + httpUrl
+	listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+	listPath = strings.ReplaceAll(listPath, "{backup_id}", url.QueryEscape(backupId))
+
+	return requestBackupFiles(client, "GET", listPath, nil, "files", "name", "download_link")
+}
+
+func listDdsBackupFiles(client *golangsdk.ServiceClient, instanceId, backupId string) ([]backupFile, error) {
+	httpUrl := "v3/{project_id}/backups/download-file?instance_id={instance_id}&backup_id={backup_id}"
+	listPath := client.Endpoint + httpUrl
+	listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+	listPath = strings.ReplaceAll(listPath, "{instance_id}", url.QueryEscape(instanceId))
+	listPath = strings.ReplaceAll(listPath, "{backup_id}", url.QueryEscape(backupId))
+
+	return requestBackupFiles(client, "GET", listPath, nil, "files", "name", "download_link")
+}
+
+func listDcsBackupFiles(client *golangsdk.ServiceClient, instanceId, backupId string) ([]backupFile, error) {
+	httpUrl := "v2/{project_id}/instances/{instance_id}/backups/{backup_id}/links"
+	listPath := client.Endpoint + httpUrl
+	listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+	listPath = strings.ReplaceAll(listPath, "{instance_id}", instanceId)
+	listPath = strings.ReplaceAll(listPath, "{backup_id}", backupId)
+
+	bodyParams := map[string]interface{}{
+		"expiration": dcsBackupLinkExpiration,
+	}
+	return requestBackupFiles(client, "POST", listPath, bodyParams, "links", "file_name", "link")
+}
+
+func requestBackupFiles(client *golangsdk.ServiceClient, method, requestPath string, bodyParams interface{},
+	filesKey, nameKey, linkKey string) ([]backupFile, error) {
+	requestOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders: map[string]string{
+			"Content-Type": "application/json",
+		},
+		JSONBody: bodyParams,
+	}
+	if method == "POST" {
+		requestOpt.OkCodes = []int{200, 201}
+	}
+
+	resp, err := client.Request(method, requestPath, &requestOpt)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := utils.FlattenResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	files := utils.PathSearch(filesKey, respBody, make([]interface{}, 0)).([]interface{})
+	result := make([]backupFile, 0, len(files))
+	for _, v := range files {
+		file := backupFile{
+			Name: utils.PathSearch(nameKey, v, "").(string),
+			Link: utils.PathSearch(linkKey, v, "").(string),
+		}
+		if file.Name == "" || file.Link == "" {
+			return nil, fmt.Errorf("the name or the download link of the backup file is missing in the response")
+		}
+		result = append(result, file)
+	}
+	return result, nil
+}
+
+// listBackupFiles resolves the download links of all files of the backup of the specified engine.
+func listBackupFiles(client *golangsdk.ServiceClient, engine, instanceId, backupId string) ([]backupFile, error) {
+	switch engine {
+	case "rds", "gaussdb":
+		return listRdsBackupFiles(client, backupId)
+	case "dds":
+		return listDdsBackupFiles(client, instanceId, backupId)
+	case "dcs":
+		return listDcsBackupFiles(client, instanceId, backupId)
+	}
+	return nil, fmt.Errorf("unsupported engine: %s", engine)
+}
+
+// buildBackupExportPrefix returns the key prefix of the objects exported for the engine, the objects of each export are
+// under the sub-prefix of the backup ID.
+func buildBackupExportPrefix(prefix, engine string) string {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return prefix + engine + "/"
+}
+
+func buildBackupExportKey(prefix, engine, backupId, fileName string) string {
+	return buildBackupExportPrefix(prefix, engine) + backupId + "/" + path.Base(strings.Trim(fileName, "/"))
+}
+
+func trimETag(eTag string) string {
+	return strings.ToLower(strings.Trim(eTag, "\""))
+}
+
+// newBackupDownloadClient returns the HTTP client which downloads the backup files. The log round tripper buffers the
+// whole response body and the redirections are signed by the provider client, so only the raw transport is used.
+func newBackupDownloadClient(cfg *config.Config) *http.Client {
+	transport := cfg.DomainClient.HTTPClient.Transport
+	if lrt, ok := transport.(*config.LogRoundTripper); ok {
+		transport = lrt.Rt
+	}
+	return &http.Client{Transport: transport}
+}
+
+// streamBackupFile downloads the backup file from the download link and uploads it to the OBS object part by part.
+// Only the integrity of the transfer is verified: the checksum of each part is verified by the OBS, and the total size
+// is compared with the content length of the download response. The backup APIs do not return the checksums of the
+// files, so the returned SHA-256 checksum is computed from the downloaded data.
+func streamBackupFile(ctx context.Context, httpClient *http.Client, obsClient *obs.ObsClient, file backupFile,
+	bucket, key string) (*exportedObject, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", file.Link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building the download request of the backup file (%s): %s", file.Name, err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading the backup file (%s): %s", file.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading the backup file (%s): unexpected status %s", file.Name, resp.Status)
+	}
+
+	initOpts := &obs.InitiateMultipartUploadInput{}
+	initOpts.Bucket = bucket
+	initOpts.Key = key
+	initOpts.ContentType = "application/octet-stream"
+	initResp, err := obsClient.InitiateMultipartUpload(initOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error initiating the multipart upload of the object (%s): %s", key, err)
+	}
+
+	result, err := uploadBackupFileParts(resp.Body, resp.ContentLength, obsClient, bucket, key, initResp.UploadId)
+	if err != nil {
+		abortOpts := &obs.AbortMultipartUploadInput{
+			Bucket:   bucket,
+			Key:      key,
+			UploadId: initResp.UploadId,
+		}
+		if _, abortErr := obsClient.AbortMultipartUpload(abortOpts); abortErr != nil {
+			log.Printf("[WARN] error aborting the multipart upload (%s) of the object (%s): %s",
+				initResp.UploadId, key, abortErr)
+		}
+		return nil, fmt.Errorf("error exporting the backup file (%s) to the object (%s): %s", file.Name, key, err)
+	}
+
+	result.SourceFile = file.Name
+	return result, nil
+}
+
+// uploadBackupFileParts uploads the data part by part, the upload is completed only if the size of the data matches
+// the content length, the negative content length means that the length is unknown.
+func uploadBackupFileParts(body io.Reader, contentLength int64, obsClient *obs.ObsClient, bucket, key,
+	uploadId string) (*exportedObject, error) {
+	var (
+		buf      = make([]byte, backupExportPartSize)
+		fileHash = sha256.New()
+		parts    = make([]obs.Part, 0)
+		size     int64
+	)
+
+	for partNumber := 1; ; partNumber++ {
+		n, readErr := io.ReadFull(body, buf)
+		if readErr != nil && !errors.Is(readErr, io.EOF) && !errors.Is(readErr, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("error reading the backup file: %s", readErr)
+		}
+		// An empty file is uploaded as an empty part, the other files are never ended with an empty part.
+		if n == 0 && partNumber > 1 {
+			break
+		}
+
+		part, err := uploadBackupFilePart(obsClient, bucket, key, uploadId, partNumber, buf[:n], fileHash)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, *part)
+		size += int64(n)
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	if contentLength >= 0 && size != contentLength {
+		return nil, fmt.Errorf("the size of the downloaded data (%d bytes) does not match the content length (%d bytes)",
+			size, contentLength)
+	}
+
+	completeOpts := &obs.CompleteMultipartUploadInput{
+		Bucket:   bucket,
+		Key:      key,
+		UploadId: uploadId,
+		Parts:    parts,
+	}
+	if _, err := obsClient.CompleteMultipartUpload(completeOpts); err != nil {
+		return nil, fmt.Errorf("error completing the multipart upload: %s", err)
+	}
+
+	return &exportedObject{
+		Key:      key,
+		Size:     size,
+		Checksum: hex.EncodeToString(fileHash.Sum(nil)),
+	}, nil
+}
+
+func uploadBackupFilePart(obsClient *obs.ObsClient, bucket, key, uploadId string, partNumber int, data []byte,
+	fileHash hash.Hash) (*obs.Part, error) {
+	partHash := md5.Sum(data)
+	fileHash.Write(data)
+
+	uploadOpts := &obs.UploadPartInput{
+		Bucket:     bucket,
+		Key:        key,
+		UploadId:   uploadId,
+		PartNumber: partNumber,
+		// The part is rejected by the OBS if the content does not match the MD5 checksum.
+		ContentMD5: base64.StdEncoding.EncodeToString(partHash[:]),
+		Body:       bytes.NewReader(data),
+		PartSize:   int64(len(data)),
+	}
+	resp, err := obsClient.UploadPart(uploadOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error uploading the part (%d): %s", partNumber, err)
+	}
+	if expected := hex.EncodeToString(partHash[:]); trimETag(resp.ETag) != expected {
+		return nil, fmt.Errorf("the checksum of the part (%d) is mismatched, expected %s, but got %s",
+			partNumber, expected, trimETag(resp.ETag))
+	}
+
+	return &obs.Part{
+		PartNumber: partNumber,
+		ETag:       resp.ETag,
+	}, nil
+}
+
+// putBackupExportManifest uploads the manifest object of the export to the sub-prefix of the backup ID.
+func putBackupExportManifest(obsClient *obs.ObsClient, bucket, prefix, engine, backupId, exportedAt string,
+	objects []*exportedObject) error {
+	manifest := backupExportManifest{
+		Engine:     engine,
+		BackupId:   backupId,
+		ExportedAt: exportedAt,
+		Objects:    make([]string, 0, len(objects)),
+	}
+	for _, object := range objects {
+		manifest.Objects = append(manifest.Objects, object.Key)
+	}
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("error marshaling the export manifest: %s", err)
+	}
+
+	key := buildBackupExportKey(prefix, engine, backupId, backupExportManifestName)
+	putOpts := &obs.PutObjectInput{
+		Body: bytes.NewReader(content),
+	}
+	putOpts.Bucket = bucket
+	putOpts.Key = key
+	putOpts.ContentType = "application/json"
+	if _, err = obsClient.PutObject(putOpts); err != nil {
+		return fmt.Errorf("error uploading the export manifest (%s): %s", key, err)
+	}
+	return nil
+}
+
+// listBackupExportManifests returns the keys of the manifest objects which are directly under the sub-prefixes of the
+// backup IDs, the manifest of the backup to be kept is excluded.
+func listBackupExportManifests(obsClient *obs.ObsClient, bucket, prefix, keepBackupId string) ([]string, error) {
+	result := make([]string, 0)
+	listOpts := &obs.ListObjectsInput{
+		Bucket: bucket,
+	}
+	listOpts.Prefix = prefix
+	for {
+		resp, err := obsClient.ListObjects(listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing the objects under the prefix (%s): %s", prefix, err)
+		}
+		for _, content := range resp.Contents {
+			backupId, name, found := strings.Cut(strings.TrimPrefix(content.Key, prefix), "/")
+			if found && name == backupExportManifestName && backupId != "" && backupId != keepBackupId {
+				result = append(result, content.Key)
+			}
+		}
+		if !resp.IsTruncated || len(resp.Contents) == 0 {
+			break
+		}
+		// The next marker is only returned when the delimiter is specified.
+		listOpts.Marker = resp.NextMarker
+		if listOpts.Marker == "" {
+			listOpts.Marker = resp.Contents[len(resp.Contents)-1].Key
+		}
+	}
+	return result, nil
+}
+
+func getBackupExportManifest(obsClient *obs.ObsClient, bucket, key string) (*backupExportManifest, error) {
+	getOpts := &obs.GetObjectInput{}
+	getOpts.Bucket = bucket
+	getOpts.Key = key
+	resp, err := obsClient.GetObject(getOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var manifest backupExportManifest
+	if err = json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("error parsing the export manifest (%s): %s", key, err)
+	}
+	return &manifest, nil
+}
+
+func deleteBackupExportObject(obsClient *obs.ObsClient, bucket, key string) error {
+	deleteOpts := &obs.DeleteObjectInput{
+		Bucket: bucket,
+		Key:    key,
+	}
+	// The objects locked by the retention policy of the bucket can not be deleted until the lock is expired.
+	if _, err := obsClient.DeleteObject(deleteOpts); err != nil && !isObjectNotFound(err) {
+		return fmt.Errorf("error deleting the expired object (%s): %s", key, err)
+	}
+	return nil
+}
+
+// pruneBackupExports deletes the exports under the prefix which are exported before the retention days. Only the
+// objects recorded by the manifest of each export, which are under the sub-prefix of the backup ID, are deleted, and
+// the export of the keepBackupId is always kept.
+func pruneBackupExports(obsClient *obs.ObsClient, bucket, prefix string, retentionDays int,
+	keepBackupId string) ([]string, error) {
+	expiredBefore := time.Now().AddDate(0, 0, -retentionDays)
+	manifestKeys, err := listBackupExportManifests(obsClient, bucket, prefix, keepBackupId)
+	if err != nil {
+		return nil, err
+	}
+
+	prunedKeys := make([]string, 0)
+	for _, manifestKey := range manifestKeys {
+		manifest, err := getBackupExportManifest(obsClient, bucket, manifestKey)
+		if err != nil {
+			return prunedKeys, fmt.Errorf("error retrieving the export manifest (%s): %s", manifestKey, err)
+		}
+		exportedAt, err := time.Parse(time.RFC3339, manifest.ExportedAt)
+		if err != nil {
+			log.Printf("[WARN] invalid export time of the manifest (%s), skip pruning it: %s", manifestKey, err)
+			continue
+		}
+		if !exportedAt.Before(expiredBefore) {
+			continue
+		}
+
+		exportPrefix := path.Dir(manifestKey) + "/"
+		for _, key := range manifest.Objects {
+			if !strings.HasPrefix(key, exportPrefix) {
+				log.Printf("[WARN] the object (%s) of the manifest (%s) is out of the export, skip deleting it", key,
+					manifestKey)
+				continue
+			}
+			if err = deleteBackupExportObject(obsClient, bucket, key); err != nil {
+				return prunedKeys, err
+			}
+			prunedKeys = append(prunedKeys, key)
+		}
+		// The manifest is deleted last, so the export is pruned again if any object fails to be deleted.
+		if err = deleteBackupExportObject(obsClient, bucket, manifestKey); err != nil {
+			return prunedKeys, err
+		}
+		prunedKeys = append(prunedKeys, manifestKey)
+	}
+	return prunedKeys, nil
+}
+
+func isObjectNotFound(err error) bool {
+	var obsError obs.ObsError
+	return errors.As(err, &obsError) && obsError.StatusCode == http.StatusNotFound
+}
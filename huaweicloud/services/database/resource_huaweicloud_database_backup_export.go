@@ -0,0 +1,298 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk/openstack/obs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// ResourceDatabaseBackupExport is the impl for huaweicloud_database_backup_export resource, which copies the files of
+// a RDS, GaussDB, DDS or DCS backup from the service-managed storage to an OBS bucket and prunes the expired exports.
+// @API RDS GET /v3/{project_id}/backup-files
+// @API GaussDB GET /v3/{project_id}/backup-files
+// @API DDS GET /v3/{project_id}/backups/download-file
+// @API DCS POST /v2/{project_id}/instances/{instance_id}/backups/{backup_id}/links
+// @API OBS POST /{ObjectName}?uploads
+// @API OBS PUT /{ObjectName}
+// @API OBS POST /{ObjectName}
+// @API OBS DELETE /{ObjectName}
+// @API OBS HEAD /{ObjectName}
+// @API OBS GET /{ObjectName}
+// @API OBS GET /
+func ResourceDatabaseBackupExport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDatabaseBackupExportCreate,
+		ReadContext:   resourceDatabaseBackupExportRead,
+		UpdateContext: resourceDatabaseBackupExportUpdate,
+		DeleteContext: resourceDatabaseBackupExportDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: `The region where the backup and the OBS bucket are located.`,
+			},
+			"engine": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"rds", "gaussdb", "dds", "dcs"}, false),
+				Description:  `The engine of the database which the backup belongs to.`,
+			},
+			"backup_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The ID of the backup to be exported.`,
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: `The ID of the instance which the backup belongs to.`,
+			},
+			"bucket": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The name of the OBS bucket to which the backup files are exported.`,
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: `The key prefix of the exported objects.`,
+			},
+			"retention_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  `The number of days to retain the exports of the same engine under the prefix.`,
+			},
+			"exported_objects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        exportedObjectSchema(),
+				Description: `The objects to which the backup files are exported.`,
+			},
+			"exported_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The time when the backup is exported, in RFC3339 format.`,
+			},
+		},
+	}
+}
+
+func exportedObjectSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The key of the exported object.`,
+			},
+			"source_file": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The name of the backup file.`,
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The size of the exported object, in bytes.`,
+			},
+			"checksum": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The SHA-256 checksum of the downloaded data, in hexadecimal.`,
+			},
+		},
+	}
+}
+
+func resourceDatabaseBackupExportCreate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	var (
+		cfg        = meta.(*config.Config)
+		region     = cfg.GetRegion(d)
+		engine     = d.Get("engine").(string)
+		backupId   = d.Get("backup_id").(string)
+		instanceId = d.Get("instance_id").(string)
+		bucket     = d.Get("bucket").(string)
+		prefix     = d.Get("prefix").(string)
+	)
+
+	if instanceId == "" && (engine == "dds" || engine == "dcs") {
+		return diag.Errorf("the instance_id is required when the engine is %s", engine)
+	}
+
+	client, err := cfg.NewServiceClient(backupExportServiceNames[engine], region)
+	if err != nil {
+		return diag.Errorf("error creating %s client: %s", engine, err)
+	}
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS client: %s", err)
+	}
+
+	files, err := listBackupFiles(client, engine, instanceId, backupId)
+	if err != nil {
+		return diag.Errorf("error retrieving the download links of the %s backup (%s): %s", engine, backupId, err)
+	}
+	if len(files) == 0 {
+		return diag.Errorf("no file is found for the %s backup (%s)", engine, backupId)
+	}
+
+	httpClient := newBackupDownloadClient(cfg)
+	objects := make([]*exportedObject, 0, len(files))
+	for _, file := range files {
+		key := buildBackupExportKey(prefix, engine, backupId, file.Name)
+		log.Printf("[DEBUG] exporting the backup file (%s) to the object (%s) of the bucket (%s)", file.Name, key, bucket)
+		object, err := streamBackupFile(ctx, httpClient, obsClient, file, bucket, key)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		objects = append(objects, object)
+	}
+
+	exportedAt := time.Now().UTC().Format(time.RFC3339)
+	if err = putBackupExportManifest(obsClient, bucket, prefix, engine, backupId, exportedAt, objects); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(backupId)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("exported_objects", flattenExportedObjects(objects)),
+		d.Set("exported_at", exportedAt),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error saving the exported objects: %s", err)
+	}
+
+	if err := pruneExpiredBackupExports(obsClient, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceDatabaseBackupExportRead(ctx, d, meta)
+}
+
+func flattenExportedObjects(objects []*exportedObject) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(objects))
+	for _, object := range objects {
+		result = append(result, map[string]interface{}{
+			"key":         object.Key,
+			"source_file": object.SourceFile,
+			"size":        object.Size,
+			"checksum":    object.Checksum,
+		})
+	}
+	return result
+}
+
+func getExportedObjectKeys(d *schema.ResourceData) []string {
+	objects := d.Get("exported_objects").([]interface{})
+	result := make([]string, 0, len(objects))
+	for _, v := range objects {
+		result = append(result, utils.PathSearch("key", v, "").(string))
+	}
+	return result
+}
+
+// pruneExpiredBackupExports deletes the exports of the same engine under the prefix which exceed the retention days.
+// It only runs when the export is created or the retention days are changed.
+func pruneExpiredBackupExports(obsClient *obs.ObsClient, d *schema.ResourceData) error {
+	retentionDays := d.Get("retention_days").(int)
+	if retentionDays == 0 {
+		return nil
+	}
+
+	bucket := d.Get("bucket").(string)
+	prefix := buildBackupExportPrefix(d.Get("prefix").(string), d.Get("engine").(string))
+	prunedKeys, err := pruneBackupExports(obsClient, bucket, prefix, retentionDays, d.Get("backup_id").(string))
+	if len(prunedKeys) > 0 {
+		log.Printf("[DEBUG] the expired exports are pruned from the bucket (%s): %v", bucket, prunedKeys)
+	}
+	if err != nil {
+		return fmt.Errorf("error pruning the expired exports from the bucket (%s): %s", bucket, err)
+	}
+	return nil
+}
+
+func resourceDatabaseBackupExportRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var (
+		cfg    = meta.(*config.Config)
+		region = cfg.GetRegion(d)
+		bucket = d.Get("bucket").(string)
+	)
+
+	obsClient, err := cfg.ObjectStorageClient(region)
+	if err != nil {
+		return diag.Errorf("error creating OBS client: %s", err)
+	}
+
+	// The export is recreated if any exported object is removed from the bucket.
+	for _, key := range getExportedObjectKeys(d) {
+		opts := &obs.GetObjectMetadataInput{
+			Bucket: bucket,
+			Key:    key,
+		}
+		if _, err := obsClient.GetObjectMetadata(opts); err != nil {
+			if isObjectNotFound(err) {
+				log.Printf("[WARN] the exported object (%s) is not found in the bucket (%s), removing the export "+
+					"from the state", key, bucket)
+				d.SetId("")
+				return nil
+			}
+			return diag.Errorf("error retrieving the exported object (%s): %s", key, err)
+		}
+	}
+
+	return diag.FromErr(d.Set("region", region))
+}
+
+func resourceDatabaseBackupExportUpdate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	obsClient, err := cfg.ObjectStorageClient(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating OBS client: %s", err)
+	}
+
+	if d.HasChange("retention_days") {
+		if err := pruneExpiredBackupExports(obsClient, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceDatabaseBackupExportRead(ctx, d, meta)
+}
+
+func resourceDatabaseBackupExportDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	errorMsg := "Deleting backup export resource does not delete the exported objects. The resource is only removed " +
+		"from the state, the objects remain in the bucket until they are pruned or deleted manually."
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  errorMsg,
+		},
+	}
+}
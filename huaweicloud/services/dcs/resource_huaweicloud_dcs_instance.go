@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -88,7 +89,10 @@ func ResourceDcsInstance() *schema.Resource {
 			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 
-		CustomizeDiff: config.MergeDefaultTags(),
+		CustomizeDiff: customdiff.All(
+			config.MergeDefaultTags(),
+			common.CheckManagedMasterPasswordRotation,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -165,10 +169,13 @@ func ResourceDcsInstance() *schema.Resource {
 				ForceNew: true,
 			},
 			"password": {
-				Type:      schema.TypeString,
-				Sensitive: true,
-				Optional:  true,
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				ConflictsWith: []string{"manage_master_password"},
 			},
+			"manage_master_password":     common.ManageMasterPasswordSchema(),
+			"master_password_version_id": common.MasterPasswordVersionSchema(),
 			"whitelist_enable": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -624,6 +631,14 @@ func resourceDcsInstancesCreate(ctx context.Context, d *schema.ResourceData, met
 	}
 	requestBody := buildCreateInstanceBodyParams(d, azCodes, cfg)
 	requestBody["password"] = utils.ValueIgnoreEmpty(d.Get("password"))
+	if common.IsManagedMasterPassword(d) {
+		// The generated password is used by the access user if it is specified, otherwise by the default user.
+		password, err := common.CreateManagedMasterPassword(d, cfg, region, d.Get("name").(string)+"-master-password")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		requestBody["password"] = password
+	}
 	createOpt.JSONBody = utils.RemoveNil(requestBody)
 
 	createResp, err := client.Request("POST", createPath, &createOpt)
@@ -760,7 +775,7 @@ func buildCreateInstanceBodyParams(d *schema.ResourceData, azCodes []string, cfg
 	bodyParams["spec_code"] = resourceSpecCode
 
 	// noPasswordAccess
-	if d.Get("access_user").(string) == "" && d.Get("password").(string) == "" {
+	if d.Get("access_user").(string) == "" && d.Get("password").(string) == "" && !common.IsManagedMasterPassword(d) {
 		bodyParams["no_password_access"] = true
 	}
 
@@ -1326,13 +1341,21 @@ func resourceDcsInstancesUpdate(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
-	if d.HasChange("password") {
+	if d.HasChange("password") && !common.IsManagedMasterPassword(d) {
 		err = updateInstancePassword(ctx, d, client)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
+	err = common.UpdateManagedMasterPassword(d, cfg, region, d.Get("name").(string)+"-master-password",
+		func(password string) error {
+			return resetInstancePassword(ctx, d, client, password)
+		})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	// resize instance
 	if d.HasChanges("flavor", "capacity") {
 		err = resizeDcsInstance(ctx, d, client, cfg)
@@ -1537,6 +1560,28 @@ func updateInstancePassword(ctx context.Context, d *schema.ResourceData, client
 	return nil
 }
 
+// resetInstancePassword sets the password generated by the managed master password mode, the password belongs to the
+// access user if it is specified.
+func resetInstancePassword(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient,
+	password string) error {
+	_, err := updateDcsInstanceField(ctx, d, client, updateInstanceFieldParams{
+		httpUrl:    "v2/{project_id}/instances/{instance_id}/password/reset",
+		httpMethod: "POST",
+		pathParams: map[string]string{"instance_id": d.Id()},
+		updateBodyParams: map[string]interface{}{
+			"new_password":       password,
+			"no_password_access": false,
+		},
+		isRetry:             true,
+		timeout:             schema.TimeoutUpdate,
+		isWaitInstanceReady: true,
+	})
+	if err != nil {
+		return fmt.Errorf("error resetting instance password: %s", err)
+	}
+	return nil
+}
+
 func buildUpdateInstancePasswordBodyParams(d *schema.ResourceData) map[string]interface{} {
 	bodyParams := make(map[string]interface{})
 	password := d.Get("password")
@@ -2032,6 +2077,9 @@ func resourceDcsInstancesDelete(ctx context.Context, d *schema.ResourceData, met
 		return diag.Errorf("error waiting instance(%s) to delete: %s", d.Id(), err)
 	}
 
+	if err = common.DeleteManagedMasterPassword(d, cfg, region); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
@@ -0,0 +1,496 @@
+package dcs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var onlineMigrationNonUpdatableParams = []string{"task_name", "vpc_id", "subnet_id", "security_group_id",
+	"description", "source_instance", "target_instance", "resume_mode", "bandwidth_limit_mb", "max_incremental_lag",
+	"exchanged_ip", "is_exchange_domain"}
+
+// The statuses of the migration task which mean that the migration is failed or stopped.
+var onlineMigrationAbnormalStatuses = []string{"FAILED", "MIGRATION_FAILED", "TERMINATED", "RELEASED", "DELETED"}
+
+// ResourceDcsOnlineMigration is the impl for huaweicloud_dcs_online_migration resource, which drives the online
+// migration from the source instance to the target instance end to end, including the full and incremental sync and
+// the optional IP exchange.
+// @API DCS POST /v2/{project_id}/migration/instance
+// @API DCS GET /v2/{project_id}/jobs/{job_id}
+// @API DCS POST /v2/{project_id}/migration/{task_id}/task
+// @API DCS GET /v2/{project_id}/migration-task/{task_id}
+// @API DCS GET /v2/{project_id}/migration-task/{task_id}/stats
+// @API DCS POST /v2/{project_id}/migration-task/{task_id}/exchange-ip
+// @API DCS POST /v2/{project_id}/migration-task/{task_id}/rollback-ip
+// @API DCS POST /v2/{project_id}/migration-task/{task_id}/stop
+// @API DCS GET /v2/{project_id}/migration-tasks
+// @API DCS DELETE /v2/{project_id}/migration-tasks/delete
+func ResourceDcsOnlineMigration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDcsOnlineMigrationCreate,
+		ReadContext:   resourceDcsOnlineMigrationRead,
+		UpdateContext: resourceDcsOnlineMigrationUpdate,
+		DeleteContext: resourceDcsOnlineMigrationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: config.FlexibleForceNew(onlineMigrationNonUpdatableParams),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: `The region where the migration task is located.`,
+			},
+			"task_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The name of the migration task.`,
+			},
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The ID of the VPC where the migration ECS is located.`,
+			},
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The ID of the subnet where the migration ECS is located.`,
+			},
+			"security_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The ID of the security group to which the migration ECS belongs.`,
+			},
+			"source_instance": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem:        onlineDataMigrationInstanceConfig(),
+				Description: `The configuration of the source Redis instance.`,
+			},
+			"target_instance": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem:        onlineDataMigrationInstanceConfig(),
+				Description: `The configuration of the target Redis instance.`,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: `The description of the migration task.`,
+			},
+			"resume_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "auto",
+				ValidateFunc: validation.StringInSlice([]string{"auto", "manual"}, false),
+				Description:  `The reconnection mode of the migration task after the network is interrupted.`,
+			},
+			"bandwidth_limit_mb": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The bandwidth limit of the migration, in MB/s.`,
+			},
+			"max_incremental_lag": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  `The maximum remaining offset of the incremental sync to consider the migration synced.`,
+			},
+			"exchange_ip": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: `Whether to exchange the IP addresses of the source and target instances after the sync.`,
+			},
+			"exchanged_ip": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The IP addresses to be exchanged.`,
+			},
+			"is_exchange_domain": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: `Whether to exchange the domain names of the source and target instances.`,
+			},
+			"enable_force_new": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"true", "false"}, false),
+				Description:  utils.SchemaDesc("", utils.SchemaDescInput{Internal: true}),
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The status of the migration task.`,
+			},
+			"ip_exchange_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The status of the IP exchange.`,
+			},
+			"full_sync_progress": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: `The progress of the full sync, in percentage.`,
+			},
+			"incremental_lag": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The remaining offset of the incremental sync.`,
+			},
+			"ecs_tenant_private_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The private IP address of the migration ECS.`,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The creation time of the migration task.`,
+			},
+		},
+	}
+}
+
+func buildOnlineMigrationConfigBodyParams(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"migration_method":   "incremental_migration",
+		"resume_mode":        d.Get("resume_mode"),
+		"source_instance":    buildOnlineDataMigrationInstanceConfigBodyParams(d.Get("source_instance")),
+		"target_instance":    buildOnlineDataMigrationInstanceConfigBodyParams(d.Get("target_instance")),
+		"bandwidth_limit_mb": utils.ValueIgnoreEmpty(d.Get("bandwidth_limit_mb")),
+	}
+}
+
+func resourceDcsOnlineMigrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dcs", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DCS client: %s", err)
+	}
+
+	createPath := client.Endpoint + "v2/{project_id}/migration/instance"
+	createPath = strings.ReplaceAll(createPath, "{project_id}", client.ProjectID)
+	createOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody:         utils.RemoveNil(buildCreateOnlineDataMigrationTaskBodyParams(d)),
+	}
+	createResp, err := client.Request("POST", createPath, &createOpt)
+	if err != nil {
+		return diag.Errorf("error creating DCS online migration task: %s", err)
+	}
+	createRespBody, err := utils.FlattenResponse(createResp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	taskId := utils.PathSearch("instance_id", createRespBody, "").(string)
+	if taskId == "" {
+		return diag.Errorf("error creating DCS online migration task: instance_id is not found in API response")
+	}
+	d.SetId(taskId)
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	jobId := utils.PathSearch("job_id", createRespBody, "").(string)
+	if jobId == "" {
+		return diag.Errorf("error creating DCS online migration task: job_id is not found in API response")
+	}
+	if err = checkDcsInstanceJobFinish(ctx, client, jobId, timeout); err != nil {
+		return diag.FromErr(err)
+	}
+
+	configPath := client.Endpoint + "v2/{project_id}/migration/{task_id}/task"
+	configPath = strings.ReplaceAll(configPath, "{project_id}", client.ProjectID)
+	configPath = strings.ReplaceAll(configPath, "{task_id}", taskId)
+	configOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody:         utils.RemoveNil(buildOnlineMigrationConfigBodyParams(d)),
+	}
+	if _, err = client.Request("POST", configPath, &configOpt); err != nil {
+		return diag.Errorf("error starting DCS online migration task (%s): %s", taskId, err)
+	}
+
+	if err = checkMigrationTaskFinish(ctx, client, taskId, []string{"INCRMIGEATING"}, timeout); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = waitForOnlineMigrationSynced(ctx, client, taskId, d.Get("max_incremental_lag").(int), timeout); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("exchange_ip").(bool) {
+		if err = exchangeOnlineMigrationIp(ctx, client, d, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDcsOnlineMigrationRead(ctx, d, meta)
+}
+
+// getMigrationTaskStats queries the statistics of the migration task, including the progress of the full sync and the
+// remaining offset of the incremental sync.
+func getMigrationTaskStats(client *golangsdk.ServiceClient, taskId string) (interface{}, error) {
+	getPath := client.Endpoint + "v2/{project_id}/migration-task/{task_id}/stats"
+	getPath = strings.ReplaceAll(getPath, "{project_id}", client.ProjectID)
+	getPath = strings.ReplaceAll(getPath, "{task_id}", taskId)
+
+	getOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+	}
+	getResp, err := client.Request("GET", getPath, &getOpt)
+	if err != nil {
+		return nil, err
+	}
+	return utils.FlattenResponse(getResp)
+}
+
+// parseMigrationTaskStat parses the statistic value, which may be returned as a number or a string such as '100%'.
+func parseMigrationTaskStat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case string:
+		result, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(v), "%"), 64)
+		if err != nil {
+			log.Printf("[WARN] unable to parse the statistic (%s) of the migration task: %s", v, err)
+		}
+		return result
+	}
+	return 0
+}
+
+func flattenOnlineMigrationStats(stats interface{}) (float64, int) {
+	progress := parseMigrationTaskStat(utils.PathSearch("full_migration_progress", stats, nil))
+	// The offset is the remaining offset of the incremental sync.
+	lag := parseMigrationTaskStat(utils.PathSearch("offset", stats, nil))
+	return progress, int(lag)
+}
+
+// waitForOnlineMigrationSynced waits for the full sync to be completed and the remaining offset of the incremental
+// sync to drop under the threshold.
+func waitForOnlineMigrationSynced(ctx context.Context, client *golangsdk.ServiceClient, taskId string, maxLag int,
+	timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"PENDING"},
+		Target:  []string{"SYNCED"},
+		Refresh: func() (interface{}, string, error) {
+			task, err := getMigrationTask(client, taskId)
+			if err != nil {
+				return nil, "ERROR", err
+			}
+			status := utils.PathSearch("status", task, "").(string)
+			if utils.StrSliceContains(onlineMigrationAbnormalStatuses, status) {
+				return task, "ERROR", fmt.Errorf("unexpected status (%s) of the migration task", status)
+			}
+
+			stats, err := getMigrationTaskStats(client, taskId)
+			if err != nil {
+				return nil, "ERROR", err
+			}
+			progress, lag := flattenOnlineMigrationStats(stats)
+			log.Printf("[DEBUG] the full sync progress of the migration task (%s) is %v%%, the incremental lag is %d",
+				taskId, progress, lag)
+			if progress >= 100 && lag <= maxLag {
+				return stats, "SYNCED", nil
+			}
+			return stats, "PENDING", nil
+		},
+		Timeout:      timeout,
+		Delay:        10 * time.Second,
+		PollInterval: 30 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the migration task (%s) to be synced: %s", taskId, err)
+	}
+	return nil
+}
+
+func exchangeOnlineMigrationIp(ctx context.Context, client *golangsdk.ServiceClient, d *schema.ResourceData,
+	timeout time.Duration) error {
+	exchangePath := client.Endpoint + "v2/{project_id}/migration-task/{task_id}/exchange-ip"
+	exchangePath = strings.ReplaceAll(exchangePath, "{project_id}", client.ProjectID)
+	exchangePath = strings.ReplaceAll(exchangePath, "{task_id}", d.Id())
+	exchangeOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          []int{200, 204},
+		JSONBody:         utils.RemoveNil(buildCreateMigrationTaskExchangeIpBodyParams(d)),
+	}
+	if _, err := client.Request("POST", exchangePath, &exchangeOpt); err != nil {
+		return fmt.Errorf("error exchanging IP of the migration task (%s): %s", d.Id(), err)
+	}
+	return waitForOnlineMigrationIpSwitched(ctx, client, d.Id(), "EXCHANGE_SUCCESS", timeout)
+}
+
+func rollbackOnlineMigrationIp(ctx context.Context, client *golangsdk.ServiceClient, taskId string,
+	timeout time.Duration) error {
+	rollbackPath := client.Endpoint + "v2/{project_id}/migration-task/{task_id}/rollback-ip"
+	rollbackPath = strings.ReplaceAll(rollbackPath, "{project_id}", client.ProjectID)
+	rollbackPath = strings.ReplaceAll(rollbackPath, "{task_id}", taskId)
+	rollbackOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          []int{200, 204},
+	}
+	if _, err := client.Request("POST", rollbackPath, &rollbackOpt); err != nil {
+		return fmt.Errorf("error rolling back IP exchange of the migration task (%s): %s", taskId, err)
+	}
+	return waitForOnlineMigrationIpSwitched(ctx, client, taskId, "ROLLBACK_SUCCESS", timeout)
+}
+
+func waitForOnlineMigrationIpSwitched(ctx context.Context, client *golangsdk.ServiceClient, taskId, target string,
+	timeout time.Duration) error {
+	// The task status keeps the result of the last switch until the current one is completed.
+	pending := []string{"PENDING", "ROLLBACK_SUCCESS"}
+	if target == "ROLLBACK_SUCCESS" {
+		pending = []string{"PENDING", "EXCHANGE_SUCCESS"}
+	}
+	stateConf := &retry.StateChangeConf{
+		Pending:      pending,
+		Target:       []string{target},
+		Refresh:      migrationTaskSwitchIpRefreshFunc(client, taskId),
+		Timeout:      timeout,
+		Delay:        10 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the IP switch (%s) of the migration task (%s): %s", target, taskId, err)
+	}
+	return nil
+}
+
+func resourceDcsOnlineMigrationRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("dcs", region)
+	if err != nil {
+		return diag.Errorf("error creating DCS client: %s", err)
+	}
+
+	task, err := getMigrationTask(client, d.Id())
+	if err != nil {
+		return common.CheckDeletedDiag(d, common.ConvertExpected400ErrInto404Err(err, "error_code", "DCS.4133"),
+			"error retrieving DCS online migration task")
+	}
+	status := utils.PathSearch("status", task, "").(string)
+	if status == "DELETED" {
+		return common.CheckDeletedDiag(d, golangsdk.ErrDefault404{}, "error retrieving DCS online migration task")
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("task_name", utils.PathSearch("task_name", task, nil)),
+		d.Set("vpc_id", utils.PathSearch("tenant_vpc_id", task, nil)),
+		d.Set("subnet_id", utils.PathSearch("tenant_subnet_id", task, nil)),
+		d.Set("security_group_id", utils.PathSearch("tenant_security_group_id", task, nil)),
+		d.Set("description", utils.PathSearch("description", task, nil)),
+		d.Set("resume_mode", utils.PathSearch("resume_mode", task, nil)),
+		d.Set("bandwidth_limit_mb", utils.PathSearch("bandwidth_limit_mb", task, nil)),
+		d.Set("source_instance", flattenMigrationInstanceConfig(d, task, "source_instance")),
+		d.Set("target_instance", flattenMigrationInstanceConfig(d, task, "target_instance")),
+		d.Set("status", status),
+		d.Set("ip_exchange_status", utils.PathSearch("task_status", task, nil)),
+		d.Set("ecs_tenant_private_ip", utils.PathSearch("ecs_tenant_private_ip", task, nil)),
+		d.Set("created_at", utils.PathSearch("created_at", task, nil)),
+	)
+
+	// The statistics are only available when the task is running.
+	if stats, err := getMigrationTaskStats(client, d.Id()); err != nil {
+		log.Printf("[WARN] error retrieving statistics of the migration task (%s): %s", d.Id(), err)
+	} else {
+		progress, lag := flattenOnlineMigrationStats(stats)
+		mErr = multierror.Append(mErr,
+			d.Set("full_sync_progress", progress),
+			d.Set("incremental_lag", lag),
+		)
+	}
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+func resourceDcsOnlineMigrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dcs", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DCS client: %s", err)
+	}
+
+	if d.HasChange("exchange_ip") {
+		timeout := d.Timeout(schema.TimeoutUpdate)
+		if d.Get("exchange_ip").(bool) {
+			err = waitForOnlineMigrationSynced(ctx, client, d.Id(), d.Get("max_incremental_lag").(int), timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			err = exchangeOnlineMigrationIp(ctx, client, d, timeout)
+		} else {
+			err = rollbackOnlineMigrationIp(ctx, client, d.Id(), timeout)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDcsOnlineMigrationRead(ctx, d, meta)
+}
+
+func resourceDcsOnlineMigrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dcs", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DCS client: %s", err)
+	}
+
+	task, err := getMigrationTask(client, d.Id())
+	if err != nil {
+		return common.CheckDeletedDiag(d, common.ConvertExpected400ErrInto404Err(err, "error_code", "DCS.4133"),
+			"error retrieving DCS online migration task")
+	}
+
+	// The incremental sync is stopped before the task is deleted, so that the target instance is not written anymore.
+	timeout := d.Timeout(schema.TimeoutDelete)
+	if utils.PathSearch("status", task, "").(string) == "INCRMIGEATING" {
+		if err = stopOnlineDataMigrationTask(d, client); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = checkMigrationTaskFinish(ctx, client, d.Id(), []string{"TERMINATED"}, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err = deleteMigrationTask(d, client); err != nil {
+		return diag.Errorf("error deleting DCS online migration task (%s): %s", d.Id(), err)
+	}
+	if err = checkMigrationTaskDeleted(ctx, client, d.Id(), timeout); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package dds
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// The resource used to restart the DDS instances to make the changed parameters take effect.
+const ddsInstanceRestartResource = "huaweicloud_dds_instance_restart"
+
+// findDefaultParameterTemplateId returns the ID of the default parameter template of the node type and the version.
+func findDefaultParameterTemplateId(client *golangsdk.ServiceClient, nodeType, version string) (string, error) {
+	var (
+		httpUrl = "v3/{project_id}/configurations?limit=100"
+		offset  = 0
+	)
+	listPath := client.Endpoint + httpUrl
+	listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+	}
+	for {
+		listResp, err := client.Request("GET", fmt.Sprintf("%s&offset=%d", listPath, offset), &opt)
+		if err != nil {
+			return "", err
+		}
+		listRespBody, err := utils.FlattenResponse(listResp)
+		if err != nil {
+			return "", err
+		}
+
+		configurations := utils.PathSearch("configurations", listRespBody, make([]interface{}, 0)).([]interface{})
+		for _, v := range configurations {
+			if !utils.PathSearch("user_defined", v, true).(bool) &&
+				utils.PathSearch("node_type", v, "").(string) == nodeType &&
+				utils.PathSearch("datastore_version", v, "").(string) == version {
+				return utils.PathSearch("id", v, "").(string), nil
+			}
+		}
+		if len(configurations) < 100 {
+			break
+		}
+		offset += 100
+	}
+	return "", fmt.Errorf("unable to find the default parameter template of %s (%s)", nodeType, version)
+}
+
+// getParameterCatalog returns the parameter catalog of the node type and the version, which is built from the
+// default parameter template.
+func getParameterCatalog(client *golangsdk.ServiceClient, nodeType, version string) (common.ParameterCatalog, error) {
+	return common.GetParameterCatalog(client, nodeType, version, func() (common.ParameterCatalog, error) {
+		configId, err := findDefaultParameterTemplateId(client, nodeType, version)
+		if err != nil {
+			return nil, err
+		}
+
+		getPath := client.Endpoint + "v3/{project_id}/configurations/{config_id}"
+		getPath = strings.ReplaceAll(getPath, "{project_id}", client.ProjectID)
+		getPath = strings.ReplaceAll(getPath, "{config_id}", configId)
+		getOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+			MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+		}
+		getResp, err := client.Request("GET", getPath, &getOpt)
+		if err != nil {
+			return nil, err
+		}
+		getRespBody, err := utils.FlattenResponse(getResp)
+		if err != nil {
+			return nil, err
+		}
+
+		catalog := make(common.ParameterCatalog)
+		for _, v := range utils.PathSearch("parameters", getRespBody, make([]interface{}, 0)).([]interface{}) {
+			name := utils.PathSearch("name", v, "").(string)
+			catalog[name] = common.ParameterDefinition{
+				Name:            name,
+				Type:            utils.PathSearch("type", v, "").(string),
+				ValueRange:      utils.PathSearch("value_range", v, "").(string),
+				RestartRequired: utils.PathSearch("restart_required", v, false).(bool),
+				Readonly:        utils.PathSearch("readonly", v, false).(bool),
+			}
+		}
+		return catalog, nil
+	})
+}
+
+// checkParameterTemplateValues validates the added and changed parameter values against the parameter catalog, the
+// validation is skipped if the catalog is unavailable. The values which require a restart are reported as a warning
+// after they are applied, see buildParameterTemplateRestartWarning.
+func checkParameterTemplateValues(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("parameter_values") || !d.NewValueKnown("parameter_values") || !d.NewValueKnown("node_type") ||
+		!d.NewValueKnown("node_version") {
+		return nil
+	}
+
+	oldVal, newVal := d.GetChange("parameter_values")
+	params := common.GetChangedParameters(oldVal.(map[string]interface{}), newVal.(map[string]interface{}))
+	nodeType := d.Get("node_type").(string)
+	version := d.Get("node_version").(string)
+	if len(params) == 0 || nodeType == "" || version == "" {
+		return nil
+	}
+
+	cfg := meta.(*config.Config)
+	region := d.Get("region").(string)
+	if region == "" {
+		region = cfg.Region
+	}
+	client, err := cfg.NewServiceClient("dds", region)
+	if err != nil {
+		return fmt.Errorf("error creating DDS client: %s", err)
+	}
+
+	catalog, err := getParameterCatalog(client, nodeType, version)
+	if err != nil {
+		log.Printf("[WARN] unable to query the parameter catalog of %s (%s), skip validating the parameters: %s",
+			nodeType, version, err)
+		return nil
+	}
+	if _, err := common.ValidateParameters(catalog, params); err != nil {
+		return fmt.Errorf("invalid parameters for %s (%s): %s", nodeType, version, err)
+	}
+	return nil
+}
+
+// buildParameterTemplateRestartWarning returns the warning of the changed values which require a restart of the
+// instances to which the parameter template is applied.
+func buildParameterTemplateRestartWarning(d *schema.ResourceData, changedValues map[string]string) diag.Diagnostics {
+	restartNames := make([]string, 0)
+	for _, v := range d.Get("parameters").([]interface{}) {
+		param := v.(map[string]interface{})
+		if _, ok := changedValues[param["name"].(string)]; ok && param["restart_required"].(bool) {
+			restartNames = append(restartNames, param["name"].(string))
+		}
+	}
+	return common.BuildParameterRestartWarning(restartNames, ddsInstanceRestartResource)
+}
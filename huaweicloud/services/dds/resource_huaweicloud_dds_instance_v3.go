@@ -14,6 +14,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -35,6 +36,7 @@ var (
 )
 
 // @API DDS POST /v3/{project_id}/instances
+// @API DDS GET /v3/{project_id}/instances/{instance_id}/restore-time
 // @API DDS GET /v3/{project_id}/instances
 // @API DDS POST /v3/{project_id}/instances/{instance_id}/tags/action
 // @API DDS GET /v3/{project_id}/instances/{instance_id}/tags
@@ -89,7 +91,11 @@ func ResourceDdsInstanceV3() *schema.Resource {
 			Delete: schema.DefaultTimeout(60 * time.Minute),
 		},
 
-		CustomizeDiff: config.MergeDefaultTags(),
+		CustomizeDiff: customdiff.All(
+			config.MergeDefaultTags(),
+			common.CheckManagedMasterPasswordRotation,
+			common.CheckRestoreFromTime("dds", "restore_time"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -132,6 +138,7 @@ func ResourceDdsInstanceV3() *schema.Resource {
 				Required:         true,
 				DiffSuppressFunc: utils.SuppressStringSepratedByCommaDiffs,
 			},
+			"restore_from": common.RestoreFromSchema(nil),
 			"vpc_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -152,10 +159,13 @@ func ResourceDdsInstanceV3() *schema.Resource {
 				Computed: true,
 			},
 			"password": {
-				Type:      schema.TypeString,
-				Optional:  true,
-				Sensitive: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"manage_master_password"},
 			},
+			"manage_master_password":     common.ManageMasterPasswordSchema(),
+			"master_password_version_id": common.MasterPasswordVersionSchema(),
 			"disk_encryption_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -521,6 +531,24 @@ func buildChargeInfoParams(d *schema.ResourceData) instances.ChargeInfo {
 	return chargeInfo
 }
 
+// ddsRestoreCreateOpts is the create options with the restore point, which creates a new instance from the backup or
+// the point-in-time of the source instance.
+type ddsRestoreCreateOpts struct {
+	instances.CreateOpts
+	RestorePoint map[string]interface{}
+}
+
+func (opts ddsRestoreCreateOpts) ToInstancesCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOpts.ToInstancesCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.RestorePoint != nil {
+		b["restore_point"] = opts.RestorePoint
+	}
+	return b, nil
+}
+
 // nolint:gocyclo
 func resourceDdsInstanceV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conf := meta.(*config.Config)
@@ -556,12 +584,23 @@ func resourceDdsInstanceV3Create(ctx context.Context, d *schema.ResourceData, me
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
 	// Add password here so it wouldn't go in the above log entry
 	createOpts.Password = d.Get("password").(string)
+	if common.IsManagedMasterPassword(d) {
+		createOpts.Password, err = common.CreateManagedMasterPassword(d, conf, conf.GetRegion(d),
+			d.Get("name").(string)+"-master-password")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	if val, ok := d.GetOk("port"); ok {
 		createOpts.Port = strconv.Itoa(val.(int))
 	}
 
-	instance, err := instances.Create(client, createOpts).Extract()
+	restoreOpts := ddsRestoreCreateOpts{
+		CreateOpts:   createOpts,
+		RestorePoint: common.BuildRestorePointBodyParams(d),
+	}
+	instance, err := instances.Create(client, restoreOpts).Extract()
 	if err != nil {
 		return diag.Errorf("Error getting instance from result: %s ", err)
 	}
@@ -1258,7 +1297,7 @@ func resourceDdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 		opts = append(opts, opt)
 	}
 
-	if d.HasChange("password") {
+	if d.HasChange("password") && !common.IsManagedMasterPassword(d) {
 		opt := instances.UpdateOpt{
 			Param:  "user_pwd",
 			Value:  d.Get("password").(string),
@@ -1335,6 +1374,14 @@ func resourceDdsInstanceV3Update(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
+	err = common.UpdateManagedMasterPassword(d, cfg, region, d.Get("name").(string)+"-master-password",
+		func(password string) error {
+			return resetDdsInstancePassword(ctx, d, client, password)
+		})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	if d.HasChange("balancer_status") {
 		err = updateBalancerStatus(ctx, client, d.Timeout(schema.TimeoutUpdate), instanceId, d.Get("balancer_status").(string))
 		if err != nil {
@@ -1624,6 +1671,41 @@ func resourceDdsInstanceV3Delete(ctx context.Context, d *schema.ResourceData, me
 			instanceId, err)
 	}
 	log.Printf("[DEBUG] Successfully deleted instance %s", instanceId)
+
+	if err = common.DeleteManagedMasterPassword(d, conf, conf.GetRegion(d)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// resetDdsInstancePassword sets the password generated by the managed master password mode.
+func resetDdsInstancePassword(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient,
+	password string) error {
+	opts := []instances.UpdateOpt{
+		{
+			Param:  "user_pwd",
+			Value:  password,
+			Action: "reset-password",
+			Method: "put",
+		},
+	}
+	retryFunc := func() (interface{}, bool, error) {
+		resp, err := instances.Update(client, d.Id(), opts).Extract()
+		shouldRetry, err := handleMultiOperationsError(err)
+		return resp, shouldRetry, err
+	}
+	_, err := common.RetryContextWithWaitForState(&common.RetryContextWithWaitForStateParam{
+		Ctx:          ctx,
+		RetryFunc:    retryFunc,
+		WaitFunc:     ddsInstanceStateRefreshFunc(client, d.Id()),
+		WaitTarget:   []string{"normal"},
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		DelayTimeout: 1 * time.Second,
+		PollInterval: 10 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("error resetting password of the DDS instance (%s): %s", d.Id(), err)
+	}
 	return nil
 }
 
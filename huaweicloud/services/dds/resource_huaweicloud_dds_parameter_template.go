@@ -35,6 +35,8 @@ func ResourceDdsParameterTemplate() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: checkParameterTemplateValues,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -220,11 +222,17 @@ func resourceDdsParameterTemplateUpdate(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("error creating DDS Client: %s", err)
 	}
 
+	oldValues, newValues := d.GetChange("parameter_values")
+	changedValues := common.GetChangedParameters(oldValues.(map[string]interface{}), newValues.(map[string]interface{}))
 	if err := updateParameterTemplate(client, d); err != nil {
 		return diag.FromErr(err)
 	}
 
-	return resourceDdsParameterTemplateRead(ctx, d, meta)
+	diags := resourceDdsParameterTemplateRead(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, buildParameterTemplateRestartWarning(d, changedValues)...)
 }
 
 func updateParameterTemplate(client *golangsdk.ServiceClient, d *schema.ResourceData) error {
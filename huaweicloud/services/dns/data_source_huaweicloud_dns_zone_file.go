@@ -0,0 +1,77 @@
+package dns
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk/openstack/dns/v2/zones"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// @API DNS GET /v2/zones/{zone_id}
+// @API DNS GET /v2/zones/{zone_id}/recordsets
+// @API DNS GET /v2.1/zones/{zone_id}/recordsets
+func DataSourceZoneFile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZoneFileRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"zone_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The ID of the zone to be exported.`,
+			},
+			"zone_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The name of the zone.`,
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The content of the zone in BIND format.`,
+			},
+		},
+	}
+}
+
+func dataSourceZoneFileRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var (
+		cfg    = meta.(*config.Config)
+		region = cfg.GetRegion(d)
+		zoneID = d.Get("zone_id").(string)
+	)
+
+	client, zoneType, err := chooseDNSClientbyZoneID(d, zoneID, meta)
+	if err != nil {
+		return diag.Errorf("error retrieving DNS zone (%s): %s", zoneID, err)
+	}
+
+	zone, err := zones.Get(client, zoneID).Extract()
+	if err != nil {
+		return diag.Errorf("error retrieving DNS zone (%s): %s", zoneID, err)
+	}
+
+	recordsets, err := listZoneRecordsets(client, zoneID, zoneType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(zoneID)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("zone_name", zone.Name),
+		d.Set("content", renderZoneFile(zone.Name, recordsets)),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
@@ -0,0 +1,101 @@
+package dns
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceZoneFileRecords is used to parse the BIND zone file into the recordsets, and it does not call any API.
+func DataSourceZoneFileRecords() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZoneFileRecordsRead,
+
+		Schema: map[string]*schema.Schema{
+			"content": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The content of the zone file in BIND format.`,
+			},
+			"origin": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The origin used to qualify the relative names before the first $ORIGIN directive.`,
+			},
+			"default_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     300,
+				Description: `The TTL used by the records without TTL before the first $TTL directive.`,
+			},
+			"recordsets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        zoneFileRecordsetSchema(),
+				Description: `The list of the recordsets parsed from the zone file.`,
+			},
+		},
+	}
+}
+
+func zoneFileRecordsetSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The fully qualified name of the recordset.`,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The type of the recordset.`,
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The TTL of the recordset.`,
+			},
+			"records": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The values of the recordset.`,
+			},
+		},
+	}
+}
+
+func dataSourceZoneFileRecordsRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	recordsets, err := parseZoneFile(d.Get("content").(string), d.Get("origin").(string), d.Get("default_ttl").(int))
+	if err != nil {
+		return diag.Errorf("error parsing zone file: %s", err)
+	}
+
+	generateUUID, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(generateUUID)
+
+	mErr := multierror.Append(nil,
+		d.Set("recordsets", flattenZoneFileRecordsets(recordsets)),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+func flattenZoneFileRecordsets(recordsets []zoneFileRecordset) []interface{} {
+	result := make([]interface{}, 0, len(recordsets))
+	for _, v := range recordsets {
+		result = append(result, map[string]interface{}{
+			"name":    v.Name,
+			"type":    v.Type,
+			"ttl":     v.TTL,
+			"records": v.Records,
+		})
+	}
+	return result
+}
@@ -0,0 +1,342 @@
+package dns
+
+// This set of code handles the conversion between the BIND zone file (RFC 1035 master file) and the DNS recordsets.
+//
+// Only the directives $ORIGIN and $TTL are supported, the SOA records and the NS records of the zone apex are skipped
+// while parsing because they are maintained by the DNS service.
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// zoneFileRecordset is a group of records which have the same name and type.
+type zoneFileRecordset struct {
+	Name    string
+	Type    string
+	TTL     int
+	Records []string
+}
+
+var zoneFileRecordTypes = []string{"A", "AAAA", "MX", "CNAME", "TXT", "NS", "SRV", "CAA", "PTR", "SOA"}
+
+// zoneFileLine is a logical line of the zone file, the content enclosed in parentheses is joined into one line.
+type zoneFileLine struct {
+	number     int
+	tokens     []string
+	ownerBlank bool
+}
+
+// splitZoneFileLines splits the zone file content into logical lines and tokens. Comments are removed and the quoted
+// strings are kept as a single token (including the quotes).
+func splitZoneFileLines(content string) ([]zoneFileLine, error) {
+	var (
+		result     = make([]zoneFileLine, 0)
+		current    = zoneFileLine{number: 1}
+		token      strings.Builder
+		lineNumber = 1
+		depth      = 0
+		inQuote    = false
+		inComment  = false
+		lineStart  = true
+	)
+
+	flushToken := func() {
+		if token.Len() > 0 {
+			current.tokens = append(current.tokens, token.String())
+			token.Reset()
+		}
+	}
+
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		if lineStart {
+			current.ownerBlank = c == ' ' || c == '\t'
+			lineStart = false
+		}
+
+		switch {
+		case c == '\n':
+			if inQuote {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", lineNumber)
+			}
+			inComment = false
+			lineNumber++
+			if depth > 0 {
+				flushToken()
+				continue
+			}
+			flushToken()
+			if len(current.tokens) > 0 {
+				result = append(result, current)
+			}
+			current = zoneFileLine{number: lineNumber}
+			lineStart = true
+		case inComment:
+			continue
+		case inQuote:
+			token.WriteRune(c)
+			if c == '\\' && i+1 < len(runes) {
+				i++
+				token.WriteRune(runes[i])
+			} else if c == '"' {
+				inQuote = false
+			}
+		case c == '"':
+			token.WriteRune(c)
+			inQuote = true
+		case c == ';':
+			flushToken()
+			inComment = true
+		case c == '(':
+			flushToken()
+			depth++
+		case c == ')':
+			flushToken()
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+			}
+			depth--
+		case unicode.IsSpace(c):
+			flushToken()
+		default:
+			token.WriteRune(c)
+		}
+	}
+
+	if inQuote {
+		return nil, fmt.Errorf("line %d: unterminated quoted string", lineNumber)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+	}
+	flushToken()
+	if len(current.tokens) > 0 {
+		result = append(result, current)
+	}
+	return result, nil
+}
+
+// parseZoneFileTTL parses the TTL value, the BIND time units (s, m, h, d and w) are supported, e.g. 1h30m.
+func parseZoneFileTTL(s string) (int, bool) {
+	if v, err := strconv.Atoi(s); err == nil {
+		return v, v >= 0
+	}
+
+	units := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var total, num int
+	hasDigit := false
+	for _, c := range strings.ToLower(s) {
+		if unicode.IsDigit(c) {
+			num = num*10 + int(c-'0')
+			hasDigit = true
+			continue
+		}
+		unit, ok := units[c]
+		if !ok || !hasDigit {
+			return 0, false
+		}
+		total += num * unit
+		num = 0
+		hasDigit = false
+	}
+	if hasDigit {
+		return 0, false
+	}
+	return total, true
+}
+
+// qualifyZoneFileName converts the name to a fully qualified domain name (ended with a dot).
+func qualifyZoneFileName(name, origin string) string {
+	if name == "@" {
+		return origin
+	}
+	if strings.HasSuffix(name, ".") {
+		return strings.ToLower(name)
+	}
+	if origin == "" {
+		return strings.ToLower(name) + "."
+	}
+	return strings.ToLower(name) + "." + origin
+}
+
+// buildZoneFileRecordValue builds the record value in the format accepted by the DNS service. The domain names in the
+// record data are qualified with the origin.
+func buildZoneFileRecordValue(recordType string, rdata []string, origin string) (string, error) {
+	// The index of the domain name in the record data.
+	nameIndex := map[string]int{"CNAME": 0, "NS": 0, "PTR": 0, "MX": 1, "SRV": 3}
+	minLength := map[string]int{"A": 1, "AAAA": 1, "CNAME": 1, "NS": 1, "PTR": 1, "TXT": 1, "MX": 2, "SRV": 4, "CAA": 3}
+	if len(rdata) < minLength[recordType] {
+		return "", fmt.Errorf("the %s record requires at least %d value(s), but got %d", recordType,
+			minLength[recordType], len(rdata))
+	}
+
+	values := make([]string, len(rdata))
+	copy(values, rdata)
+	if index, ok := nameIndex[recordType]; ok {
+		values[index] = qualifyZoneFileName(values[index], origin)
+	}
+	if recordType == "TXT" {
+		for i, v := range values {
+			if !strings.HasPrefix(v, `"`) {
+				values[i] = strconv.Quote(v)
+			}
+		}
+	}
+	return strings.Join(values, " "), nil
+}
+
+// parseZoneFile parses the BIND zone file content and groups the records by name and type.
+// The records are returned in the order they first appear in the file.
+func parseZoneFile(content, origin string, defaultTTL int) ([]zoneFileRecordset, error) {
+	lines, err := splitZoneFileLines(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if origin != "" {
+		origin = qualifyZoneFileName(origin, "")
+	}
+	var (
+		result    = make([]zoneFileRecordset, 0)
+		indexes   = make(map[string]int)
+		lastOwner string
+	)
+	for _, line := range lines {
+		tokens := line.tokens
+		if strings.HasPrefix(tokens[0], "$") {
+			directive := strings.ToUpper(tokens[0])
+			if len(tokens) < 2 {
+				return nil, fmt.Errorf("line %d: missing value of the directive %s", line.number, directive)
+			}
+			switch directive {
+			case "$ORIGIN":
+				origin = qualifyZoneFileName(tokens[1], origin)
+			case "$TTL":
+				ttl, ok := parseZoneFileTTL(tokens[1])
+				if !ok {
+					return nil, fmt.Errorf("line %d: invalid TTL value (%s)", line.number, tokens[1])
+				}
+				defaultTTL = ttl
+			default:
+				return nil, fmt.Errorf("line %d: unsupported directive %s", line.number, directive)
+			}
+			continue
+		}
+
+		owner := lastOwner
+		if !line.ownerBlank {
+			owner = qualifyZoneFileName(tokens[0], origin)
+			tokens = tokens[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("line %d: missing owner name", line.number)
+		}
+		lastOwner = owner
+
+		// The TTL and class are both optional and can appear in any order before the type.
+		ttl := defaultTTL
+		for len(tokens) > 0 {
+			if v, ok := parseZoneFileTTL(tokens[0]); ok {
+				ttl = v
+			} else if !utils.StrSliceContains([]string{"IN", "CH", "HS"}, strings.ToUpper(tokens[0])) {
+				break
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing record type", line.number)
+		}
+
+		recordType := strings.ToUpper(tokens[0])
+		if !utils.StrSliceContains(zoneFileRecordTypes, recordType) {
+			return nil, fmt.Errorf("line %d: unsupported record type (%s)", line.number, tokens[0])
+		}
+		if recordType == "SOA" || (recordType == "NS" && owner == origin) {
+			continue
+		}
+
+		value, err := buildZoneFileRecordValue(recordType, tokens[1:], origin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line.number, err)
+		}
+
+		key := owner + "|" + recordType
+		if index, ok := indexes[key]; ok {
+			if !utils.StrSliceContains(result[index].Records, value) {
+				result[index].Records = append(result[index].Records, value)
+			}
+			continue
+		}
+		indexes[key] = len(result)
+		result = append(result, zoneFileRecordset{
+			Name:    owner,
+			Type:    recordType,
+			TTL:     ttl,
+			Records: []string{value},
+		})
+	}
+	return result, nil
+}
+
+// relativeZoneFileName returns the name relative to the origin, the origin itself is rendered as '@'.
+func relativeZoneFileName(name, origin string) string {
+	name = strings.ToLower(name)
+	if name == origin {
+		return "@"
+	}
+	if strings.HasSuffix(name, "."+origin) {
+		return strings.TrimSuffix(name, "."+origin)
+	}
+	return name
+}
+
+// renderZoneFile renders the recordsets of the zone in BIND format. The line and weight of the recordset, which cannot
+// be expressed in the zone file, are rendered as comments.
+func renderZoneFile(zoneName string, recordsets []interface{}) string {
+	origin := qualifyZoneFileName(zoneName, "")
+	sorted := make([]interface{}, len(recordsets))
+	copy(sorted, recordsets)
+	sortKey := func(v interface{}) string {
+		recordType := utils.PathSearch("type", v, "").(string)
+		// Keep the SOA record at the top of the file.
+		if recordType == "SOA" {
+			return ""
+		}
+		return fmt.Sprintf("%s|%s|%s", strings.ToLower(utils.PathSearch("name", v, "").(string)), recordType,
+			utils.PathSearch("line", v, "").(string))
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sortKey(sorted[i]) < sortKey(sorted[j])
+	})
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("$ORIGIN %s\n", origin))
+	for _, v := range sorted {
+		name := relativeZoneFileName(utils.PathSearch("name", v, "").(string), origin)
+		recordType := utils.PathSearch("type", v, "").(string)
+		ttl := int(utils.PathSearch("ttl", v, float64(0)).(float64))
+
+		line := utils.PathSearch("line", v, "").(string)
+		weight := utils.PathSearch("weight", v, nil)
+		if line != "" && line != "default_view" {
+			b.WriteString(fmt.Sprintf("; line: %s\n", line))
+		}
+		if weight != nil {
+			b.WriteString(fmt.Sprintf("; weight: %v\n", weight))
+		}
+
+		records := utils.ExpandToStringList(utils.PathSearch("records", v, make([]interface{}, 0)).([]interface{}))
+		sort.Strings(records)
+		for _, record := range records {
+			b.WriteString(fmt.Sprintf("%s\t%d\tIN\t%s\t%s\n", name, ttl, recordType, record))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,652 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/pagination"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// The maximum number of recordsets that can be created, updated or deleted in one batch request.
+const zoneRecordsetsBatchSize = 100
+
+// @API DNS GET /v2/zones/{zone_id}
+// @API DNS GET /v2/zones/{zone_id}/recordsets
+// @API DNS POST /v2/zones/{zone_id}/recordsets
+// @API DNS GET /v2/zones/{zone_id}/recordsets/{recordset_id}
+// @API DNS PUT /v2/zones/{zone_id}/recordsets/{recordset_id}
+// @API DNS DELETE /v2/zones/{zone_id}/recordsets/{recordset_id}
+// @API DNS GET /v2.1/zones/{zone_id}/recordsets
+// @API DNS POST /v2.1/zones/{zone_id}/recordsets/batch/lines
+// @API DNS GET /v2.1/zones/{zone_id}/recordsets/{recordset_id}
+// @API DNS PUT /v2.1/zones/{zone_id}/recordsets
+// @API DNS DELETE /v2.1/recordsets
+func ResourceZoneRecords() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZoneRecordsCreate,
+		ReadContext:   resourceZoneRecordsRead,
+		UpdateContext: resourceZoneRecordsUpdate,
+		DeleteContext: resourceZoneRecordsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceZoneRecordsImportState,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"zone_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The ID of the zone whose recordsets are managed.`,
+			},
+			"recordsets": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        zoneRecordsRecordsetSchema(),
+				Description: `The complete list of the recordsets in the zone.`,
+			},
+		},
+	}
+}
+
+func zoneRecordsRecordsetSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The name of the recordset.`,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"A", "AAAA", "MX", "CNAME", "TXT", "NS", "SRV", "CAA", "PTR",
+				}, false),
+				Description: `The type of the recordset.`,
+			},
+			"records": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The values of the recordset.`,
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				ValidateFunc: validation.IntBetween(1, 2147483647),
+				Description:  `The time to live (TTL) of the recordset, in seconds.`,
+			},
+			"line_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The resolution line ID of the recordset, only available for public zone.`,
+			},
+			"weight": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 1000),
+				Description:  `The weight of the recordset, only available for public zone.`,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The description of the recordset.`,
+			},
+		},
+	}
+}
+
+func normalizeZoneRecordsetName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+}
+
+func normalizeZoneRecordsetLine(line, zoneType string) string {
+	if line == "" && zoneType != "private" {
+		return "default_view"
+	}
+	return line
+}
+
+func zoneRecordsetKey(name, recordType, line, zoneType string) string {
+	return fmt.Sprintf("%s|%s|%s", normalizeZoneRecordsetName(name), recordType,
+		normalizeZoneRecordsetLine(line, zoneType))
+}
+
+func desiredZoneRecordsetKey(desired map[string]interface{}, zoneType string) string {
+	return zoneRecordsetKey(desired["name"].(string), desired["type"].(string), desired["line_id"].(string), zoneType)
+}
+
+func remoteZoneRecordsetKey(remote interface{}, zoneType string) string {
+	return zoneRecordsetKey(utils.PathSearch("name", remote, "").(string), utils.PathSearch("type", remote, "").(string),
+		utils.PathSearch("line", remote, "").(string), zoneType)
+}
+
+// listZoneRecordsets queries all recordsets of the zone, including the default SOA and NS recordsets.
+func listZoneRecordsets(client *golangsdk.ServiceClient, zoneID, zoneType string) ([]interface{}, error) {
+	// The private zone can only use v2 version API. The public zone use v2.1 version API
+	version := getApiVersionByZoneType(zoneType)
+	listPath := client.Endpoint + fmt.Sprintf("%s/zones/{zone_id}/recordsets", version)
+	listPath = strings.ReplaceAll(listPath, "{zone_id}", zoneID)
+
+	listResp, err := pagination.ListAllItems(client, "offset", listPath, &pagination.QueryOpts{MarkerField: ""})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving recordsets of DNS zone (%s): %s", zoneID, err)
+	}
+
+	listRespJson, err := json.Marshal(listResp)
+	if err != nil {
+		return nil, err
+	}
+	var listRespBody interface{}
+	if err := json.Unmarshal(listRespJson, &listRespBody); err != nil {
+		return nil, err
+	}
+	return utils.PathSearch("recordsets", listRespBody, make([]interface{}, 0)).([]interface{}), nil
+}
+
+// listManagedZoneRecordsets queries the recordsets of the zone which can be managed by the user, the default recordsets
+// (SOA and NS records of the zone apex) are maintained by the DNS service.
+func listManagedZoneRecordsets(client *golangsdk.ServiceClient, zoneID, zoneType string) ([]interface{}, error) {
+	recordsets, err := listZoneRecordsets(client, zoneID, zoneType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0, len(recordsets))
+	for _, v := range recordsets {
+		if utils.PathSearch("default", v, false).(bool) || utils.PathSearch("type", v, "").(string) == "SOA" {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+func isZoneRecordsetEqual(desired map[string]interface{}, remote interface{}, zoneType string) bool {
+	if desiredZoneRecordsetKey(desired, zoneType) != remoteZoneRecordsetKey(remote, zoneType) {
+		return false
+	}
+	if desired["ttl"].(int) != int(utils.PathSearch("ttl", remote, float64(0)).(float64)) {
+		return false
+	}
+	if desired["description"].(string) != utils.PathSearch("description", remote, "").(string) {
+		return false
+	}
+	// The weight is not managed if it is omitted.
+	if weight := desired["weight"].(int); weight != 0 &&
+		weight != int(utils.PathSearch("weight", remote, float64(0)).(float64)) {
+		return false
+	}
+
+	records := utils.PathSearch("records", remote, make([]interface{}, 0)).([]interface{})
+	return desired["records"].(*schema.Set).Equal(schema.NewSet(schema.HashString, records))
+}
+
+func buildZoneRecordsetBodyParams(desired map[string]interface{}, zoneType string,
+	isCreate bool) map[string]interface{} {
+	bodyParams := map[string]interface{}{
+		"name":        desired["name"],
+		"type":        desired["type"],
+		"ttl":         desired["ttl"],
+		"records":     desired["records"].(*schema.Set).List(),
+		"description": desired["description"],
+		"weight":      utils.ValueIgnoreEmpty(desired["weight"]),
+	}
+	// The line of the recordset can not be changed.
+	if isCreate && zoneType != "private" {
+		bodyParams["line"] = utils.ValueIgnoreEmpty(desired["line_id"])
+	}
+	return bodyParams
+}
+
+func createZoneRecordset(client *golangsdk.ServiceClient, zoneID, zoneType string,
+	desired map[string]interface{}) (string, error) {
+	createPath := client.Endpoint + fmt.Sprintf("%s/zones/{zone_id}/recordsets", getApiVersionByZoneType(zoneType))
+	createPath = strings.ReplaceAll(createPath, "{zone_id}", zoneID)
+	createOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          []int{202},
+		JSONBody:         utils.RemoveNil(buildZoneRecordsetBodyParams(desired, zoneType, true)),
+	}
+	createResp, err := client.Request("POST", createPath, &createOpt)
+	if err != nil {
+		return "", fmt.Errorf("error creating DNS recordset (%s %s): %s", desired["name"], desired["type"], err)
+	}
+
+	createRespBody, err := utils.FlattenResponse(createResp)
+	if err != nil {
+		return "", err
+	}
+	recordsetID := utils.PathSearch("id", createRespBody, "").(string)
+	if recordsetID == "" {
+		return "", fmt.Errorf("unable to find the DNS recordset ID from the API response")
+	}
+	return recordsetID, nil
+}
+
+func updateZoneRecordset(client *golangsdk.ServiceClient, zoneID, zoneType, recordsetID string,
+	desired map[string]interface{}) error {
+	updatePath := client.Endpoint + fmt.Sprintf("%s/zones/{zone_id}/recordsets/{recordset_id}",
+		getApiVersionByZoneType(zoneType))
+	updatePath = strings.ReplaceAll(updatePath, "{zone_id}", zoneID)
+	updatePath = strings.ReplaceAll(updatePath, "{recordset_id}", recordsetID)
+	updateOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          []int{202},
+		JSONBody:         utils.RemoveNil(buildZoneRecordsetBodyParams(desired, zoneType, false)),
+	}
+	_, err := client.Request("PUT", updatePath, &updateOpt)
+	if err != nil {
+		return fmt.Errorf("error updating DNS recordset (%s): %s", recordsetID, err)
+	}
+	return nil
+}
+
+// batchCreateZoneRecordsets creates the recordsets of the public zone in batches, the recordsets with the same name,
+// type, TTL and description are created by one request with their lines.
+func batchCreateZoneRecordsets(client *golangsdk.ServiceClient, zoneID string,
+	desiredList []map[string]interface{}) ([]string, error) {
+	groups := make(map[string][]map[string]interface{})
+	groupKeys := make([]string, 0)
+	for _, desired := range desiredList {
+		key := fmt.Sprintf("%s|%s|%d|%s", normalizeZoneRecordsetName(desired["name"].(string)), desired["type"],
+			desired["ttl"], desired["description"])
+		if _, ok := groups[key]; !ok {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], desired)
+	}
+
+	createPath := client.Endpoint + "v2.1/zones/{zone_id}/recordsets/batch/lines"
+	createPath = strings.ReplaceAll(createPath, "{zone_id}", zoneID)
+	result := make([]string, 0, len(desiredList))
+	for _, key := range groupKeys {
+		group := groups[key]
+		for start := 0; start < len(group); start += zoneRecordsetsBatchSize {
+			end := start + zoneRecordsetsBatchSize
+			if end > len(group) {
+				end = len(group)
+			}
+			lines := make([]map[string]interface{}, 0, end-start)
+			for _, desired := range group[start:end] {
+				lines = append(lines, map[string]interface{}{
+					"line":    normalizeZoneRecordsetLine(desired["line_id"].(string), "public"),
+					"records": desired["records"].(*schema.Set).List(),
+					"weight":  utils.ValueIgnoreEmpty(desired["weight"]),
+				})
+			}
+			createOpt := golangsdk.RequestOpts{
+				KeepResponseBody: true,
+				OkCodes:          []int{200, 202},
+				JSONBody: utils.RemoveNil(map[string]interface{}{
+					"name":        group[0]["name"],
+					"type":        group[0]["type"],
+					"ttl":         group[0]["ttl"],
+					"description": utils.ValueIgnoreEmpty(group[0]["description"]),
+					"lines":       lines,
+				}),
+			}
+			createResp, err := client.Request("POST", createPath, &createOpt)
+			if err != nil {
+				return result, fmt.Errorf("error creating DNS recordsets (%s %s): %s", group[0]["name"],
+					group[0]["type"], err)
+			}
+
+			createRespBody, err := utils.FlattenResponse(createResp)
+			if err != nil {
+				return result, err
+			}
+			ids := utils.PathSearch("recordsets[*].id", createRespBody, make([]interface{}, 0)).([]interface{})
+			if len(ids) != end-start {
+				return result, fmt.Errorf("expected %d DNS recordsets (%s %s) to be created, but got %d",
+					end-start, group[0]["name"], group[0]["type"], len(ids))
+			}
+			for _, id := range ids {
+				result = append(result, id.(string))
+			}
+		}
+	}
+	return result, nil
+}
+
+// batchUpdateZoneRecordsets updates the recordsets of the public zone in batches, the key of the map is the recordset
+// ID.
+func batchUpdateZoneRecordsets(client *golangsdk.ServiceClient, zoneID string,
+	desiredMap map[string]map[string]interface{}) error {
+	recordsets := make([]interface{}, 0, len(desiredMap))
+	for recordsetID, desired := range desiredMap {
+		bodyParams := buildZoneRecordsetBodyParams(desired, "public", false)
+		bodyParams["id"] = recordsetID
+		recordsets = append(recordsets, utils.RemoveNil(bodyParams))
+	}
+
+	updatePath := client.Endpoint + "v2.1/zones/{zone_id}/recordsets"
+	updatePath = strings.ReplaceAll(updatePath, "{zone_id}", zoneID)
+	for start := 0; start < len(recordsets); start += zoneRecordsetsBatchSize {
+		end := start + zoneRecordsetsBatchSize
+		if end > len(recordsets) {
+			end = len(recordsets)
+		}
+		updateOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+			OkCodes:          []int{200, 202},
+			JSONBody: map[string]interface{}{
+				"recordsets": recordsets[start:end],
+			},
+		}
+		if _, err := client.Request("PUT", updatePath, &updateOpt); err != nil {
+			return fmt.Errorf("error updating DNS recordsets of zone (%s): %s", zoneID, err)
+		}
+	}
+	return nil
+}
+
+// deleteZoneRecordsets deletes the recordsets. The recordsets of the public zone are deleted in batches, and the
+// recordsets of the private zone are deleted one by one because the batch API is not supported.
+func deleteZoneRecordsets(client *golangsdk.ServiceClient, zoneID, zoneType string, recordsetIDs []string) error {
+	if zoneType == "private" {
+		for _, recordsetID := range recordsetIDs {
+			deletePath := client.Endpoint + "v2/zones/{zone_id}/recordsets/{recordset_id}"
+			deletePath = strings.ReplaceAll(deletePath, "{zone_id}", zoneID)
+			deletePath = strings.ReplaceAll(deletePath, "{recordset_id}", recordsetID)
+			deleteOpt := golangsdk.RequestOpts{
+				KeepResponseBody: true,
+				OkCodes:          []int{202},
+			}
+			if _, err := client.Request("DELETE", deletePath, &deleteOpt); err != nil {
+				return fmt.Errorf("error deleting DNS recordset (%s): %s", recordsetID, err)
+			}
+		}
+		return nil
+	}
+
+	for start := 0; start < len(recordsetIDs); start += zoneRecordsetsBatchSize {
+		end := start + zoneRecordsetsBatchSize
+		if end > len(recordsetIDs) {
+			end = len(recordsetIDs)
+		}
+		deleteOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+			OkCodes:          []int{200, 202, 204},
+			JSONBody: map[string]interface{}{
+				"recordset_ids": recordsetIDs[start:end],
+			},
+		}
+		if _, err := client.Request("DELETE", client.Endpoint+"v2.1/recordsets", &deleteOpt); err != nil {
+			return fmt.Errorf("error deleting DNS recordsets (%s): %s", strings.Join(recordsetIDs[start:end], ","), err)
+		}
+	}
+	return nil
+}
+
+// applyZoneRecordsets makes the recordsets of the zone the same as the desired ones. The changes are calculated per
+// name, type and line: the recordsets with the same content are kept, the recordsets with the same key are updated,
+// and the others are created or deleted. The recordsets of the public zone are created, updated and deleted by the
+// batch APIs. All requests are submitted before waiting for the recordsets to be ready.
+func applyZoneRecordsets(ctx context.Context, client *golangsdk.ServiceClient, zoneID, zoneType string,
+	desiredList []interface{}, timeout time.Duration) error {
+	config.MutexKV.Lock(zoneID)
+	defer config.MutexKV.Unlock(zoneID)
+
+	remoteList, err := listManagedZoneRecordsets(client, zoneID, zoneType)
+	if err != nil {
+		return err
+	}
+
+	matched := make([]bool, len(remoteList))
+	pending := make([]map[string]interface{}, 0)
+	for _, v := range desiredList {
+		desired := v.(map[string]interface{})
+		if zoneType == "private" && (desired["line_id"].(string) != "" || desired["weight"].(int) != 0) {
+			return fmt.Errorf("the recordset (%s %s) of the private zone does not support line_id and weight",
+				desired["name"], desired["type"])
+		}
+
+		found := false
+		for i, remote := range remoteList {
+			if !matched[i] && isZoneRecordsetEqual(desired, remote, zoneType) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			pending = append(pending, desired)
+		}
+	}
+
+	toUpdate := make(map[string]map[string]interface{})
+	toCreate := make([]map[string]interface{}, 0)
+	for _, desired := range pending {
+		key := desiredZoneRecordsetKey(desired, zoneType)
+		found := false
+		for i, remote := range remoteList {
+			if !matched[i] && remoteZoneRecordsetKey(remote, zoneType) == key {
+				matched[i] = true
+				found = true
+				toUpdate[utils.PathSearch("id", remote, "").(string)] = desired
+				break
+			}
+		}
+		if !found {
+			toCreate = append(toCreate, desired)
+		}
+	}
+
+	toDelete := make([]string, 0)
+	for i, remote := range remoteList {
+		if !matched[i] {
+			toDelete = append(toDelete, utils.PathSearch("id", remote, "").(string))
+		}
+	}
+	log.Printf("[DEBUG] applying recordsets of DNS zone (%s): %d to create, %d to update, %d to delete", zoneID,
+		len(toCreate), len(toUpdate), len(toDelete))
+
+	// Delete the recordsets first to avoid the conflicts, e.g. a CNAME record replaces an A record with the same name.
+	if len(toDelete) > 0 {
+		if err := deleteZoneRecordsets(client, zoneID, zoneType, toDelete); err != nil {
+			return err
+		}
+		for _, recordsetID := range toDelete {
+			waitForConfig := &WaitForConfig{
+				ZoneID:      zoneID,
+				RecordsetID: recordsetID,
+				ZoneType:    zoneType,
+				Timeout:     timeout,
+			}
+			if err := waitForDNSRecordsetDeleted(ctx, client, waitForConfig); err != nil {
+				return err
+			}
+		}
+	}
+
+	changedIDs := make([]string, 0, len(toUpdate)+len(toCreate))
+	for recordsetID := range toUpdate {
+		changedIDs = append(changedIDs, recordsetID)
+	}
+	if zoneType == "private" {
+		// The batch APIs are not supported by the private zone.
+		for recordsetID, desired := range toUpdate {
+			if err := updateZoneRecordset(client, zoneID, zoneType, recordsetID, desired); err != nil {
+				return err
+			}
+		}
+		for _, desired := range toCreate {
+			recordsetID, err := createZoneRecordset(client, zoneID, zoneType, desired)
+			if err != nil {
+				return err
+			}
+			changedIDs = append(changedIDs, recordsetID)
+		}
+	} else {
+		if len(toUpdate) > 0 {
+			if err := batchUpdateZoneRecordsets(client, zoneID, toUpdate); err != nil {
+				return err
+			}
+		}
+		if len(toCreate) > 0 {
+			createdIDs, err := batchCreateZoneRecordsets(client, zoneID, toCreate)
+			if err != nil {
+				return err
+			}
+			changedIDs = append(changedIDs, createdIDs...)
+		}
+	}
+
+	for _, recordsetID := range changedIDs {
+		waitForConfig := &WaitForConfig{
+			ZoneID:      zoneID,
+			RecordsetID: recordsetID,
+			ZoneType:    zoneType,
+			Timeout:     timeout,
+		}
+		if err := waitForDNSRecordsetCreateOrUpdate(ctx, client, waitForConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceZoneRecordsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zoneID := d.Get("zone_id").(string)
+	client, zoneType, err := chooseDNSClientbyZoneID(d, zoneID, meta)
+	if err != nil {
+		return diag.Errorf("error retrieving DNS zone (%s): %s", zoneID, err)
+	}
+
+	err = applyZoneRecordsets(ctx, client, zoneID, zoneType, d.Get("recordsets").(*schema.Set).List(),
+		d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(zoneID)
+
+	return resourceZoneRecordsRead(ctx, d, meta)
+}
+
+// flattenZoneRecordsets converts the remote recordsets to the schema format. The name, line and weight are kept as
+// the configured form if they are semantically equal, to avoid meaningless changes.
+func flattenZoneRecordsets(remoteList []interface{}, stateList []interface{}, zoneType string) []interface{} {
+	used := make([]bool, len(stateList))
+	result := make([]interface{}, 0, len(remoteList))
+	for _, remote := range remoteList {
+		line := utils.PathSearch("line", remote, "").(string)
+		if line == "default_view" {
+			line = ""
+		}
+		recordset := map[string]interface{}{
+			"name":        utils.PathSearch("name", remote, nil),
+			"type":        utils.PathSearch("type", remote, nil),
+			"ttl":         utils.PathSearch("ttl", remote, nil),
+			"records":     utils.PathSearch("records", remote, nil),
+			"line_id":     line,
+			"weight":      utils.PathSearch("weight", remote, nil),
+			"description": utils.PathSearch("description", remote, nil),
+		}
+
+		key := remoteZoneRecordsetKey(remote, zoneType)
+		for i, v := range stateList {
+			state := v.(map[string]interface{})
+			if used[i] || desiredZoneRecordsetKey(state, zoneType) != key {
+				continue
+			}
+			used[i] = true
+			recordset["name"] = state["name"]
+			recordset["line_id"] = state["line_id"]
+			if state["weight"].(int) == 0 {
+				recordset["weight"] = 0
+			}
+			break
+		}
+		result = append(result, recordset)
+	}
+	return result
+}
+
+func resourceZoneRecordsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	zoneID := d.Id()
+
+	client, zoneType, err := chooseDNSClientbyZoneID(d, zoneID, meta)
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "error retrieving DNS zone")
+	}
+
+	remoteList, err := listManagedZoneRecordsets(client, zoneID, zoneType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("zone_id", zoneID),
+		d.Set("recordsets", flattenZoneRecordsets(remoteList, d.Get("recordsets").(*schema.Set).List(), zoneType)),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+func resourceZoneRecordsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zoneID := d.Id()
+	client, zoneType, err := chooseDNSClientbyZoneID(d, zoneID, meta)
+	if err != nil {
+		return diag.Errorf("error retrieving DNS zone (%s): %s", zoneID, err)
+	}
+
+	if d.HasChange("recordsets") {
+		err = applyZoneRecordsets(ctx, client, zoneID, zoneType, d.Get("recordsets").(*schema.Set).List(),
+			d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceZoneRecordsRead(ctx, d, meta)
+}
+
+func resourceZoneRecordsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zoneID := d.Id()
+	client, zoneType, err := chooseDNSClientbyZoneID(d, zoneID, meta)
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "error retrieving DNS zone")
+	}
+
+	err = applyZoneRecordsets(ctx, client, zoneID, zoneType, nil, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceZoneRecordsImportState(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	return []*schema.ResourceData{d}, d.Set("zone_id", d.Id())
+}
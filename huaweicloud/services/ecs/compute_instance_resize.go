@@ -0,0 +1,333 @@
+package ecs
+
+// This set of code handles the flavor resizing of an huaweicloud_compute_instance resource.
+//
+// Changing the flavor is orchestrated as a sequence of steps: stop the instance (offline strategy), expand the system
+// disk (if its size is changed at the same time), resize the flavor and start the instance again. If the instance fails
+// to return to its original state after the resize, the flavor will be reverted to the previous one. The expanded
+// system disk can not be shrunk, so its size is kept in the state even if the following steps fail.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
+	"github.com/chnsz/golangsdk/openstack/evs/v2/cloudvolumes"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/httphelper"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/cbc"
+)
+
+const (
+	resizeStrategyOnline  = "online"
+	resizeStrategyOffline = "offline"
+)
+
+// getInstanceResizeStrategy returns the strategy used to resize the instance. If `resize_strategy` is not specified,
+// the instance is stopped gracefully before the resize when `stop_before_destroy` is enabled.
+func getInstanceResizeStrategy(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("resize_strategy"); ok {
+		return v.(string)
+	}
+	if d.Get("stop_before_destroy").(bool) {
+		return resizeStrategyOffline
+	}
+	return resizeStrategyOnline
+}
+
+// listInstanceResizeFlavorIds queries the flavors which the instance can be resized to.
+func listInstanceResizeFlavorIds(client *golangsdk.ServiceClient, instanceId string) ([]string, error) {
+	resp, err := httphelper.New(client).
+		Method("GET").
+		URI("/v1/{project_id}/cloudservers/resize_flavors").
+		Query(map[string]any{"instance_uuid": instanceId}).
+		MarkerPager("flavors", "flavors[-1].id", "marker").
+		Request().
+		Result()
+	if err != nil {
+		return nil, err
+	}
+
+	flavors := resp.Get("flavors").Array()
+	result := make([]string, 0, len(flavors))
+	for _, flavor := range flavors {
+		result = append(result, flavor.Get("id").String())
+	}
+	return result, nil
+}
+
+// validateInstanceResizeFlavor checks at plan time whether the new flavor is in the list of flavors returned by the
+// resize-flavors API.
+func validateInstanceResizeFlavor(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChanges("flavor_id", "flavor_name") {
+		return nil
+	}
+
+	newFlavorId := d.Get("flavor_id").(string)
+	if d.HasChange("flavor_name") {
+		newFlavorId = d.Get("flavor_name").(string)
+	}
+	if !d.NewValueKnown("flavor_id") || !d.NewValueKnown("flavor_name") || newFlavorId == "" {
+		return nil
+	}
+
+	cfg := meta.(*config.Config)
+	region := d.Get("region").(string)
+	if region == "" {
+		region = cfg.Region
+	}
+	client, err := cfg.NewServiceClient("ecs", region)
+	if err != nil {
+		return fmt.Errorf("error creating ECS client: %s", err)
+	}
+
+	flavorIds, err := listInstanceResizeFlavorIds(client, d.Id())
+	if err != nil {
+		// The validation is best-effort, the resize API will return the error if the flavor is not supported.
+		log.Printf("[WARN] unable to query the resize flavors of instance (%s): %s", d.Id(), err)
+		return nil
+	}
+
+	for _, flavorId := range flavorIds {
+		if flavorId == newFlavorId {
+			return nil
+		}
+	}
+	return fmt.Errorf("the instance (%s) can not be resized to flavor (%s), the flavor is not returned by the "+
+		"resize flavors API, please check the data source huaweicloud_compute_resize_flavors", d.Id(), newFlavorId)
+}
+
+func resizeInstanceFlavor(ecsClient, ecsV11Client *golangsdk.ServiceClient, d *schema.ResourceData, flavorId string,
+	timeout time.Duration) error {
+	resizeOpts := &cloudservers.ResizeOpts{
+		FlavorRef: flavorId,
+		Mode:      "withStopServer",
+		ExtendParam: &cloudservers.ResizeExtendParam{
+			AutoPay: cbc.GetAutoPay(d),
+		},
+	}
+	log.Printf("[DEBUG] resize configuration: %#v", resizeOpts)
+	job, err := cloudservers.Resize(ecsV11Client, resizeOpts, d.Id()).ExtractJobResponse()
+	if err != nil {
+		return fmt.Errorf("error resizing server: %s", err)
+	}
+
+	if err := cloudservers.WaitForJobSuccess(ecsClient, int(timeout/time.Second), job.JobID); err != nil {
+		return fmt.Errorf("error waiting for instance (%s) to be resized: %s", d.Id(), err)
+	}
+	return nil
+}
+
+// extendInstanceSystemDisk expands the system disk to the size of `system_disk_size`.
+func extendInstanceSystemDisk(ctx context.Context, d *schema.ResourceData, cfg *config.Config) error {
+	region := cfg.GetRegion(d)
+	extendOpts := cloudvolumes.ExtendOpts{
+		SizeOpts: cloudvolumes.ExtendSizeOpts{
+			NewSize: d.Get("system_disk_size").(int),
+		},
+	}
+
+	isPrePaid := strings.EqualFold(d.Get("charging_mode").(string), "prePaid")
+	if isPrePaid {
+		extendOpts.ChargeInfo = &cloudvolumes.ExtendChargeOpts{
+			IsAutoPay: cbc.GetAutoPay(d),
+		}
+	}
+
+	evsV2Client, err := cfg.BlockStorageV2Client(region)
+	if err != nil {
+		return fmt.Errorf("error creating evs V2 client: %s", err)
+	}
+	evsV21Client, err := cfg.BlockStorageV21Client(region)
+	if err != nil {
+		return fmt.Errorf("error creating evs V2.1 client: %s", err)
+	}
+
+	systemDiskID := d.Get("system_disk_id").(string)
+	resp, err := cloudvolumes.ExtendSize(evsV21Client, systemDiskID, extendOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("error extending EVS volume (%s) size: %s", systemDiskID, err)
+	}
+
+	if isPrePaid {
+		bssClient, err := cfg.BssV2Client(region)
+		if err != nil {
+			return fmt.Errorf("error creating BSS v2 client: %s", err)
+		}
+		err = common.WaitOrderComplete(ctx, bssClient, resp.OrderID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return fmt.Errorf("the order (%s) is not completed while extending system disk (%s) size: %v",
+				resp.OrderID, d.Id(), err)
+		}
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{"extending"},
+		Target:     []string{"available", "in-use"},
+		Refresh:    cloudVolumeRefreshFunc(evsV2Client, systemDiskID),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for huaweicloud_compute_instance system disk %s to become ready: %s",
+			systemDiskID, err)
+	}
+	return nil
+}
+
+// instanceChangesAfterFlavor are the arguments which are updated after the flavor, they are not applied if the
+// flavor update fails.
+var instanceChangesAfterFlavor = []string{"flavor_id", "flavor_name", "system_disk_size", "network", "tags",
+	"enterprise_project_id", "power_action", "auto_terminate_time", "scheduler_hints"}
+
+// restoreInstanceUnappliedChanges restores the arguments which are not applied to their values in the state, so that
+// only the applied changes are saved to the state when the flavor update fails.
+func restoreInstanceUnappliedChanges(d *schema.ResourceData, keys []string) error {
+	var mErr *multierror.Error
+	for _, key := range keys {
+		if d.HasChange(key) {
+			oldVal, _ := d.GetChange(key)
+			mErr = multierror.Append(mErr, d.Set(key, oldVal))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// updateInstanceFlavor orchestrates the resize of the instance: stop -> grow system disk -> resize -> start.
+// If the instance fails to become ACTIVE after the resize, the flavor is reverted to the previous one.
+// When any step fails, the changes which are not applied, including the flavor, are not saved to the state, whether
+// the flavor has been reverted or not, while the size of the system disk is saved as soon as it is expanded.
+func updateInstanceFlavor(ctx context.Context, d *schema.ResourceData, cfg *config.Config, ecsClient,
+	ecsV11Client *golangsdk.ServiceClient) error {
+	systemDiskExpanded := false
+	err := resizeInstanceWithSystemDisk(ctx, d, cfg, ecsClient, ecsV11Client, func() {
+		systemDiskExpanded = true
+	})
+	if err == nil {
+		return nil
+	}
+
+	unapplied := make([]string, 0, len(instanceChangesAfterFlavor))
+	for _, key := range instanceChangesAfterFlavor {
+		// The expanded system disk can not be shrunk, keep its size in the state.
+		if key != "system_disk_size" || !systemDiskExpanded {
+			unapplied = append(unapplied, key)
+		}
+	}
+	if restoreErr := restoreInstanceUnappliedChanges(d, unapplied); restoreErr != nil {
+		log.Printf("[WARN] unable to restore the unapplied changes of the instance (%s): %s", d.Id(), restoreErr)
+	}
+	return err
+}
+
+func resizeInstanceWithSystemDisk(ctx context.Context, d *schema.ResourceData, cfg *config.Config, ecsClient,
+	ecsV11Client *golangsdk.ServiceClient, onSystemDiskExpanded func()) error {
+	oldFlavorId := d.Get("flavor_id").(string)
+	if d.HasChange("flavor_id") {
+		oldRaw, _ := d.GetChange("flavor_id")
+		oldFlavorId = oldRaw.(string)
+	} else if d.HasChange("flavor_name") {
+		oldRaw, _ := d.GetChange("flavor_name")
+		oldFlavorId = oldRaw.(string)
+	}
+	newFlavorId, err := getFlavorID(d)
+	if err != nil {
+		return err
+	}
+
+	server, err := cloudservers.Get(ecsClient, d.Id()).Extract()
+	if err != nil {
+		return fmt.Errorf("error retrieving compute instance (%s): %s", d.Id(), err)
+	}
+	wasActive := server.Status == "ACTIVE"
+	strategy := getInstanceResizeStrategy(d)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	if strategy == resizeStrategyOffline && wasActive {
+		if err := doPowerAction(ecsClient, d, "OFF"); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("system_disk_size") {
+		if err := extendInstanceSystemDisk(ctx, d, cfg); err != nil {
+			restoreInstancePowerState(ecsClient, d, strategy, wasActive)
+			return err
+		}
+		onSystemDiskExpanded()
+	}
+
+	if err := resizeInstanceFlavor(ecsClient, ecsV11Client, d, newFlavorId, timeout); err != nil {
+		// The flavor is not changed when the resize job fails, restore the power state of the instance.
+		restoreInstancePowerState(ecsClient, d, strategy, wasActive)
+		return err
+	}
+
+	if !wasActive {
+		return nil
+	}
+
+	if strategy == resizeStrategyOffline {
+		if err := doPowerAction(ecsClient, d, "ON"); err != nil {
+			log.Printf("[WARN] failed to start the instance (%s) after resize: %s", d.Id(), err)
+		}
+	}
+	err = waitForServerTargetState(ctx, ecsClient, d.Id(), []string{"SHUTOFF", "RESIZE", "VERIFY_RESIZE", "REBOOT"},
+		[]string{"ACTIVE"}, timeout)
+	if err == nil {
+		return nil
+	}
+
+	log.Printf("[WARN] the instance (%s) failed to become ACTIVE after resize, reverting the flavor to %s",
+		d.Id(), oldFlavorId)
+	if revertErr := revertInstanceFlavor(ctx, d, ecsClient, ecsV11Client, oldFlavorId, timeout); revertErr != nil {
+		return fmt.Errorf("%s; and failed to revert the flavor to %s: %s", err, oldFlavorId, revertErr)
+	}
+	return fmt.Errorf("%s; the flavor has been reverted to %s", err, oldFlavorId)
+}
+
+// restoreInstancePowerState starts the instance which is stopped for the resize.
+func restoreInstancePowerState(ecsClient *golangsdk.ServiceClient, d *schema.ResourceData, strategy string,
+	wasActive bool) {
+	if strategy != resizeStrategyOffline || !wasActive {
+		return
+	}
+	if err := doPowerAction(ecsClient, d, "ON"); err != nil {
+		log.Printf("[WARN] failed to start the instance (%s) after the resize failed: %s", d.Id(), err)
+	}
+}
+
+func revertInstanceFlavor(ctx context.Context, d *schema.ResourceData, ecsClient, ecsV11Client *golangsdk.ServiceClient,
+	flavorId string, timeout time.Duration) error {
+	server, err := cloudservers.Get(ecsClient, d.Id()).Extract()
+	if err != nil {
+		return fmt.Errorf("error retrieving compute instance (%s): %s", d.Id(), err)
+	}
+	if server.Status == "ACTIVE" {
+		if err := doPowerAction(ecsClient, d, "FORCE-OFF"); err != nil {
+			return err
+		}
+	}
+
+	if err := resizeInstanceFlavor(ecsClient, ecsV11Client, d, flavorId, timeout); err != nil {
+		return err
+	}
+
+	if err := doPowerAction(ecsClient, d, "ON"); err != nil {
+		return err
+	}
+	return waitForServerTargetState(ctx, ecsClient, d.Id(), []string{"SHUTOFF", "REBOOT"}, []string{"ACTIVE"}, timeout)
+}
@@ -37,6 +37,7 @@ func ResourceComputeEIPAssociate() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceComputeEIPAssociateCreate,
 		ReadContext:   resourceComputeEIPAssociateRead,
+		UpdateContext: resourceComputeEIPAssociateUpdate,
 		DeleteContext: resourceComputeEIPAssociateDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceComputeEIPAssociateImportState,
@@ -79,6 +80,11 @@ func ResourceComputeEIPAssociate() *schema.Resource {
 				Computed:     true,
 				ValidateFunc: validation.IsIPAddress,
 			},
+			"retain_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: `Whether to report the retained EIP after it is unbound when the resource is destroyed.`,
+			},
 			"port_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -281,6 +287,11 @@ func resourceComputeEIPAssociateRead(_ context.Context, d *schema.ResourceData,
 	return diag.FromErr(mErr.ErrorOrNil())
 }
 
+func resourceComputeEIPAssociateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only the retain_on_destroy can be updated, which is only used when the resource is destroyed.
+	return resourceComputeEIPAssociateRead(ctx, d, meta)
+}
+
 func resourceComputeEIPAssociateDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cfg := meta.(*config.Config)
 	region := cfg.GetRegion(d)
@@ -315,6 +326,17 @@ func resourceComputeEIPAssociateDelete(_ context.Context, d *schema.ResourceData
 		if err != nil {
 			return diag.Errorf("error disassociating Floating IP: %s", err)
 		}
+
+		// The EIP is never released by this resource, it is unbound and kept allocated.
+		if d.Get("retain_on_destroy").(bool) {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary: fmt.Sprintf("The EIP (%s) is unbound from the instance and retained because "+
+						"retain_on_destroy is enabled, it can be adopted by the address.", eipAddr),
+				},
+			}
+		}
 	} else {
 		bwClient, err := cfg.NetworkingV2Client(region)
 		if err != nil {
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -57,6 +58,7 @@ var (
 // @API ECS GET /v1.1/{project_id}/cloudservers/detail
 // @API ECS GET /v1/{project_id}/cloudservers/{server_id}/block_device/{volume_id}
 // @API ECS GET /v1/{project_id}/jobs/{job_id}
+// @API ECS GET /v1/{project_id}/cloudservers/resize_flavors
 // @API ECS POST /v1/{project_id}/cloudservers/{server_id}/changevpc
 // @API ECS POST /v1/{project_id}/cloudservers/{server_id}/migrate
 // @API ECS POST /v1/{project_id}/cloudservers/actions/change-charge-mode
@@ -84,7 +86,10 @@ func ResourceComputeInstance() *schema.Resource {
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
-		CustomizeDiff: config.MergeDefaultTags(),
+		CustomizeDiff: customdiff.All(
+			config.MergeDefaultTags(),
+			validateInstanceResizeFlavor,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -368,6 +373,13 @@ func ResourceComputeInstance() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"resize_strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					resizeStrategyOnline, resizeStrategyOffline,
+				}, false),
+			},
 			"enterprise_project_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -382,6 +394,11 @@ func ResourceComputeInstance() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"retain_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: `Whether to keep the EIP bound to the instance allocated when the instance is destroyed.`,
+			},
 			"include_data_disks_on_update": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -1384,28 +1401,9 @@ func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if d.HasChanges("flavor_id", "flavor_name") {
-		newFlavorId, err := getFlavorID(d)
-		if err != nil {
+		if err := updateInstanceFlavor(ctx, d, cfg, ecsClient, ecsV11Client); err != nil {
 			return diag.FromErr(err)
 		}
-
-		extendParam := &cloudservers.ResizeExtendParam{
-			AutoPay: cbc.GetAutoPay(d),
-		}
-		resizeOpts := &cloudservers.ResizeOpts{
-			FlavorRef:   newFlavorId,
-			Mode:        "withStopServer",
-			ExtendParam: extendParam,
-		}
-		log.Printf("[DEBUG] resize configuration: %#v", resizeOpts)
-		job, err := cloudservers.Resize(ecsV11Client, resizeOpts, serverID).ExtractJobResponse()
-		if err != nil {
-			return diag.Errorf("error resizing server: %s", err)
-		}
-
-		if err := cloudservers.WaitForJobSuccess(ecsClient, int(d.Timeout(schema.TimeoutUpdate)/time.Second), job.JobID); err != nil {
-			return diag.Errorf("error waiting for instance (%s) to be resized: %s", serverID, err)
-		}
 	}
 
 	if d.HasChange("network") {
@@ -1439,56 +1437,10 @@ func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	if d.HasChange("system_disk_size") {
-		extendOpts := cloudvolumes.ExtendOpts{
-			SizeOpts: cloudvolumes.ExtendSizeOpts{
-				NewSize: d.Get("system_disk_size").(int),
-			},
-		}
-
-		if strings.EqualFold(d.Get("charging_mode").(string), "prePaid") {
-			extendOpts.ChargeInfo = &cloudvolumes.ExtendChargeOpts{
-				IsAutoPay: cbc.GetAutoPay(d),
-			}
-		}
-
-		evsV2Client, err := cfg.BlockStorageV2Client(region)
-		if err != nil {
-			return diag.Errorf("error creating evs V2 client: %s", err)
-		}
-		evsV21Client, err := cfg.BlockStorageV21Client(region)
-		if err != nil {
-			return diag.Errorf("error creating evs V2.1 client: %s", err)
-		}
-
-		systemDiskID := d.Get("system_disk_id").(string)
-
-		resp, err := cloudvolumes.ExtendSize(evsV21Client, systemDiskID, extendOpts).Extract()
-		if err != nil {
-			return diag.Errorf("error extending EVS volume (%s) size: %s", systemDiskID, err)
-		}
-
-		if strings.EqualFold(d.Get("charging_mode").(string), "prePaid") {
-			err = common.WaitOrderComplete(ctx, bssClient, resp.OrderID, d.Timeout(schema.TimeoutUpdate))
-			if err != nil {
-				return diag.Errorf("The order (%s) is not completed while extending system disk (%s) size: %v",
-					resp.OrderID, serverID, err)
-			}
-		}
-
-		stateConf := &retry.StateChangeConf{
-			Pending:    []string{"extending"},
-			Target:     []string{"available", "in-use"},
-			Refresh:    cloudVolumeRefreshFunc(evsV2Client, systemDiskID),
-			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
-		}
-
-		_, err = stateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf(
-				"error waiting for huaweicloud_compute_instance system disk %s to become ready: %s", systemDiskID, err)
+	// The system disk has been expanded during the resize if the flavor is also changed.
+	if d.HasChange("system_disk_size") && !d.HasChanges("flavor_id", "flavor_name") {
+		if err := extendInstanceSystemDisk(ctx, d, cfg); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
@@ -1901,7 +1853,7 @@ func resourceComputeInstanceDelete(ctx context.Context, d *schema.ResourceData,
 		deleteOpts := cloudservers.DeleteOpts{
 			Servers:        serverRequests,
 			DeleteVolume:   d.Get("delete_disks_on_termination").(bool),
-			DeletePublicIP: d.Get("delete_eip_on_termination").(bool),
+			DeletePublicIP: shouldDeleteInstanceEIP(d),
 		}
 
 		n, err := cloudservers.Delete(ecsClient, deleteOpts).ExtractJobResponse()
@@ -1922,6 +1874,15 @@ func resourceComputeInstanceDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if publicIp := d.Get("public_ip").(string); publicIp != "" && d.Get("retain_on_destroy").(bool) {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary: fmt.Sprintf("The EIP (%s) is retained because retain_on_destroy is enabled, it can be "+
+					"adopted by the address.", publicIp),
+			},
+		}
+	}
 	return nil
 }
 
@@ -2362,8 +2323,14 @@ func calcUnsubscribeResources(d *schema.ResourceData, cfg *config.Config) ([]str
 	return mainResources, nil
 }
 
+// shouldDeleteInstanceEIP checks whether the EIP bound to the instance is released together with the instance, the
+// retain_on_destroy takes precedence over the delete_eip_on_termination.
+func shouldDeleteInstanceEIP(d *schema.ResourceData) bool {
+	return d.Get("delete_eip_on_termination").(bool) && !d.Get("retain_on_destroy").(bool)
+}
+
 func shouldUnsubscribeEIP(d *schema.ResourceData) bool {
-	deleteEIP := d.Get("delete_eip_on_termination").(bool)
+	deleteEIP := shouldDeleteInstanceEIP(d)
 	eipAddr := d.Get("public_ip").(string)
 	eipType := d.Get("eip_type").(string)
 	_, sharebw := d.GetOk("bandwidth.0.id")
@@ -0,0 +1,611 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/subnets"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const instanceGroupIndexPlaceholder = "{index}"
+
+// @API ECS POST /v1.1/{project_id}/cloudservers
+// @API ECS GET /v1/{project_id}/jobs/{job_id}
+// @API ECS GET /v1/{project_id}/cloudservers/{server_id}
+// @API ECS PUT /v1/{project_id}/cloudservers/{server_id}
+// @API ECS POST /v1/{project_id}/cloudservers/{server_id}/tags/action
+// @API ECS POST /v1/{project_id}/cloudservers/delete
+// @API VPC GET /v1/{project_id}/subnets/{subnet_id}
+func ResourceComputeInstanceGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceComputeInstanceGroupCreate,
+		ReadContext:   resourceComputeInstanceGroupRead,
+		UpdateContext: resourceComputeInstanceGroupUpdate,
+		DeleteContext: resourceComputeInstanceGroupDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"instance_count": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 500),
+			},
+			"image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"flavor_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"security_group_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"key_pair": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"admin_pass": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				ForceNew:  true,
+			},
+			"system_disk_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  SystemDiskType,
+			},
+			"system_disk_size": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"user_data": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"enterprise_project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"tags": common.TagsSchema(),
+			"delete_disks_on_termination": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"scale_in_member_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// instanceGroupMember records a single server managed by the instance group.
+type instanceGroupMember struct {
+	ID        string
+	Index     int
+	Name      string
+	Status    string
+	PrivateIP string
+}
+
+// buildInstanceGroupMemberName renders the member name from the name template. If the template does not contain the
+// index placeholder, the index is appended to the template as a suffix.
+func buildInstanceGroupMemberName(template string, index int) string {
+	if !strings.Contains(template, instanceGroupIndexPlaceholder) {
+		return fmt.Sprintf("%s-%d", template, index)
+	}
+	return strings.ReplaceAll(template, instanceGroupIndexPlaceholder, strconv.Itoa(index))
+}
+
+// getInstanceGroupNamePrefix returns the fixed part of the name template, which is used to filter servers by name.
+func getInstanceGroupNamePrefix(template string) string {
+	if idx := strings.Index(template, instanceGroupIndexPlaceholder); idx >= 0 {
+		return template[:idx]
+	}
+	return template
+}
+
+func getInstanceGroupMembers(d *schema.ResourceData) []instanceGroupMember {
+	rawMembers := d.Get("members").([]interface{})
+	members := make([]instanceGroupMember, 0, len(rawMembers))
+	for _, v := range rawMembers {
+		raw := v.(map[string]interface{})
+		members = append(members, instanceGroupMember{
+			ID:        raw["id"].(string),
+			Index:     raw["index"].(int),
+			Name:      raw["name"].(string),
+			Status:    raw["status"].(string),
+			PrivateIP: raw["private_ip"].(string),
+		})
+	}
+	return members
+}
+
+func flattenInstanceGroupMembers(members []instanceGroupMember) []map[string]interface{} {
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Index < members[j].Index
+	})
+
+	result := make([]map[string]interface{}, len(members))
+	for i, member := range members {
+		result[i] = map[string]interface{}{
+			"id":         member.ID,
+			"index":      member.Index,
+			"name":       member.Name,
+			"status":     member.Status,
+			"private_ip": member.PrivateIP,
+		}
+	}
+	return result
+}
+
+// allocateInstanceGroupIndexes returns the lowest count indexes (starting from 1) that are not used by any member.
+func allocateInstanceGroupIndexes(members []instanceGroupMember, count int) []int {
+	used := make(map[int]bool, len(members))
+	for _, member := range members {
+		used[member.Index] = true
+	}
+
+	result := make([]int, 0, count)
+	for i := 1; len(result) < count; i++ {
+		if !used[i] {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// selectInstanceGroupScaleInMembers splits the members sorted by index into the kept members and the count members
+// to be removed. The members specified by the IDs are removed first, and then the members with the highest indexes.
+func selectInstanceGroupScaleInMembers(members []instanceGroupMember, count int,
+	memberIds []string) ([]instanceGroupMember, []instanceGroupMember) {
+	kept := make([]instanceGroupMember, 0, len(members))
+	removed := make([]instanceGroupMember, 0, count)
+	for _, member := range members {
+		if len(removed) < count && utils.StrSliceContains(memberIds, member.ID) {
+			removed = append(removed, member)
+			continue
+		}
+		kept = append(kept, member)
+	}
+
+	if remaining := count - len(removed); remaining > 0 {
+		removed = append(removed, kept[len(kept)-remaining:]...)
+		kept = kept[:len(kept)-remaining]
+	}
+	return kept, removed
+}
+
+func buildInstanceGroupCreateOpts(d *schema.ResourceData, cfg *config.Config, vpcId string,
+	count int) *cloudservers.CreateOpts {
+	createOpts := cloudservers.CreateOpts{
+		Name:             getInstanceGroupNamePrefix(d.Get("name").(string)),
+		Description:      d.Get("description").(string),
+		ImageRef:         d.Get("image_id").(string),
+		FlavorRef:        d.Get("flavor_id").(string),
+		KeyName:          d.Get("key_pair").(string),
+		VpcId:            vpcId,
+		AvailabilityZone: d.Get("availability_zone").(string),
+		Count:            count,
+		RootVolume: cloudservers.RootVolume{
+			VolumeType: d.Get("system_disk_type").(string),
+			Size:       d.Get("system_disk_size").(int),
+		},
+		Nics: []cloudservers.Nic{
+			{
+				SubnetId: d.Get("subnet_id").(string),
+			},
+		},
+		UserData: []byte(d.Get("user_data").(string)),
+	}
+	if createOpts.Name == "" {
+		createOpts.Name = "instance-group"
+	}
+
+	rawSecGroups := d.Get("security_group_ids").([]interface{})
+	for _, v := range rawSecGroups {
+		createOpts.SecurityGroups = append(createOpts.SecurityGroups, cloudservers.SecurityGroup{ID: v.(string)})
+	}
+
+	if tags, ok := d.GetOk("tags"); ok {
+		createOpts.ServerTags = utils.ExpandResourceTags(tags.(map[string]interface{}))
+	}
+
+	if epsId := cfg.GetEnterpriseProjectID(d); epsId != "" {
+		createOpts.ExtendParam = &cloudservers.ServerExtendParam{
+			EnterpriseProjectId: epsId,
+		}
+	}
+
+	return &createOpts
+}
+
+// createInstanceGroupMembers creates count servers through a single multi-server request, waits for the job and
+// renames each new server according to the allocated indexes.
+func createInstanceGroupMembers(ctx context.Context, d *schema.ResourceData, cfg *config.Config,
+	existing []instanceGroupMember, count int) ([]instanceGroupMember, error) {
+	region := cfg.GetRegion(d)
+	ecsClient, err := cfg.ComputeV1Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating compute v1 client: %s", err)
+	}
+	ecsV11Client, err := cfg.ComputeV11Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating compute v1.1 client: %s", err)
+	}
+	vpcClient, err := cfg.NetworkingV1Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating networking v1 client: %s", err)
+	}
+
+	subnet, err := subnets.Get(vpcClient, d.Get("subnet_id").(string)).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving subnet: %s", err)
+	}
+
+	createOpts := buildInstanceGroupCreateOpts(d, cfg, subnet.VPC_ID, count)
+	log.Printf("[DEBUG] ECS instance group create options: %#v", createOpts)
+	// Add password here so it wouldn't go in the above log entry
+	createOpts.AdminPass = d.Get("admin_pass").(string)
+
+	n, err := cloudservers.Create(ecsV11Client, createOpts).ExtractJobResponse()
+	if err != nil {
+		return nil, fmt.Errorf("error creating servers of the instance group: %s", err)
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+	// The job is failed if any sub-job fails, the successful servers still need to be recorded.
+	jobErr := waitForJobComplete(ctx, ecsClient, n.JobID, timeout)
+	serverIds, err := getInstanceGroupJobServerIds(ecsClient, n.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := allocateInstanceGroupIndexes(existing, len(serverIds))
+	members := make([]instanceGroupMember, 0, len(serverIds))
+	var mErr *multierror.Error
+	for i, serverId := range serverIds {
+		member := instanceGroupMember{
+			ID:    serverId,
+			Index: indexes[i],
+			Name:  buildInstanceGroupMemberName(d.Get("name").(string), indexes[i]),
+		}
+		updateOpts := cloudservers.UpdateOpts{
+			Name: member.Name,
+		}
+		if err := cloudservers.Update(ecsClient, serverId, updateOpts).ExtractErr(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("error renaming server (%s): %s", serverId, err))
+		}
+		members = append(members, member)
+	}
+
+	if jobErr != nil {
+		mErr = multierror.Append(mErr, jobErr)
+	}
+	return members, mErr.ErrorOrNil()
+}
+
+func getInstanceGroupJobServerIds(client *golangsdk.ServiceClient, jobId string) ([]string, error) {
+	httpUrl := "v1/{project_id}/jobs/{job_id}"
+	getPath := client.Endpoint + httpUrl
+	getPath = strings.ReplaceAll(getPath, "{project_id}", client.ProjectID)
+	getPath = strings.ReplaceAll(getPath, "{job_id}", jobId)
+
+	getOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+	}
+	getResp, err := client.Request("GET", getPath, &getOpt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving ECS job (%s): %s", jobId, err)
+	}
+
+	getRespBody, err := utils.FlattenResponse(getResp)
+	if err != nil {
+		return nil, err
+	}
+
+	rawIds := utils.PathSearch("entities.sub_jobs[?status=='SUCCESS'].entities.server_id", getRespBody,
+		make([]interface{}, 0)).([]interface{})
+	return utils.ExpandToStringList(rawIds), nil
+}
+
+func resourceComputeInstanceGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+
+	groupId, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+
+	members, err := createInstanceGroupMembers(ctx, d, cfg, nil, d.Get("instance_count").(int))
+	if len(members) > 0 {
+		// Record the created servers so that they can be managed (or destroyed) later, even if the job failed.
+		d.SetId(groupId)
+		if setErr := d.Set("members", flattenInstanceGroupMembers(members)); setErr != nil {
+			return diag.Errorf("error saving members of the instance group: %s", setErr)
+		}
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceComputeInstanceGroupRead(ctx, d, meta)
+}
+
+// getInstanceGroupServer queries the member server by its ID, so the members are still tracked after being renamed.
+// The nil server is returned if the server does not exist.
+func getInstanceGroupServer(client *golangsdk.ServiceClient, serverId string) (*cloudservers.CloudServer, error) {
+	server, err := cloudservers.Get(client, serverId).Extract()
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if server.Status == "DELETED" || server.Status == "SOFT_DELETED" {
+		return nil, nil
+	}
+	return server, nil
+}
+
+func getServerPrivateIP(server cloudservers.CloudServer) string {
+	for _, addresses := range server.Addresses {
+		for _, address := range addresses {
+			if address.Type == "fixed" && address.Version == "4" {
+				return address.Addr
+			}
+		}
+	}
+	return ""
+}
+
+func resourceComputeInstanceGroupRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	ecsClient, err := cfg.ComputeV1Client(region)
+	if err != nil {
+		return diag.Errorf("error creating compute v1 client: %s", err)
+	}
+
+	var (
+		members        = make([]instanceGroupMember, 0)
+		removedMembers = make([]string, 0)
+	)
+	for _, member := range getInstanceGroupMembers(d) {
+		server, err := getInstanceGroupServer(ecsClient, member.ID)
+		if err != nil {
+			return diag.Errorf("error retrieving server (%s) of the instance group: %s", member.ID, err)
+		}
+		if server == nil {
+			log.Printf("[WARN] the server (%s) of the instance group has been removed", member.ID)
+			removedMembers = append(removedMembers, member.ID)
+			continue
+		}
+		member.Name = server.Name
+		member.Status = server.Status
+		member.PrivateIP = getServerPrivateIP(*server)
+		members = append(members, member)
+	}
+
+	if len(members) == 0 {
+		log.Printf("[WARN] all servers of the instance group (%s) have been removed", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("instance_count", len(members)),
+		d.Set("members", flattenInstanceGroupMembers(members)),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting instance group fields: %s", err)
+	}
+
+	if len(removedMembers) > 0 {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Members removed outside Terraform",
+				Detail: fmt.Sprintf("The servers (%s) of the instance group have been removed, the next apply creates "+
+					"new members to restore the configured instance_count.", strings.Join(removedMembers, ", ")),
+			},
+		}
+	}
+	return nil
+}
+
+func deleteInstanceGroupMembers(client *golangsdk.ServiceClient, d *schema.ResourceData, members []instanceGroupMember,
+	timeout time.Duration) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	servers := make([]cloudservers.Server, len(members))
+	for i, member := range members {
+		servers[i] = cloudservers.Server{
+			Id: member.ID,
+		}
+	}
+	deleteOpts := cloudservers.DeleteOpts{
+		Servers:      servers,
+		DeleteVolume: d.Get("delete_disks_on_termination").(bool),
+	}
+	n, err := cloudservers.Delete(client, deleteOpts).ExtractJobResponse()
+	if err != nil {
+		return fmt.Errorf("error deleting servers of the instance group: %s", err)
+	}
+
+	return cloudservers.WaitForJobSuccess(client, int(timeout/time.Second), n.JobID)
+}
+
+func resourceComputeInstanceGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	ecsClient, err := cfg.ComputeV1Client(region)
+	if err != nil {
+		return diag.Errorf("error creating compute v1 client: %s", err)
+	}
+
+	members := getInstanceGroupMembers(d)
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Index < members[j].Index
+	})
+
+	if d.HasChange("instance_count") {
+		expected := d.Get("instance_count").(int)
+		if expected < len(members) {
+			var removed []instanceGroupMember
+			members, removed = selectInstanceGroupScaleInMembers(members, len(members)-expected,
+				utils.ExpandToStringListBySet(d.Get("scale_in_member_ids").(*schema.Set)))
+			err = deleteInstanceGroupMembers(ecsClient, d, removed, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		} else if expected > len(members) {
+			// Scale out: create the missing members through a single request.
+			newMembers, err := createInstanceGroupMembers(ctx, d, cfg, members, expected-len(members))
+			members = append(members, newMembers...)
+			if setErr := d.Set("members", flattenInstanceGroupMembers(members)); setErr != nil {
+				return diag.Errorf("error saving members of the instance group: %s", setErr)
+			}
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if err := d.Set("members", flattenInstanceGroupMembers(members)); err != nil {
+			return diag.Errorf("error saving members of the instance group: %s", err)
+		}
+	}
+
+	if d.HasChange("name") {
+		for _, member := range members {
+			updateOpts := cloudservers.UpdateOpts{
+				Name: buildInstanceGroupMemberName(d.Get("name").(string), member.Index),
+			}
+			if err := cloudservers.Update(ecsClient, member.ID, updateOpts).ExtractErr(); err != nil {
+				return diag.Errorf("error renaming server (%s): %s", member.ID, err)
+			}
+		}
+	}
+
+	if d.HasChange("tags") {
+		for _, member := range members {
+			if err := utils.UpdateResourceTags(ecsClient, d, "cloudservers", member.ID); err != nil {
+				return diag.Errorf("error updating tags of server (%s): %s", member.ID, err)
+			}
+		}
+	}
+
+	return resourceComputeInstanceGroupRead(ctx, d, meta)
+}
+
+func resourceComputeInstanceGroupDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	ecsClient, err := cfg.ComputeV1Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating compute v1 client: %s", err)
+	}
+
+	err = deleteInstanceGroupMembers(ecsClient, d, getInstanceGroupMembers(d), d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package eip
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
@@ -97,6 +98,11 @@ func ResourceVpcBandWidthV2() *schema.Resource {
 				RequiredWith: []string{"period_unit"},
 			},
 			"auto_renew": common.SchemaAutoRenewUpdatable(nil),
+			"retain_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: `Whether to keep the bandwidth and only remove it from the state when the resource is destroyed.`,
+			},
 			"bandwidth_type": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -373,6 +379,16 @@ func resourceVpcBandWidthV2Delete(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	bwID := d.Id()
+	if d.Get("retain_on_destroy").(bool) {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary: fmt.Sprintf("The bandwidth (%s) is retained because retain_on_destroy is enabled, it is "+
+					"only removed from the state.", bwID),
+			},
+		}
+	}
+
 	if v, ok := d.GetOk("charging_mode"); ok && v.(string) == "prePaid" {
 		if err := common.UnsubscribePrePaidResource(d, cfg, []string{bwID}); err != nil {
 			return diag.Errorf("error unsubscribe bandwidth: %s", err)
@@ -230,6 +230,11 @@ func ResourceVpcEIPV1() *schema.Resource {
 			},
 			"auto_renew": common.SchemaAutoRenewUpdatable([]string{"publicip.0.ip_address"}),
 			"auto_pay":   common.SchemaAutoPay([]string{"publicip.0.ip_address"}),
+			"retain_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: `Whether to keep the EIP allocated and only unbind it when the resource is destroyed.`,
+			},
 
 			// Attributes
 			"address": {
@@ -848,6 +853,10 @@ func resourceVpcEipDelete(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
+	if d.Get("retain_on_destroy").(bool) {
+		return retainVpcEip(networkingClient, d, timeout)
+	}
+
 	if v, ok := d.GetOk("charging_mode"); ok && v.(string) == "prePaid" {
 		if err := common.UnsubscribePrePaidResource(d, cfg, []string{resourceId}); err != nil {
 			return diag.Errorf("error unsubscribe publicip: %s", err)
@@ -876,6 +885,31 @@ func resourceVpcEipDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	return nil
 }
 
+// retainVpcEip unbinds the EIP from the instance and removes it from the state without releasing it, so that the
+// address can be adopted again by the huaweicloud_vpc_eip_adopt resource.
+func retainVpcEip(client *golangsdk.ServiceClient, d *schema.ResourceData, timeout time.Duration) diag.Diagnostics {
+	resourceId := d.Id()
+	// The EIP may be bound by the binding resources or other instances, e.g. NAT gateways and load balancers.
+	publicIp, err := eips.Get(client, resourceId).Extract()
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "error retrieving EIP")
+	}
+	if publicIp.PortID != "" {
+		if err = unbindPort(client, resourceId, publicIp.PortID, timeout); err != nil {
+			return diag.Errorf("error unbinding EIP (%s) from port (%s): %s", resourceId, publicIp.PortID, err)
+		}
+	}
+
+	d.SetId("")
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary: fmt.Sprintf("The EIP (%s) is retained because retain_on_destroy is enabled, it is only removed "+
+				"from the state and can be adopted by the address %s.", resourceId, publicIp.PublicAddress),
+		},
+	}
+}
+
 func resourcePublicIP(d *schema.ResourceData) eips.PublicIpOpts {
 	publicIPRaw := d.Get("publicip").([]interface{})
 	rawMap := publicIPRaw[0].(map[string]interface{})
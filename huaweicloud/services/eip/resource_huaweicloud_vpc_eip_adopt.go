@@ -0,0 +1,287 @@
+package eip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/eips"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// ResourceVpcEipAdopt is the impl for huaweicloud_vpc_eip_adopt resource, which brings an existing EIP, e.g. the one
+// retained by huaweicloud_vpc_eip, under management by its address.
+// @API EIP GET /v1/{project_id}/publicips
+// @API EIP GET /v1/{project_id}/publicips/{id}
+// @API EIP PUT /v1/{project_id}/publicips/{id}
+// @API EIP DELETE /v1/{project_id}/publicips/{id}
+// @API BSS POST /v2/orders/subscriptions/resources/unsubscribe
+func ResourceVpcEipAdopt() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVpcEipAdoptCreate,
+		ReadContext:   resourceVpcEipAdoptRead,
+		UpdateContext: resourceVpcEipAdoptUpdate,
+		DeleteContext: resourceVpcEipAdoptDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: `The region where the EIP is located.`,
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  `The address of the EIP to be adopted.`,
+			},
+			"enterprise_project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: `The enterprise project ID to which the EIP belongs.`,
+			},
+			"port_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: `The ID of the port to which the EIP is reattached.`,
+			},
+			"bound_port_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The ID of the port to which the EIP is bound by this resource.`,
+			},
+			"release_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: `Whether to release the EIP when the resource is destroyed.`,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The name of the EIP.`,
+			},
+			"ipv6_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The IPv6 address of the EIP.`,
+			},
+			"private_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The private IP address to which the EIP is bound.`,
+			},
+			"bandwidth_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The ID of the bandwidth used by the EIP.`,
+			},
+			"bandwidth_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The size of the bandwidth used by the EIP.`,
+			},
+			"bandwidth_share_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `Whether the bandwidth used by the EIP is dedicated or shared.`,
+			},
+			"charging_mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The charging mode of the EIP.`,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The status of the EIP.`,
+			},
+		},
+	}
+}
+
+func resourceVpcEipAdoptCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NetworkingV1Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating VPC client: %s", err)
+	}
+
+	address := d.Get("address").(string)
+	epsId := cfg.GetEnterpriseProjectID(d)
+	if epsId == "" {
+		epsId = "all_granted_eps"
+	}
+	eipId, err := common.GetEipIDbyAddress(client, address, epsId)
+	if err != nil {
+		return diag.Errorf("unable to find the EIP (%s) to be adopted: %s", address, err)
+	}
+	d.SetId(eipId)
+
+	if portId, ok := d.GetOk("port_id"); ok {
+		err = bindVpcEipAdoptPort(ctx, client, eipId, portId.(string), d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set("bound_port_id", portId); err != nil {
+			return diag.Errorf("error saving the bound port of the EIP (%s): %s", eipId, err)
+		}
+	}
+
+	return resourceVpcEipAdoptRead(ctx, d, meta)
+}
+
+// bindVpcEipAdoptPort reattaches the adopted EIP to the port, the EIP must not be bound to another port.
+func bindVpcEipAdoptPort(ctx context.Context, client *golangsdk.ServiceClient, eipId, portId string,
+	timeout time.Duration) error {
+	publicIp, err := eips.Get(client, eipId).Extract()
+	if err != nil {
+		return fmt.Errorf("error retrieving EIP (%s): %s", eipId, err)
+	}
+	if publicIp.PortID == portId {
+		return nil
+	}
+	if publicIp.PortID != "" {
+		return fmt.Errorf("the EIP (%s) is already bound to the port (%s)", publicIp.PublicAddress, publicIp.PortID)
+	}
+
+	if err = bindPort(client, eipId, portId, timeout); err != nil {
+		return fmt.Errorf("error binding EIP (%s) to port (%s): %s", eipId, portId, err)
+	}
+	if err = waitForStateCompleted(ctx, eipAssociateRefreshFunc(client, eipId, portId), timeout); err != nil {
+		return fmt.Errorf("error waiting for EIP association to complete: %s", err)
+	}
+	return nil
+}
+
+func resourceVpcEipAdoptRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NetworkingV1Client(region)
+	if err != nil {
+		return diag.Errorf("error creating VPC client: %s", err)
+	}
+
+	publicIp, err := eips.Get(client, d.Id()).Extract()
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "EIP")
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("address", publicIp.PublicAddress),
+		d.Set("enterprise_project_id", publicIp.EnterpriseProjectID),
+		d.Set("port_id", publicIp.PortID),
+		d.Set("name", publicIp.Alias),
+		d.Set("ipv6_address", publicIp.PublicIpv6Address),
+		d.Set("private_ip", publicIp.PrivateAddress),
+		d.Set("bandwidth_id", publicIp.BandwidthID),
+		d.Set("bandwidth_size", publicIp.BandwidthSize),
+		d.Set("bandwidth_share_type", publicIp.BandwidthShareType),
+		d.Set("charging_mode", normalizeChargingMode(publicIp.Profile.OrderID)),
+		d.Set("status", NormalizeEipStatus(publicIp.Status)),
+	)
+	if err = mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting adopted EIP fields: %s", err)
+	}
+	return nil
+}
+
+func resourceVpcEipAdoptUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NetworkingV1Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating VPC client: %s", err)
+	}
+
+	if d.HasChange("port_id") {
+		timeout := d.Timeout(schema.TimeoutUpdate)
+		oldVal, newVal := d.GetChange("port_id")
+		if oldPort := oldVal.(string); oldPort != "" {
+			if err = unbindPort(client, d.Id(), oldPort, timeout); err != nil {
+				return diag.Errorf("error unbinding EIP (%s) from port (%s): %s", d.Id(), oldPort, err)
+			}
+		}
+		if newPort := newVal.(string); newPort != "" {
+			if err = bindVpcEipAdoptPort(ctx, client, d.Id(), newPort, timeout); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if err = d.Set("bound_port_id", newVal); err != nil {
+			return diag.Errorf("error saving the bound port of the EIP (%s): %s", d.Id(), err)
+		}
+	}
+
+	return resourceVpcEipAdoptRead(ctx, d, meta)
+}
+
+func resourceVpcEipAdoptDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NetworkingV1Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating VPC client: %s", err)
+	}
+
+	eipId := d.Id()
+	timeout := d.Timeout(schema.TimeoutDelete)
+	publicIp, err := eips.Get(client, eipId).Extract()
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "error retrieving EIP")
+	}
+	// The EIP is unbound only from the port bound by this resource, unless it is released.
+	releaseOnDestroy := d.Get("release_on_destroy").(bool)
+	if publicIp.PortID != "" && (releaseOnDestroy || publicIp.PortID == d.Get("bound_port_id").(string)) {
+		if err = unbindPort(client, eipId, publicIp.PortID, timeout); err != nil {
+			return diag.Errorf("error unbinding EIP (%s) from port (%s): %s", eipId, publicIp.PortID, err)
+		}
+	}
+
+	if !releaseOnDestroy {
+		return nil
+	}
+
+	if d.Get("charging_mode").(string) == "prePaid" {
+		if err = common.UnsubscribePrePaidResource(d, cfg, []string{eipId}); err != nil {
+			return diag.Errorf("error unsubscribing EIP (%s): %s", eipId, err)
+		}
+	} else if err = eips.Delete(client, eipId).ExtractErr(); err != nil {
+		return diag.Errorf("error releasing EIP (%s): %s", eipId, err)
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{"PENDING"},
+		Target:     []string{"COMPLETED"},
+		Refresh:    eipStatusRefreshFunc(client, eipId, nil),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for EIP (%s) to be released: %s", eipId, err)
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ func ResourceEIPAssociate() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceEIPAssociateCreate,
 		ReadContext:   resourceEIPAssociateRead,
+		UpdateContext: resourceEIPAssociateUpdate,
 		DeleteContext: resourceEIPAssociateDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -82,6 +83,11 @@ func ResourceEIPAssociate() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"retain_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: `Whether to report the retained EIP after it is unbound when the resource is destroyed.`,
+			},
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -217,6 +223,11 @@ func resourceEIPAssociateRead(_ context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
+func resourceEIPAssociateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only the retain_on_destroy can be updated, which is only used when the resource is destroyed.
+	return resourceEIPAssociateRead(ctx, d, meta)
+}
+
 func resourceEIPAssociateDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cfg := meta.(*config.Config)
 	region := cfg.GetRegion(d)
@@ -232,6 +243,17 @@ func resourceEIPAssociateDelete(_ context.Context, d *schema.ResourceData, meta
 			d.Id(), portID, err)
 	}
 
+	// The EIP is never released by this resource, it is unbound and kept allocated.
+	if d.Get("retain_on_destroy").(bool) {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary: fmt.Sprintf("The EIP (%s) is unbound from the port (%s) and retained because "+
+					"retain_on_destroy is enabled, it can be adopted by the address %s.", d.Id(), portID,
+					d.Get("public_ip").(string)),
+			},
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,459 @@
+package elb
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// The maximum number of members that can be operated in one batch request.
+const memberBatchSize = 100
+
+// @API ELB GET /v3/{project_id}/elb/pools/{pool_id}/members
+// @API ELB POST /v3/{project_id}/elb/pools/{pool_id}/members/batch-add
+// @API ELB POST /v3/{project_id}/elb/pools/{pool_id}/members/batch-update
+// @API ELB POST /v3/{project_id}/elb/pools/{pool_id}/members/batch-delete
+func ResourceMemberSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMemberSetCreate,
+		ReadContext:   resourceMemberSetRead,
+		UpdateContext: resourceMemberSetUpdate,
+		DeleteContext: resourceMemberSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMemberSetImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"pool_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     memberSetMemberSchema(),
+			},
+			"member_statuses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     memberSetMemberStatusSchema(),
+			},
+		},
+	}
+}
+
+func memberSetMemberSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"protocol_port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// The weight is not managed if it is omitted, e.g. it is changed by huaweicloud_elb_traffic_shift.
+			"weight": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func memberSetMemberStatusSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"protocol_port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"weight": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"operating_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// getUnmanagedWeightMemberKeys returns the keys of the members whose weight is omitted in the configuration.
+func getUnmanagedWeightMemberKeys(d *schema.ResourceData) map[string]bool {
+	result := make(map[string]bool)
+	members, _ := utils.GetNestedObjectFromRawConfig(d.GetRawConfig(), "members").([]interface{})
+	for _, member := range members {
+		if utils.PathSearch("weight", member, nil) != nil {
+			continue
+		}
+		key := memberSetKey(utils.PathSearch("address", member, "").(string),
+			int(utils.PathSearch("protocol_port", member, float64(0)).(float64)),
+			utils.PathSearch("subnet_id", member, "").(string))
+		result[key] = true
+	}
+	return result
+}
+
+// buildMemberSetWeight returns nil if the weight of the member is not managed.
+func buildMemberSetWeight(weight int, unmanaged bool) interface{} {
+	if unmanaged {
+		return nil
+	}
+	return weight
+}
+
+func memberSetKey(address string, protocolPort int, subnetId string) string {
+	return fmt.Sprintf("%s|%d|%s", address, protocolPort, subnetId)
+}
+
+func desiredMemberSetKey(desired map[string]interface{}) string {
+	return memberSetKey(desired["address"].(string), desired["protocol_port"].(int), desired["subnet_id"].(string))
+}
+
+func remoteMemberSetKey(remote interface{}) string {
+	return memberSetKey(utils.PathSearch("address", remote, "").(string),
+		int(utils.PathSearch("protocol_port", remote, float64(0)).(float64)),
+		utils.PathSearch("subnet_cidr_id", remote, "").(string))
+}
+
+// listPoolMembers queries all members of the pool.
+func listPoolMembers(client *golangsdk.ServiceClient, poolId string) ([]interface{}, error) {
+	httpUrl := "v3/{project_id}/elb/pools/{pool_id}/members?limit=2000"
+	listPath := client.Endpoint + httpUrl
+	listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+	listPath = strings.ReplaceAll(listPath, "{pool_id}", poolId)
+
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+
+	result := make([]interface{}, 0)
+	marker := ""
+	for {
+		queryPath := listPath
+		if marker != "" {
+			queryPath = fmt.Sprintf("%s&marker=%s", listPath, marker)
+		}
+		listResp, err := client.Request("GET", queryPath, &listOpt)
+		if err != nil {
+			return nil, err
+		}
+		listRespBody, err := utils.FlattenResponse(listResp)
+		if err != nil {
+			return nil, err
+		}
+
+		members := utils.PathSearch("members", listRespBody, make([]interface{}, 0)).([]interface{})
+		result = append(result, members...)
+		marker = utils.PathSearch("page_info.next_marker", listRespBody, "").(string)
+		if marker == "" || len(members) == 0 {
+			break
+		}
+	}
+	return result, nil
+}
+
+// batchOperatePoolMembers calls the batch API of the pool members in chunks, the action can be batch-add,
+// batch-update and batch-delete. The IDs of the members in the responses are returned.
+func batchOperatePoolMembers(client *golangsdk.ServiceClient, poolId, action string,
+	members []map[string]interface{}) ([]string, error) {
+	httpUrl := "v3/{project_id}/elb/pools/{pool_id}/members/{action}"
+	batchPath := client.Endpoint + httpUrl
+	batchPath = strings.ReplaceAll(batchPath, "{project_id}", client.ProjectID)
+	batchPath = strings.ReplaceAll(batchPath, "{pool_id}", poolId)
+	batchPath = strings.ReplaceAll(batchPath, "{action}", action)
+
+	result := make([]string, 0, len(members))
+	for start := 0; start < len(members); start += memberBatchSize {
+		end := start + memberBatchSize
+		if end > len(members) {
+			end = len(members)
+		}
+
+		batchOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+			OkCodes:          []int{200, 201, 202, 204},
+			JSONBody: map[string]interface{}{
+				"members": members[start:end],
+			},
+		}
+		batchResp, err := client.Request("POST", batchPath, &batchOpt)
+		if err != nil {
+			return nil, fmt.Errorf("error calling %s API of the pool (%s) members: %s", action, poolId, err)
+		}
+		batchRespBody, err := utils.FlattenResponse(batchResp)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range utils.PathSearch("members[*].id", batchRespBody, make([]interface{}, 0)).([]interface{}) {
+			result = append(result, id.(string))
+		}
+	}
+	return result, nil
+}
+
+// waitForPoolMembersReady waits for the members to leave the INITIAL status.
+func waitForPoolMembersReady(ctx context.Context, client *golangsdk.ServiceClient, poolId string,
+	memberIds []string, timeout time.Duration) error {
+	if len(memberIds) == 0 {
+		return nil
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Target:  []string{"Ready"},
+		Pending: []string{"Pending"},
+		Refresh: func() (interface{}, string, error) {
+			members, err := listPoolMembers(client, poolId)
+			if err != nil {
+				return nil, "Failed", err
+			}
+			for _, member := range members {
+				id := utils.PathSearch("id", member, "").(string)
+				status := utils.PathSearch("operating_status", member, "").(string)
+				if utils.StrSliceContains(memberIds, id) && status == "INITIAL" {
+					return members, "Pending", nil
+				}
+			}
+			return members, "Ready", nil
+		},
+		Timeout:      timeout,
+		Delay:        2 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the members of the pool (%s) to be ready: %s", poolId, err)
+	}
+	return nil
+}
+
+// applyMemberSet makes the members of the pool the same as the desired ones. The members are matched by address,
+// protocol port and subnet, the weight and name of the matched members are updated if they are changed, and the
+// others are added or deleted. The weight of the members in unmanagedWeights is neither sent nor compared.
+func applyMemberSet(ctx context.Context, client *golangsdk.ServiceClient, poolId string, desiredList []interface{},
+	unmanagedWeights map[string]bool, timeout time.Duration) error {
+	config.MutexKV.Lock(poolId)
+	defer config.MutexKV.Unlock(poolId)
+
+	remoteList, err := listPoolMembers(client, poolId)
+	if err != nil {
+		return fmt.Errorf("error retrieving members of the pool (%s): %s", poolId, err)
+	}
+
+	remoteMap := make(map[string]interface{}, len(remoteList))
+	for _, remote := range remoteList {
+		remoteMap[remoteMemberSetKey(remote)] = remote
+	}
+
+	toAdd := make([]map[string]interface{}, 0)
+	toUpdate := make([]map[string]interface{}, 0)
+	for _, v := range desiredList {
+		desired := v.(map[string]interface{})
+		key := desiredMemberSetKey(desired)
+		remote, ok := remoteMap[key]
+		if !ok {
+			toAdd = append(toAdd, map[string]interface{}{
+				"address":        desired["address"],
+				"protocol_port":  utils.ValueIgnoreEmpty(desired["protocol_port"]),
+				"subnet_cidr_id": utils.ValueIgnoreEmpty(desired["subnet_id"]),
+				"weight":         buildMemberSetWeight(desired["weight"].(int), unmanagedWeights[key]),
+				"name":           utils.ValueIgnoreEmpty(desired["name"]),
+			})
+			continue
+		}
+		delete(remoteMap, key)
+
+		weight := desired["weight"].(int)
+		remoteWeight := int(utils.PathSearch("weight", remote, float64(0)).(float64))
+		name := desired["name"].(string)
+		if (!unmanagedWeights[key] && weight != remoteWeight) ||
+			name != utils.PathSearch("name", remote, "").(string) {
+			toUpdate = append(toUpdate, utils.RemoveNil(map[string]interface{}{
+				"id":     utils.PathSearch("id", remote, ""),
+				"weight": buildMemberSetWeight(weight, unmanagedWeights[key]),
+				"name":   name,
+			}))
+		}
+	}
+
+	toDelete := make([]map[string]interface{}, 0, len(remoteMap))
+	for _, remote := range remoteMap {
+		toDelete = append(toDelete, map[string]interface{}{"id": utils.PathSearch("id", remote, "")})
+	}
+	log.Printf("[DEBUG] applying members of the pool (%s): %d to add, %d to update, %d to delete", poolId,
+		len(toAdd), len(toUpdate), len(toDelete))
+
+	if len(toDelete) > 0 {
+		if _, err := batchOperatePoolMembers(client, poolId, "batch-delete", toDelete); err != nil {
+			return err
+		}
+	}
+	if len(toUpdate) > 0 {
+		if _, err := batchOperatePoolMembers(client, poolId, "batch-update", toUpdate); err != nil {
+			return err
+		}
+	}
+	if len(toAdd) > 0 {
+		addedIds, err := batchOperatePoolMembers(client, poolId, "batch-add", toAdd)
+		if err != nil {
+			return err
+		}
+		return waitForPoolMembersReady(ctx, client, poolId, addedIds, timeout)
+	}
+	return nil
+}
+
+func resourceMemberSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("elb", region)
+	if err != nil {
+		return diag.Errorf("error creating ELB client: %s", err)
+	}
+
+	poolId := d.Get("pool_id").(string)
+	err = applyMemberSet(ctx, client, poolId, d.Get("members").(*schema.Set).List(), getUnmanagedWeightMemberKeys(d),
+		d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.Errorf("error creating ELB member set: %s", err)
+	}
+
+	d.SetId(poolId)
+
+	return resourceMemberSetRead(ctx, d, meta)
+}
+
+func flattenMemberSetMembers(remoteList []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(remoteList))
+	for _, remote := range remoteList {
+		member := map[string]interface{}{
+			"address":       utils.PathSearch("address", remote, nil),
+			"protocol_port": utils.PathSearch("protocol_port", remote, nil),
+			"subnet_id":     utils.PathSearch("subnet_cidr_id", remote, nil),
+			"weight":        utils.PathSearch("weight", remote, nil),
+			"name":          utils.PathSearch("name", remote, nil),
+		}
+		result = append(result, member)
+	}
+	return result
+}
+
+func flattenMemberSetMemberStatuses(remoteList []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(remoteList))
+	for _, remote := range remoteList {
+		result = append(result, map[string]interface{}{
+			"id":               utils.PathSearch("id", remote, nil),
+			"address":          utils.PathSearch("address", remote, nil),
+			"protocol_port":    utils.PathSearch("protocol_port", remote, nil),
+			"weight":           utils.PathSearch("weight", remote, nil),
+			"operating_status": utils.PathSearch("operating_status", remote, nil),
+		})
+	}
+	return result
+}
+
+func resourceMemberSetRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("elb", region)
+	if err != nil {
+		return diag.Errorf("error creating ELB client: %s", err)
+	}
+
+	remoteList, err := listPoolMembers(client, d.Id())
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "error retrieving ELB member set")
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("pool_id", d.Id()),
+		d.Set("members", flattenMemberSetMembers(remoteList)),
+		d.Set("member_statuses", flattenMemberSetMemberStatuses(remoteList)),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+func resourceMemberSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("elb", region)
+	if err != nil {
+		return diag.Errorf("error creating ELB client: %s", err)
+	}
+
+	if d.HasChange("members") {
+		err = applyMemberSet(ctx, client, d.Id(), d.Get("members").(*schema.Set).List(),
+			getUnmanagedWeightMemberKeys(d), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.Errorf("error updating ELB member set: %s", err)
+		}
+	}
+	return resourceMemberSetRead(ctx, d, meta)
+}
+
+func resourceMemberSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("elb", region)
+	if err != nil {
+		return diag.Errorf("error creating ELB client: %s", err)
+	}
+
+	err = applyMemberSet(ctx, client, d.Id(), nil, nil, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.Errorf("error deleting ELB member set: %s", err)
+	}
+	return nil
+}
+
+func resourceMemberSetImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	return []*schema.ResourceData{d}, d.Set("pool_id", d.Id())
+}
@@ -0,0 +1,593 @@
+package elb
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/httphelper"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// The operating statuses of the members which are considered healthy.
+var healthyMemberStatuses = []string{"ONLINE", "NO_MONITOR"}
+
+// @API ELB GET /v3/{project_id}/elb/members
+// @API ELB GET /v3/{project_id}/elb/pools/{pool_id}/members
+// @API ELB POST /v3/{project_id}/elb/pools/{pool_id}/members/batch-update
+// @API ELB GET /v3/{project_id}/elb/l7policies/{l7policy_id}
+// @API ELB PUT /v3/{project_id}/elb/l7policies/{l7policy_id}
+func ResourceTrafficShift() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTrafficShiftCreate,
+		ReadContext:   resourceTrafficShiftRead,
+		UpdateContext: resourceTrafficShiftUpdate,
+		DeleteContext: resourceTrafficShiftDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"l7policy_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"l7policy_id", "pool_id"},
+				RequiredWith: []string{"source_pool_id", "target_pool_id"},
+			},
+			"source_pool_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"l7policy_id"},
+			},
+			"target_pool_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"l7policy_id"},
+			},
+			"pool_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"source_addresses", "target_addresses"},
+			},
+			"source_addresses": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				RequiredWith: []string{"pool_id"},
+			},
+			"target_addresses": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				RequiredWith: []string{"pool_id"},
+			},
+			"percentage": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"step_percentage": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      20,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"step_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"health_check_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"rollback_on_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"current_percentage": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// The weights before the traffic is shifted, the key is the ID of the pool or the member.
+			"original_weights": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+// trafficShifter shifts the traffic between the source and the target in one of the following ways:
+// 1. the weights of the source and target pools in the redirect pools of the L7 policy;
+// 2. the weights of the source and target members in the same pool.
+type trafficShifter struct {
+	client *golangsdk.ServiceClient
+	d      *schema.ResourceData
+}
+
+func (s *trafficShifter) isL7Policy() bool {
+	return s.d.Get("l7policy_id").(string) != ""
+}
+
+// targetPoolId returns the pool whose members should be healthy after the traffic shifted to the target.
+func (s *trafficShifter) targetPoolId() string {
+	if s.isL7Policy() {
+		return s.d.Get("target_pool_id").(string)
+	}
+	return s.d.Get("pool_id").(string)
+}
+
+func (s *trafficShifter) listMembersByAddresses(addresses []string) ([]interface{}, error) {
+	members, err := listPoolMembers(s.client, s.d.Get("pool_id").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0)
+	for _, member := range members {
+		if utils.StrSliceContains(addresses, utils.PathSearch("address", member, "").(string)) {
+			result = append(result, member)
+		}
+	}
+	return result, nil
+}
+
+func (s *trafficShifter) addresses(key string) []string {
+	return utils.ExpandToStringList(s.d.Get(key).([]interface{}))
+}
+
+// getCurrentPercentage calculates the percentage of the traffic which is forwarded to the target.
+func (s *trafficShifter) getCurrentPercentage() (int, error) {
+	var sourceWeight, targetWeight float64
+	if s.isL7Policy() {
+		policy, err := getL7Policy(s.client, s.d.Get("l7policy_id").(string))
+		if err != nil {
+			return 0, err
+		}
+		for _, v := range utils.PathSearch("l7policy.redirect_pools_config", policy, make([]interface{}, 0)).([]interface{}) {
+			weight := utils.PathSearch("weight", v, float64(0)).(float64)
+			switch utils.PathSearch("pool_id", v, "").(string) {
+			case s.d.Get("source_pool_id").(string):
+				sourceWeight += weight
+			case s.d.Get("target_pool_id").(string):
+				targetWeight += weight
+			}
+		}
+	} else {
+		sources, err := s.listMembersByAddresses(s.addresses("source_addresses"))
+		if err != nil {
+			return 0, err
+		}
+		targets, err := s.listMembersByAddresses(s.addresses("target_addresses"))
+		if err != nil {
+			return 0, err
+		}
+		for _, v := range sources {
+			sourceWeight += utils.PathSearch("weight", v, float64(0)).(float64)
+		}
+		for _, v := range targets {
+			targetWeight += utils.PathSearch("weight", v, float64(0)).(float64)
+		}
+	}
+
+	if sourceWeight+targetWeight == 0 {
+		return 0, nil
+	}
+	return int(math.Round(targetWeight * 100 / (sourceWeight + targetWeight))), nil
+}
+
+// getOriginalWeights queries the weights of the source and target pools in the redirect pools of the L7 policy, or the
+// weights of the source and target members.
+func (s *trafficShifter) getOriginalWeights() (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	if s.isL7Policy() {
+		policy, err := getL7Policy(s.client, s.d.Get("l7policy_id").(string))
+		if err != nil {
+			return nil, err
+		}
+		poolIds := []string{s.d.Get("source_pool_id").(string), s.d.Get("target_pool_id").(string)}
+		for _, v := range utils.PathSearch("l7policy.redirect_pools_config", policy, make([]interface{}, 0)).([]interface{}) {
+			poolId := utils.PathSearch("pool_id", v, "").(string)
+			if utils.StrSliceContains(poolIds, poolId) {
+				result[poolId] = int(utils.PathSearch("weight", v, float64(0)).(float64))
+			}
+		}
+		return result, nil
+	}
+
+	addresses := append(s.addresses("source_addresses"), s.addresses("target_addresses")...)
+	members, err := s.listMembersByAddresses(addresses)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range members {
+		result[utils.PathSearch("id", v, "").(string)] = int(utils.PathSearch("weight", v, float64(0)).(float64))
+	}
+	return result, nil
+}
+
+// restoreOriginalWeights sets the weights of the pools or members back to the ones before the traffic is shifted.
+// The source or target pool which is not in the redirect pools originally is removed from the L7 policy, and the
+// members which no longer exist are skipped.
+func (s *trafficShifter) restoreOriginalWeights(ctx context.Context, weights map[string]interface{},
+	timeout time.Duration) error {
+	if s.isL7Policy() {
+		policyId := s.d.Get("l7policy_id").(string)
+		policy, err := getL7Policy(s.client, policyId)
+		if err != nil {
+			return err
+		}
+
+		poolIds := []string{s.d.Get("source_pool_id").(string), s.d.Get("target_pool_id").(string)}
+		poolsConfig := make([]map[string]interface{}, 0)
+		for _, v := range utils.PathSearch("l7policy.redirect_pools_config", policy, make([]interface{}, 0)).([]interface{}) {
+			poolId := utils.PathSearch("pool_id", v, "").(string)
+			weight := utils.PathSearch("weight", v, float64(0))
+			if utils.StrSliceContains(poolIds, poolId) {
+				w, ok := weights[poolId]
+				if !ok {
+					continue
+				}
+				weight = w
+			}
+			poolsConfig = append(poolsConfig, map[string]interface{}{"pool_id": poolId, "weight": weight})
+		}
+		return s.updateL7PolicyRedirectPools(ctx, poolsConfig, timeout)
+	}
+
+	poolId := s.d.Get("pool_id").(string)
+	config.MutexKV.Lock(poolId)
+	defer config.MutexKV.Unlock(poolId)
+
+	remoteMembers, err := listPoolMembers(s.client, poolId)
+	if err != nil {
+		return err
+	}
+	members := make([]map[string]interface{}, 0, len(weights))
+	for _, v := range remoteMembers {
+		id := utils.PathSearch("id", v, "").(string)
+		if weight, ok := weights[id]; ok {
+			members = append(members, map[string]interface{}{"id": id, "weight": weight})
+		}
+	}
+	if len(members) == 0 {
+		return nil
+	}
+	_, err = batchOperatePoolMembers(s.client, poolId, "batch-update", members)
+	return err
+}
+
+// buildMemberWeights calculates the weight of each source member and target member so that the percentage of the
+// traffic is forwarded to the target members. The maximum weight is 100.
+func buildMemberWeights(percentage, sourceCount, targetCount int) (sourceWeight, targetWeight int) {
+	if sourceCount == 0 || targetCount == 0 {
+		if percentage > 0 {
+			targetWeight = 100
+		}
+		if percentage < 100 {
+			sourceWeight = 100
+		}
+		return
+	}
+
+	rawSource := float64(100-percentage) / float64(sourceCount)
+	rawTarget := float64(percentage) / float64(targetCount)
+	maxWeight := math.Max(rawSource, rawTarget)
+	sourceWeight = int(math.Round(rawSource / maxWeight * 100))
+	targetWeight = int(math.Round(rawTarget / maxWeight * 100))
+	// Make sure the members still receive the traffic after rounding.
+	if percentage < 100 && sourceWeight == 0 {
+		sourceWeight = 1
+	}
+	if percentage > 0 && targetWeight == 0 {
+		targetWeight = 1
+	}
+	return
+}
+
+func (s *trafficShifter) applyPercentage(ctx context.Context, percentage int) error {
+	log.Printf("[DEBUG] shifting %d%% of the traffic to the target", percentage)
+	if s.isL7Policy() {
+		return s.applyL7PolicyPercentage(ctx, percentage)
+	}
+
+	sources, err := s.listMembersByAddresses(s.addresses("source_addresses"))
+	if err != nil {
+		return err
+	}
+	targets, err := s.listMembersByAddresses(s.addresses("target_addresses"))
+	if err != nil {
+		return err
+	}
+	sourceWeight, targetWeight := buildMemberWeights(percentage, len(sources), len(targets))
+
+	members := make([]map[string]interface{}, 0, len(sources)+len(targets))
+	for _, v := range sources {
+		members = append(members, map[string]interface{}{"id": utils.PathSearch("id", v, ""), "weight": sourceWeight})
+	}
+	for _, v := range targets {
+		members = append(members, map[string]interface{}{"id": utils.PathSearch("id", v, ""), "weight": targetWeight})
+	}
+
+	poolId := s.d.Get("pool_id").(string)
+	config.MutexKV.Lock(poolId)
+	defer config.MutexKV.Unlock(poolId)
+	_, err = batchOperatePoolMembers(s.client, poolId, "batch-update", members)
+	return err
+}
+
+func (s *trafficShifter) applyL7PolicyPercentage(ctx context.Context, percentage int) error {
+	policyId := s.d.Get("l7policy_id").(string)
+	policy, err := getL7Policy(s.client, policyId)
+	if err != nil {
+		return err
+	}
+
+	weights := map[string]int{
+		s.d.Get("source_pool_id").(string): 100 - percentage,
+		s.d.Get("target_pool_id").(string): percentage,
+	}
+	poolsConfig := make([]map[string]interface{}, 0)
+	for _, v := range utils.PathSearch("l7policy.redirect_pools_config", policy, make([]interface{}, 0)).([]interface{}) {
+		poolId := utils.PathSearch("pool_id", v, "").(string)
+		weight := utils.PathSearch("weight", v, float64(0))
+		if w, ok := weights[poolId]; ok {
+			weight = w
+			delete(weights, poolId)
+		}
+		poolsConfig = append(poolsConfig, map[string]interface{}{"pool_id": poolId, "weight": weight})
+	}
+	// The source or target pool is not in the redirect pools yet.
+	for poolId, weight := range weights {
+		poolsConfig = append(poolsConfig, map[string]interface{}{"pool_id": poolId, "weight": weight})
+	}
+	return s.updateL7PolicyRedirectPools(ctx, poolsConfig, s.d.Timeout(schema.TimeoutUpdate))
+}
+
+func (s *trafficShifter) updateL7PolicyRedirectPools(ctx context.Context, poolsConfig []map[string]interface{},
+	timeout time.Duration) error {
+	policyId := s.d.Get("l7policy_id").(string)
+	httpUrl := "v3/{project_id}/elb/l7policies/{l7policy_id}"
+	updatePath := s.client.Endpoint + httpUrl
+	updatePath = strings.ReplaceAll(updatePath, "{project_id}", s.client.ProjectID)
+	updatePath = strings.ReplaceAll(updatePath, "{l7policy_id}", policyId)
+	updateOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody: map[string]interface{}{
+			"l7policy": map[string]interface{}{
+				"redirect_pools_config": poolsConfig,
+			},
+		},
+	}
+	if _, err := s.client.Request("PUT", updatePath, &updateOpt); err != nil {
+		return fmt.Errorf("error updating the redirect pools of the L7 policy (%s): %s", policyId, err)
+	}
+	return waitForL7Policy(ctx, s.client, policyId, "ACTIVE", nil, timeout)
+}
+
+// listTargetMemberStatuses queries the operating statuses of the target members through the API used by the data
+// source huaweicloud_elb_all_members.
+func (s *trafficShifter) listTargetMemberStatuses() (map[string]string, error) {
+	resp, err := httphelper.New(s.client).
+		Method("GET").
+		URI("/v3/{project_id}/elb/members").
+		Query(map[string]any{"pool_id": []string{s.targetPoolId()}}).
+		MarkerPager("members", "page_info.next_marker", "marker").
+		Request().
+		Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var targetAddresses []string
+	if !s.isL7Policy() {
+		targetAddresses = s.addresses("target_addresses")
+	}
+	result := make(map[string]string)
+	for _, member := range resp.Get("members").Array() {
+		if targetAddresses != nil && !utils.StrSliceContains(targetAddresses, member.Get("address").String()) {
+			continue
+		}
+		result[member.Get("id").String()] = member.Get("operating_status").String()
+	}
+	return result, nil
+}
+
+// waitForTargetHealthy waits for all members of the target to be healthy.
+func (s *trafficShifter) waitForTargetHealthy(ctx context.Context) error {
+	timeout := time.Duration(s.d.Get("health_check_timeout").(int)) * time.Second
+	stateConf := &retry.StateChangeConf{
+		Target:  []string{"Healthy"},
+		Pending: []string{"Unhealthy"},
+		Refresh: func() (interface{}, string, error) {
+			statuses, err := s.listTargetMemberStatuses()
+			if err != nil {
+				return nil, "Failed", err
+			}
+			for id, status := range statuses {
+				if !utils.StrSliceContains(healthyMemberStatuses, status) {
+					log.Printf("[DEBUG] the target member (%s) is %s", id, status)
+					return statuses, "Unhealthy", nil
+				}
+			}
+			return statuses, "Healthy", nil
+		},
+		Timeout:      timeout,
+		PollInterval: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the target members to be healthy: %s", err)
+	}
+	return nil
+}
+
+// shift moves the traffic from the current percentage to the target percentage step by step. Between the steps, it
+// waits for the step interval and the target members to be healthy. If any step fails, the traffic is moved back to
+// the original percentage if rollback_on_failure is enabled.
+func (s *trafficShifter) shift(ctx context.Context, from, to int) error {
+	step := s.d.Get("step_percentage").(int)
+	interval := time.Duration(s.d.Get("step_interval").(int)) * time.Second
+
+	current := from
+	for {
+		next := to
+		if to > current && current+step < to {
+			next = current + step
+		} else if to < current && current-step > to {
+			next = current - step
+		}
+
+		err := s.applyPercentage(ctx, next)
+		if err == nil && next > 0 {
+			// Give the health check a chance to detect the unhealthy members under the new traffic.
+			if next != to {
+				select {
+				case <-ctx.Done():
+					err = ctx.Err()
+				case <-time.After(interval):
+				}
+			}
+			if err == nil {
+				err = s.waitForTargetHealthy(ctx)
+			}
+		}
+		if err != nil {
+			if !s.d.Get("rollback_on_failure").(bool) || next == from {
+				return fmt.Errorf("error shifting %d%% of the traffic to the target: %s", next, err)
+			}
+			log.Printf("[WARN] failed to shift %d%% of the traffic to the target, rolling back to %d%%: %s",
+				next, from, err)
+			if rollbackErr := s.applyPercentage(ctx, from); rollbackErr != nil {
+				return fmt.Errorf("error shifting %d%% of the traffic to the target: %s; and failed to roll back to "+
+					"%d%%: %s", next, err, from, rollbackErr)
+			}
+			return fmt.Errorf("error shifting %d%% of the traffic to the target: %s; the traffic has been rolled "+
+				"back to %d%%", next, err, from)
+		}
+
+		current = next
+		if current == to {
+			return nil
+		}
+	}
+}
+
+func resourceTrafficShiftCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("elb", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating ELB client: %s", err)
+	}
+
+	shifter := &trafficShifter{client: client, d: d}
+	from, err := shifter.getCurrentPercentage()
+	if err != nil {
+		return diag.Errorf("error retrieving the current traffic percentage: %s", err)
+	}
+	originalWeights, err := shifter.getOriginalWeights()
+	if err != nil {
+		return diag.Errorf("error retrieving the original weights: %s", err)
+	}
+
+	// Save the original weights before shifting, so that they can be restored even if the shifting fails.
+	if shifter.isL7Policy() {
+		d.SetId(d.Get("l7policy_id").(string))
+	} else {
+		d.SetId(d.Get("pool_id").(string))
+	}
+	if err := d.Set("original_weights", originalWeights); err != nil {
+		return diag.Errorf("error saving the original weights: %s", err)
+	}
+
+	if err := shifter.shift(ctx, from, d.Get("percentage").(int)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTrafficShiftRead(ctx, d, meta)
+}
+
+func resourceTrafficShiftRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("elb", region)
+	if err != nil {
+		return diag.Errorf("error creating ELB client: %s", err)
+	}
+
+	shifter := &trafficShifter{client: client, d: d}
+	current, err := shifter.getCurrentPercentage()
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "error retrieving the current traffic percentage")
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("current_percentage", current),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+func resourceTrafficShiftUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("elb", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating ELB client: %s", err)
+	}
+
+	if d.HasChange("percentage") {
+		shifter := &trafficShifter{client: client, d: d}
+		if err := shifter.shift(ctx, d.Get("current_percentage").(int), d.Get("percentage").(int)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceTrafficShiftRead(ctx, d, meta)
+}
+
+func resourceTrafficShiftDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("elb", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating ELB client: %s", err)
+	}
+
+	shifter := &trafficShifter{client: client, d: d}
+	originalWeights := d.Get("original_weights").(map[string]interface{})
+	if err := shifter.restoreOriginalWeights(ctx, originalWeights, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return common.CheckDeletedDiag(d, err, "error restoring the original weights")
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package er
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// @API ER GET /v3/{project_id}/enterprise-router/route-tables/{route_table_id}/routes
+func DataSourceEffectiveRoutes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEffectiveRoutesRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: `The region where the route table is located.`,
+			},
+			"route_table_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The ID of the route table to which the effective routes belong.`,
+			},
+			"destination": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The destination of the effective routes to be queried.`,
+			},
+			"origin": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The origin of the effective routes to be queried.`,
+			},
+			"routes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        effectiveRouteSchema(),
+				Description: `All effective routes that match the filter parameters.`,
+			},
+			"blackhole_destinations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The destinations of the blackhole routes.`,
+			},
+			"overlapping_routes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        overlappingRouteSchema(),
+				Description: `The pairs of effective routes whose destinations overlap.`,
+			},
+		},
+	}
+}
+
+func effectiveRouteSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The ID of the route.`,
+			},
+			"destination": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The destination of the route.`,
+			},
+			"origin": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The origin of the route.`,
+			},
+			"next_hop_attachment_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The ID of the attachment to which the route first points.`,
+			},
+			"next_hops": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: `The next hops of the route.`,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attachment_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The ID of the attachment.`,
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The ID of the attached resource.`,
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The type of the attached resource.`,
+						},
+					},
+				},
+			},
+			"is_blackhole": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: `Whether the route is a blackhole route.`,
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The state of the route.`,
+			},
+		},
+	}
+}
+
+func overlappingRouteSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"destination": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The destination of the route.`,
+			},
+			"origin": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The origin of the route.`,
+			},
+			"overlapped_destination": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The destination of the route overlapped by the route.`,
+			},
+			"overlapped_origin": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The origin of the route overlapped by the route.`,
+			},
+		},
+	}
+}
+
+// getEffectiveRouteState returns the state of the route, a route without any next hop can not forward the traffic.
+func getEffectiveRouteState(route interface{}) string {
+	if utils.PathSearch("is_blackhole", route, false).(bool) {
+		return "blackhole"
+	}
+	if len(utils.PathSearch("next_hops", route, make([]interface{}, 0)).([]interface{})) == 0 {
+		return "unreachable"
+	}
+	return "active"
+}
+
+func flattenEffectiveRoutes(routes []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(routes))
+	for _, route := range routes {
+		nextHops := utils.PathSearch("next_hops", route, make([]interface{}, 0)).([]interface{})
+		flattenedNextHops := make([]interface{}, 0, len(nextHops))
+		for _, nextHop := range nextHops {
+			flattenedNextHops = append(flattenedNextHops, map[string]interface{}{
+				"attachment_id": utils.PathSearch("attachment_id", nextHop, nil),
+				"resource_id":   utils.PathSearch("resource_id", nextHop, nil),
+				"resource_type": utils.PathSearch("resource_type", nextHop, nil),
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":                     utils.PathSearch("route_id", route, nil),
+			"destination":            utils.PathSearch("destination", route, nil),
+			"origin":                 utils.PathSearch("route_type", route, nil),
+			"next_hop_attachment_id": utils.PathSearch("next_hops[0].attachment_id", route, nil),
+			"next_hops":              flattenedNextHops,
+			"is_blackhole":           utils.PathSearch("is_blackhole", route, false),
+			"state":                  getEffectiveRouteState(route),
+		})
+	}
+	return result
+}
+
+func flattenBlackholeDestinations(routes []interface{}) []string {
+	result := make([]string, 0)
+	for _, route := range routes {
+		if getEffectiveRouteState(route) == "blackhole" {
+			result = append(result, utils.PathSearch("destination", route, "").(string))
+		}
+	}
+	return result
+}
+
+// flattenOverlappingRoutes returns each pair of routes whose destinations overlap, the less specific route of a pair
+// is reported as the overlapped one.
+func flattenOverlappingRoutes(routes []interface{}) []interface{} {
+	result := make([]interface{}, 0)
+	for i := 0; i < len(routes); i++ {
+		for j := i + 1; j < len(routes); j++ {
+			destination := utils.PathSearch("destination", routes[i], "").(string)
+			other := utils.PathSearch("destination", routes[j], "").(string)
+			if !isDestinationOverlapped(destination, other) {
+				continue
+			}
+
+			route, overlapped := routes[i], routes[j]
+			if getDestinationPrefixLength(destination) < getDestinationPrefixLength(other) {
+				route, overlapped = routes[j], routes[i]
+			}
+			result = append(result, map[string]interface{}{
+				"destination":            utils.PathSearch("destination", route, nil),
+				"origin":                 utils.PathSearch("route_type", route, nil),
+				"overlapped_destination": utils.PathSearch("destination", overlapped, nil),
+				"overlapped_origin":      utils.PathSearch("route_type", overlapped, nil),
+			})
+		}
+	}
+	return result
+}
+
+func filterEffectiveRoutes(d *schema.ResourceData, routes []interface{}) []interface{} {
+	origin := d.Get("origin").(string)
+	if origin == "" {
+		return routes
+	}
+
+	result := make([]interface{}, 0, len(routes))
+	for _, route := range routes {
+		if utils.PathSearch("route_type", route, "").(string) == origin {
+			result = append(result, route)
+		}
+	}
+	return result
+}
+
+func dataSourceEffectiveRoutesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var (
+		cfg    = meta.(*config.Config)
+		region = cfg.GetRegion(d)
+	)
+	client, err := cfg.NewServiceClient("er", region)
+	if err != nil {
+		return diag.Errorf("error creating ER client: %s", err)
+	}
+
+	routes, err := listEffectiveRoutes(client, d.Get("route_table_id").(string), d.Get("destination").(string))
+	if err != nil {
+		return diag.Errorf("error querying effective routes: %s", err)
+	}
+	routes = filterEffectiveRoutes(d, routes)
+	sort.SliceStable(routes, func(i, j int) bool {
+		return utils.PathSearch("destination", routes[i], "").(string) <
+			utils.PathSearch("destination", routes[j], "").(string)
+	})
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(id)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("routes", flattenEffectiveRoutes(routes)),
+		d.Set("blackhole_destinations", flattenBlackholeDestinations(routes)),
+		d.Set("overlapping_routes", flattenOverlappingRoutes(routes)),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
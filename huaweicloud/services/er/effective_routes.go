@@ -0,0 +1,110 @@
+package er
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// The route type of the static routes, the other routes in the route table are learned from the propagations.
+const effectiveRouteTypeStatic = "static"
+
+// listEffectiveRoutes queries all effective routes of the route table, the destination is an optional filter.
+func listEffectiveRoutes(client *golangsdk.ServiceClient, routeTableId, destination string) ([]interface{}, error) {
+	var (
+		httpUrl = "v3/{project_id}/enterprise-router/route-tables/{route_table_id}/routes?limit=2000"
+		marker  = ""
+		result  = make([]interface{}, 0)
+	)
+
+	listPath := client.Endpoint + httpUrl
+	listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+	listPath = strings.ReplaceAll(listPath, "{route_table_id}", routeTableId)
+	if destination != "" {
+		listPath += fmt.Sprintf("&destination=%s", url.QueryEscape(destination))
+	}
+
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders: map[string]string{
+			"Content-Type": "application/json",
+		},
+	}
+
+	for {
+		requestPath := listPath
+		if marker != "" {
+			requestPath += fmt.Sprintf("&marker=%s", marker)
+		}
+
+		requestResp, err := client.Request("GET", requestPath, &opt)
+		if err != nil {
+			return nil, err
+		}
+
+		respBody, err := utils.FlattenResponse(requestResp)
+		if err != nil {
+			return nil, err
+		}
+
+		routes := utils.PathSearch("routes", respBody, make([]interface{}, 0)).([]interface{})
+		result = append(result, routes...)
+		nextMarker := utils.PathSearch("page_info.next_marker", respBody, "").(string)
+		if nextMarker == "" || nextMarker == marker {
+			break
+		}
+		marker = nextMarker
+	}
+
+	return result, nil
+}
+
+// isDestinationOverlapped checks whether two route destinations share at least one address, which means that one
+// destination contains the other one.
+func isDestinationOverlapped(destination, other string) bool {
+	_, network, err := net.ParseCIDR(destination)
+	if err != nil {
+		return false
+	}
+	_, otherNetwork, err := net.ParseCIDR(other)
+	if err != nil {
+		return false
+	}
+	return network.Contains(otherNetwork.IP) || otherNetwork.Contains(network.IP)
+}
+
+// getDestinationPrefixLength returns the prefix length of the route destination, -1 is returned if it is invalid.
+func getDestinationPrefixLength(destination string) int {
+	_, network, err := net.ParseCIDR(destination)
+	if err != nil {
+		return -1
+	}
+	ones, _ := network.Mask.Size()
+	return ones
+}
+
+// findOverlappedPropagatedRoutes returns the destinations of the propagated routes which overlap the destination.
+func findOverlappedPropagatedRoutes(client *golangsdk.ServiceClient, routeTableId, destination string) ([]string,
+	error) {
+	routes, err := listEffectiveRoutes(client, routeTableId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0)
+	for _, route := range routes {
+		if utils.PathSearch("route_type", route, "").(string) == effectiveRouteTypeStatic {
+			continue
+		}
+		routeDestination := utils.PathSearch("destination", route, "").(string)
+		if isDestinationOverlapped(destination, routeDestination) {
+			result = append(result, routeDestination)
+		}
+	}
+	return result, nil
+}
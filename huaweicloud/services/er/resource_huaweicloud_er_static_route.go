@@ -3,6 +3,7 @@ package er
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 // @API ER GET /v3/{project_id}/enterprise-router/route-tables/{route_table_id}/static-routes/{route_id}
 // @API ER PUT /v3/{project_id}/enterprise-router/route-tables/{route_table_id}/static-routes/{route_id}
 // @API ER DELETE /v3/{project_id}/enterprise-router/route-tables/{route_table_id}/static-routes/{route_id}
+// @API ER GET /v3/{project_id}/enterprise-router/route-tables/{route_table_id}/routes
 func ResourceStaticRoute() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceStaticRouteCreate,
@@ -40,6 +42,8 @@ func ResourceStaticRoute() *schema.Resource {
 			Delete: schema.DefaultTimeout(2 * time.Minute),
 		},
 
+		CustomizeDiff: checkStaticRouteOverlapped,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:        schema.TypeString,
@@ -71,6 +75,12 @@ func ResourceStaticRoute() *schema.Resource {
 				Default:     false,
 				Description: `Whether route is the black hole route.`,
 			},
+			"reject_overlapped": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: `Whether to reject the static route whose destination overlaps the propagated routes.`,
+			},
 			"type": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -121,6 +131,66 @@ func staticRouteStatusRefreshFunc(client *golangsdk.ServiceClient, routeTableId,
 	}
 }
 
+// checkStaticRouteOverlapped rejects the new static route whose destination overlaps the propagated routes in the
+// same route table during the plan, if the reject_overlapped is enabled.
+func checkStaticRouteOverlapped(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || !d.Get("reject_overlapped").(bool) || !d.NewValueKnown("route_table_id") ||
+		!d.NewValueKnown("destination") {
+		return nil
+	}
+
+	cfg := meta.(*config.Config)
+	region := d.Get("region").(string)
+	if region == "" {
+		region = cfg.Region
+	}
+	client, err := cfg.NewServiceClient("er", region)
+	if err != nil {
+		return fmt.Errorf("error creating ER client: %s", err)
+	}
+
+	destination := d.Get("destination").(string)
+	overlapped, err := findOverlappedPropagatedRoutes(client, d.Get("route_table_id").(string), destination)
+	if err != nil {
+		// The route table may be created in the same plan, the check is performed again before the creation.
+		log.Printf("[WARN] unable to check whether the static route (%s) overlaps the propagated routes: %s",
+			destination, err)
+		return nil
+	}
+	return buildStaticRouteOverlappedError(destination, overlapped)
+}
+
+func buildStaticRouteOverlappedError(destination string, overlapped []string) error {
+	if len(overlapped) == 0 {
+		return nil
+	}
+	return fmt.Errorf("the destination (%s) overlaps the propagated routes (%s), set reject_overlapped to false to "+
+		"create the static route anyway", destination, strings.Join(overlapped, ", "))
+}
+
+// buildStaticRouteOverlappedWarning returns the warning of the propagated routes overlapped by the new static route.
+func buildStaticRouteOverlappedWarning(client *golangsdk.ServiceClient, routeTableId,
+	destination string) diag.Diagnostics {
+	overlapped, err := findOverlappedPropagatedRoutes(client, routeTableId, destination)
+	if err != nil {
+		log.Printf("[WARN] unable to check whether the static route (%s) overlaps the propagated routes: %s",
+			destination, err)
+		return nil
+	}
+	if len(overlapped) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The destination of the static route overlaps the propagated routes",
+			Detail: fmt.Sprintf("The destination (%s) overlaps the propagated routes (%s) in the route table (%s), "+
+				"the traffic to the overlapped addresses will be forwarded by the most specific route.",
+				destination, strings.Join(overlapped, ", "), routeTableId),
+		},
+	}
+}
+
 func resourceStaticRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cfg := meta.(*config.Config)
 	client, err := cfg.ErV3Client(cfg.GetRegion(d))
@@ -132,6 +202,16 @@ func resourceStaticRouteCreate(ctx context.Context, d *schema.ResourceData, meta
 		routeTableId = d.Get("route_table_id").(string)
 		opts         = buildStaticRouteCreateOpts(d)
 	)
+	if d.Get("reject_overlapped").(bool) {
+		overlapped, err := findOverlappedPropagatedRoutes(client, routeTableId, opts.Destination)
+		if err != nil {
+			return diag.Errorf("error checking whether the static route (%s) overlaps the propagated routes: %s",
+				opts.Destination, err)
+		}
+		if err := buildStaticRouteOverlappedError(opts.Destination, overlapped); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	resp, err := routes.Create(client, routeTableId, opts)
 	if err != nil {
 		return diag.Errorf("error creating static route: %s", err)
@@ -150,7 +230,8 @@ func resourceStaticRouteCreate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error waiting for the create operation completed: %s", err)
 	}
 
-	return resourceStaticRouteRead(ctx, d, meta)
+	warnings := buildStaticRouteOverlappedWarning(client, routeTableId, opts.Destination)
+	return append(warnings, resourceStaticRouteRead(ctx, d, meta)...)
 }
 
 func resourceStaticRouteRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -213,24 +294,27 @@ func resourceStaticRouteUpdate(ctx context.Context, d *schema.ResourceData, meta
 			IsBlackHole:  utils.Bool(d.Get("is_blackhole").(bool)),
 		}
 	)
-	_, err = routes.Update(client, routeTableId, staticRouteId, opts)
-	if err != nil {
-		return diag.Errorf("error updating static route (%s): %s", staticRouteId, err)
-	}
+	if d.HasChanges("attachment_id", "is_blackhole") {
+		_, err = routes.Update(client, routeTableId, staticRouteId, opts)
+		if err != nil {
+			return diag.Errorf("error updating static route (%s): %s", staticRouteId, err)
+		}
 
-	stateConf := &retry.StateChangeConf{
-		Pending:      []string{"PENDING"},
-		Target:       []string{"COMPLETED"},
-		Refresh:      staticRouteStatusRefreshFunc(client, routeTableId, d.Id(), []string{"available"}),
-		Timeout:      d.Timeout(schema.TimeoutUpdate),
-		PollInterval: 10 * time.Second,
-	}
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("error waiting for the update operation completed: %s", err)
+		stateConf := &retry.StateChangeConf{
+			Pending:      []string{"PENDING"},
+			Target:       []string{"COMPLETED"},
+			Refresh:      staticRouteStatusRefreshFunc(client, routeTableId, d.Id(), []string{"available"}),
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+			PollInterval: 10 * time.Second,
+		}
+		_, err = stateConf.WaitForStateContext(ctx)
+		if err != nil {
+			return diag.Errorf("error waiting for the update operation completed: %s", err)
+		}
 	}
 
-	return resourceStaticRouteRead(ctx, d, meta)
+	warnings := buildStaticRouteOverlappedWarning(client, routeTableId, d.Get("destination").(string))
+	return append(warnings, resourceStaticRouteRead(ctx, d, meta)...)
 }
 
 func resourceStaticRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -272,8 +356,9 @@ func resourceStaticRouteImportState(_ context.Context, d *schema.ResourceData, _
 	}
 
 	d.SetId(parts[1])
-	if err := d.Set("route_table_id", parts[0]); err != nil {
-		return []*schema.ResourceData{d}, err
-	}
-	return []*schema.ResourceData{d}, nil
+	mErr := multierror.Append(nil,
+		d.Set("route_table_id", parts[0]),
+		d.Set("reject_overlapped", false),
+	)
+	return []*schema.ResourceData{d}, mErr.ErrorOrNil()
 }
@@ -24,6 +24,10 @@ import (
 // @API ER PUT /v3/{project_id}/enterprise-router/{er_id}/vpc-attachments/{vpc_attachment_id}
 // @API ER DELETE /v3/{project_id}/enterprise-router/{er_id}/vpc-attachments/{vpc_attachment_id}
 // @API ER GET /v3/{project_id}/enterprise-router/{er_id}/vpc-attachments/{vpc_attachment_id}
+// @API ER POST /v3/{project_id}/enterprise-router/{er_id}/attachments/{attachment_id}/accept
+// @API ER GET /v3/{project_id}/enterprise-router/{er_id}/attachments/{attachment_id}
+// @API IAM POST /v3.0/OS-CREDENTIAL/securitytokens
+// @API STS POST /v5/agencies/assume
 func ResourceVpcAttachment() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVpcAttachmentCreate,
@@ -87,12 +91,19 @@ func ResourceVpcAttachment() *schema.Resource {
 				Description: `Whether to automatically configure routes for the VPC which pointing to the ER instance.`,
 			},
 			"tags": common.TagsSchema(),
+			"peer_assume_role": common.PeerAssumeRoleSchema(
+				`The agency of the ER instance owner, which is used to accept the VPC attachment.`),
 			// Attributes
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: `The current status of the VPC attachment.`,
 			},
+			"peer_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The status of the VPC attachment in the account of the ER instance owner.`,
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -129,10 +140,15 @@ func resourceVpcAttachmentCreate(ctx context.Context, d *schema.ResourceData, me
 	}
 	d.SetId(resp.ID)
 
+	targets := []string{"available", "initiating_request"}
+	if len(d.Get("peer_assume_role").([]interface{})) > 0 {
+		// The VPC attachment across accounts is accepted by the agency of the ER instance owner after it is created.
+		targets = append(targets, "pending_acceptance")
+	}
 	stateConf := &retry.StateChangeConf{
 		Pending:      []string{"PENDING"},
 		Target:       []string{"COMPLETED"},
-		Refresh:      vpcAttachmentStatusRefreshFunc(client, instanceId, d.Id(), []string{"available", "initiating_request"}),
+		Refresh:      vpcAttachmentStatusRefreshFunc(client, instanceId, d.Id(), targets),
 		Timeout:      d.Timeout(schema.TimeoutCreate),
 		Delay:        5 * time.Second,
 		PollInterval: 10 * time.Second,
@@ -141,9 +157,100 @@ func resourceVpcAttachmentCreate(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	if err = acceptVpcAttachmentByPeer(ctx, client, d, cfg, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
 	return resourceVpcAttachmentRead(ctx, d, meta)
 }
 
+// acceptVpcAttachmentByPeer accepts the VPC attachment by the agency of the ER instance owner and waits for the VPC
+// attachment to become available.
+func acceptVpcAttachmentByPeer(ctx context.Context, client *golangsdk.ServiceClient, d *schema.ResourceData,
+	cfg *config.Config, timeout time.Duration) error {
+	var (
+		acceptHttpUrl = "v3/{project_id}/enterprise-router/{er_id}/attachments/{attachment_id}/accept"
+		instanceId    = d.Get("instance_id").(string)
+		attachmentId  = d.Id()
+	)
+
+	peerCfg, err := common.GetPeerConfig(d, cfg)
+	if err != nil {
+		return err
+	}
+	if peerCfg == nil {
+		return nil
+	}
+
+	peerClient, err := peerCfg.NewServiceClient("er", cfg.GetRegion(d))
+	if err != nil {
+		return fmt.Errorf("error creating ER client of the peer account: %s", err)
+	}
+
+	resp, err := vpcattachments.Get(client, instanceId, attachmentId)
+	if err != nil {
+		return fmt.Errorf("error retrieving VPC attachment (%s): %s", attachmentId, err)
+	}
+	if resp.Status == "pending_acceptance" {
+		acceptPath := peerClient.Endpoint + acceptHttpUrl
+		acceptPath = strings.ReplaceAll(acceptPath, "{project_id}", peerClient.ProjectID)
+		acceptPath = strings.ReplaceAll(acceptPath, "{er_id}", instanceId)
+		acceptPath = strings.ReplaceAll(acceptPath, "{attachment_id}", attachmentId)
+		acceptOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+		}
+		if _, err = peerClient.Request("POST", acceptPath, &acceptOpt); err != nil {
+			return fmt.Errorf("unable to accept the VPC attachment (%s) by the peer account: %s", attachmentId, err)
+		}
+
+		stateConf := &retry.StateChangeConf{
+			Pending:      []string{"PENDING"},
+			Target:       []string{"COMPLETED"},
+			Refresh:      vpcAttachmentStatusRefreshFunc(client, instanceId, attachmentId, []string{"available"}),
+			Timeout:      timeout,
+			Delay:        5 * time.Second,
+			PollInterval: 10 * time.Second,
+		}
+		if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getVpcAttachmentPeerStatus queries the status of the VPC attachment in the account of the ER instance owner by the
+// agency of the owner, an empty status is returned if the peer_assume_role is not specified.
+func getVpcAttachmentPeerStatus(d *schema.ResourceData, cfg *config.Config) (string, error) {
+	httpUrl := "v3/{project_id}/enterprise-router/{er_id}/attachments/{attachment_id}"
+	peerCfg, err := common.GetPeerConfig(d, cfg)
+	if err != nil || peerCfg == nil {
+		return "", err
+	}
+
+	peerClient, err := peerCfg.NewServiceClient("er", cfg.GetRegion(d))
+	if err != nil {
+		return "", fmt.Errorf("error creating ER client of the peer account: %s", err)
+	}
+
+	getPath := peerClient.Endpoint + httpUrl
+	getPath = strings.ReplaceAll(getPath, "{project_id}", peerClient.ProjectID)
+	getPath = strings.ReplaceAll(getPath, "{er_id}", d.Get("instance_id").(string))
+	getPath = strings.ReplaceAll(getPath, "{attachment_id}", d.Id())
+	getOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	requestResp, err := peerClient.Request("GET", getPath, &getOpt)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving VPC attachment in the peer account: %s", err)
+	}
+	respBody, err := utils.FlattenResponse(requestResp)
+	if err != nil {
+		return "", err
+	}
+	return utils.PathSearch("attachment.state", respBody, "").(string), nil
+}
+
 func resourceVpcAttachmentRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var (
 		cfg          = meta.(*config.Config)
@@ -176,10 +283,23 @@ func resourceVpcAttachmentRead(_ context.Context, d *schema.ResourceData, meta i
 		d.Set("updated_at", utils.FormatTimeStampRFC3339(utils.ConvertTimeStrToNanoTimestamp(resp.UpdatedAt)/1000, false)),
 	)
 
+	var diags diag.Diagnostics
+	if peerStatus, err := getVpcAttachmentPeerStatus(d, cfg); err != nil {
+		// The saved peer_status is kept if the agency of the ER instance owner is unavailable.
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unable to refresh the peer status",
+			Detail: fmt.Sprintf("The status of the VPC attachment in the account of the ER instance owner is not "+
+				"refreshed: %s", err),
+		})
+	} else {
+		mErr = multierror.Append(mErr, d.Set("peer_status", peerStatus))
+	}
+
 	if mErr.ErrorOrNil() != nil {
 		return diag.Errorf("error saving VPC attachment (%s) fields: %s", d.Id(), mErr)
 	}
-	return nil
+	return diags
 }
 
 func updateVpcAttachmentBasicInfo(ctx context.Context, client *golangsdk.ServiceClient, d *schema.ResourceData) error {
@@ -253,6 +373,12 @@ func resourceVpcAttachmentUpdate(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
+	if d.HasChange("peer_assume_role") {
+		if err = acceptVpcAttachmentByPeer(ctx, client, d, cfg, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceVpcAttachmentRead(ctx, d, meta)
 }
 
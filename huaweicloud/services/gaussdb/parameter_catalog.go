@@ -0,0 +1,155 @@
+package gaussdb
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const gaussDbInstanceRestartResource = "huaweicloud_gaussdb_opengauss_instance_restart"
+
+// findDefaultParameterTemplateId returns the ID of the default parameter template of the engine version and the
+// instance mode.
+func findDefaultParameterTemplateId(client *golangsdk.ServiceClient, version, instanceMode string) (string, error) {
+	var (
+		httpUrl = "v3.1/{project_id}/configurations?limit=100"
+		offset  = 0
+	)
+	listPath := client.Endpoint + httpUrl
+	listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+	}
+	for {
+		listResp, err := client.Request("GET", fmt.Sprintf("%s&offset=%d", listPath, offset), &opt)
+		if err != nil {
+			return "", err
+		}
+		listRespBody, err := utils.FlattenResponse(listResp)
+		if err != nil {
+			return "", err
+		}
+
+		configurations := utils.PathSearch("configurations", listRespBody, make([]interface{}, 0)).([]interface{})
+		for _, v := range configurations {
+			if !utils.PathSearch("user_defined", v, true).(bool) &&
+				utils.PathSearch("datastore_version", v, "").(string) == version &&
+				strings.EqualFold(utils.PathSearch("ha_mode", v, "").(string), instanceMode) {
+				return utils.PathSearch("id", v, "").(string), nil
+			}
+		}
+		if len(configurations) < 100 {
+			break
+		}
+		offset += 100
+	}
+	return "", fmt.Errorf("unable to find the default parameter template of %s (%s)", version, instanceMode)
+}
+
+// getParameterCatalog returns the parameter catalog of the engine version and the instance mode, which is built from
+// the default parameter template.
+func getParameterCatalog(client *golangsdk.ServiceClient, version, instanceMode string) (common.ParameterCatalog,
+	error) {
+	return common.GetParameterCatalog(client, instanceMode, version, func() (common.ParameterCatalog, error) {
+		configId, err := findDefaultParameterTemplateId(client, version, instanceMode)
+		if err != nil {
+			return nil, err
+		}
+
+		getPath := client.Endpoint + "v3/{project_id}/configurations/{config_id}"
+		getPath = strings.ReplaceAll(getPath, "{project_id}", client.ProjectID)
+		getPath = strings.ReplaceAll(getPath, "{config_id}", configId)
+		getOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+		}
+		getResp, err := client.Request("GET", getPath, &getOpt)
+		if err != nil {
+			return nil, err
+		}
+		getRespBody, err := utils.FlattenResponse(getResp)
+		if err != nil {
+			return nil, err
+		}
+
+		catalog := make(common.ParameterCatalog)
+		parameters := utils.PathSearch("configuration_parameters", getRespBody, make([]interface{}, 0)).([]interface{})
+		for _, v := range parameters {
+			name := utils.PathSearch("name", v, "").(string)
+			catalog[name] = common.ParameterDefinition{
+				Name:            name,
+				Type:            utils.PathSearch("data_type", v, "").(string),
+				ValueRange:      utils.PathSearch("value_range", v, "").(string),
+				RestartRequired: utils.PathSearch("need_restart", v, false).(bool),
+				Readonly:        utils.PathSearch("readonly", v, false).(bool),
+			}
+		}
+		return catalog, nil
+	})
+}
+
+// checkParameterTemplateParameters validates the parameters of the new parameter template against the parameter
+// catalog, the validation is skipped if the catalog is unavailable. The parameters which require a restart are
+// reported as a warning after the template is created, see buildParameterTemplateRestartWarning.
+func checkParameterTemplateParameters(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("parameters") || !d.NewValueKnown("parameters") || !d.NewValueKnown("engine_version") ||
+		!d.NewValueKnown("instance_mode") {
+		return nil
+	}
+
+	params := make(map[string]string)
+	for _, v := range d.Get("parameters").(*schema.Set).List() {
+		param := v.(map[string]interface{})
+		params[param["name"].(string)] = param["value"].(string)
+	}
+	version := d.Get("engine_version").(string)
+	instanceMode := d.Get("instance_mode").(string)
+	if len(params) == 0 || version == "" || instanceMode == "" {
+		return nil
+	}
+
+	cfg := meta.(*config.Config)
+	region := d.Get("region").(string)
+	if region == "" {
+		region = cfg.Region
+	}
+	client, err := cfg.NewServiceClient("opengauss", region)
+	if err != nil {
+		return fmt.Errorf("error creating GaussDB client: %s", err)
+	}
+
+	catalog, err := getParameterCatalog(client, version, instanceMode)
+	if err != nil {
+		log.Printf("[WARN] unable to query the parameter catalog of %s (%s), skip validating the parameters: %s",
+			version, instanceMode, err)
+		return nil
+	}
+	if _, err := common.ValidateParameters(catalog, params); err != nil {
+		return fmt.Errorf("invalid parameters for %s (%s): %s", version, instanceMode, err)
+	}
+	return nil
+}
+
+// buildParameterTemplateRestartWarning returns the warning of the parameters which require a restart of the instances
+// to which the parameter template is applied.
+func buildParameterTemplateRestartWarning(d *schema.ResourceData) diag.Diagnostics {
+	restartNames := make([]string, 0)
+	for _, v := range d.Get("parameters").(*schema.Set).List() {
+		param := v.(map[string]interface{})
+		if param["need_restart"].(bool) {
+			restartNames = append(restartNames, param["name"].(string))
+		}
+	}
+	return common.BuildParameterRestartWarning(restartNames, gaussDbInstanceRestartResource)
+}
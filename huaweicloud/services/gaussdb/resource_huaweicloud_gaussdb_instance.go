@@ -40,6 +40,7 @@ var openGaussInstanceNonUpdatableParams = []string{"availability_zone", "vpc_id"
 
 // @API GaussDB GET /v3/{project_id}/instances
 // @API GaussDB POST /v3/{project_id}/instances
+// @API GaussDB GET /v3/{project_id}/instances/{instance_id}/restore-time
 // @API GaussDB GET /v3/{project_id}/jobs
 // @API GaussDB PUT /v3/{project_id}/instances/{instance_id}/alias
 // @API GaussDB PUT /v3/{project_id}/instances/{instance_id}/configurations
@@ -108,6 +109,8 @@ func ResourceGaussDbInstance() *schema.Resource {
 			},
 			config.MergeDefaultTags(),
 			config.FlexibleForceNew(openGaussInstanceNonUpdatableParams),
+			common.CheckManagedMasterPasswordRotation,
+			common.CheckRestoreFromTime("opengauss", "restore_time"),
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -130,10 +133,12 @@ func ResourceGaussDbInstance() *schema.Resource {
 				Required: true,
 			},
 			"password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "manage_master_password"},
 			},
+			"restore_from": common.RestoreFromSchema(nil),
 			"vpc_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -421,7 +426,9 @@ func ResourceGaussDbInstance() *schema.Resource {
 					return equal
 				},
 			},
-			"tags": common.TagsSchema(),
+			"manage_master_password":     common.ManageMasterPasswordSchema(),
+			"master_password_version_id": common.MasterPasswordVersionSchema(),
+			"tags":                       common.TagsSchema(),
 			"force_import": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -591,7 +598,15 @@ func resourceOpenGaussInstanceCreate(ctx context.Context, d *schema.ResourceData
 	createOpt := golangsdk.RequestOpts{
 		KeepResponseBody: true,
 	}
-	createOpt.JSONBody = utils.RemoveNil(buildCreateGaussDBOpenGaussBodyParams(d, region))
+	createBody := buildCreateGaussDBOpenGaussBodyParams(d, region)
+	if common.IsManagedMasterPassword(d) {
+		password, err := common.CreateManagedMasterPassword(d, cfg, region, d.Get("name").(string)+"-master-password")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		createBody["password"] = password
+	}
+	createOpt.JSONBody = utils.RemoveNil(createBody)
 	createResp, err := client.Request("POST", createPath, &createOpt)
 	if err != nil {
 		return diag.Errorf("error creating GaussDB OpenGauss instance: %s", err)
@@ -791,6 +806,7 @@ func buildCreateGaussDBOpenGaussBodyParams(d *schema.ResourceData, region string
 		"sharding_num":          utils.ValueIgnoreEmpty(d.Get("sharding_num")),
 		"coordinator_num":       utils.ValueIgnoreEmpty(d.Get("coordinator_num")),
 		"replica_num":           utils.ValueIgnoreEmpty(d.Get("replica_num")),
+		"restore_point":         common.BuildRestorePointBodyParams(d),
 	}
 	if v := d.Get("enable_force_switch").(bool); v {
 		bodyParams["enable_force_switch"] = v
@@ -1368,12 +1384,20 @@ func resourceOpenGaussInstanceUpdate(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
-	if d.HasChange("password") {
+	if d.HasChange("password") && !common.IsManagedMasterPassword(d) {
 		if err = updateInstancePassword(ctx, d, client); err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
+	err = common.UpdateManagedMasterPassword(d, cfg, region, d.Get("name").(string)+"-master-password",
+		func(password string) error {
+			return resetInstancePassword(ctx, d, client, password)
+		})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	if d.HasChange("port") {
 		if err = updateInstancePort(ctx, d, client); err != nil {
 			return diag.FromErr(err)
@@ -1603,6 +1627,22 @@ func updateInstancePassword(ctx context.Context, d *schema.ResourceData, client
 	return nil
 }
 
+// resetInstancePassword sets the password generated by the managed master password mode.
+func resetInstancePassword(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient,
+	password string) error {
+	_, err := updateGaussDbInstanceField(ctx, d, client, updateInstanceFieldParams{
+		httpUrl:          "v3/{project_id}/instances/{instance_id}/password",
+		httpMethod:       "POST",
+		pathParams:       map[string]string{"instance_id": d.Id()},
+		updateBodyParams: map[string]interface{}{"password": password},
+	})
+	if err != nil {
+		return fmt.Errorf("error resetting GaussDB instance password: %s", err)
+	}
+
+	return nil
+}
+
 func buildUpdateInstancePasswordBodyParams(d *schema.ResourceData) map[string]interface{} {
 	bodyParams := map[string]interface{}{
 		"password": d.Get("password"),
@@ -2561,6 +2601,9 @@ func resourceOpenGaussInstanceDelete(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
+	if err = common.DeleteManagedMasterPassword(d, cfg, region); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
@@ -29,6 +29,8 @@ func ResourceGaussDbParameterTemplate() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: checkParameterTemplateParameters,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -155,7 +157,11 @@ func resourceOpenGaussParameterTemplateCreate(ctx context.Context, d *schema.Res
 
 	d.SetId(id)
 
-	return resourceOpenGaussParameterTemplateRead(ctx, d, meta)
+	diags := resourceOpenGaussParameterTemplateRead(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, buildParameterTemplateRestartWarning(d)...)
 }
 
 func createParameterTemplate(d *schema.ResourceData, client *golangsdk.ServiceClient) (string, error) {
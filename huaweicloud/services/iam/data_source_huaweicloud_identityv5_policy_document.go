@@ -0,0 +1,365 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// @API IAM GET /v5/authorization-schemas/registered-services
+// @API IAM GET /v5/authorization-schemas/services/{service_code}
+func DataSourceV5PolicyDocument() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceV5PolicyDocumentRead,
+
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     v5PolicyDocumentVersion,
+				Description: `The version of the policy document.`,
+			},
+			"source_policy_documents": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+				Description: `The policy documents whose statements are merged into the policy document.`,
+			},
+			"override_policy_documents": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+				Description: `The policy documents whose statements override the statements with the same Sid.`,
+			},
+			"statement": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        v5PolicyDocumentStatementSchema(),
+				Description: `The statements of the policy document.`,
+			},
+			"validate_actions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: `Whether to validate the actions against the authorization schemas of the services.`,
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The canonical JSON of the policy document.`,
+			},
+		},
+	}
+}
+
+func v5PolicyDocumentStatementSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"sid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The ID of the statement.`,
+			},
+			"effect": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Allow",
+				ValidateFunc: validation.StringInSlice([]string{"Allow", "Deny"}, false),
+				Description:  `The effect of the statement.`,
+			},
+			"action": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The actions which the statement applies to.`,
+			},
+			"not_action": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The actions which the statement does not apply to.`,
+			},
+			"resource": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The resource URNs which the statement applies to.`,
+			},
+			"condition": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"operator": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: `The operator of the condition.`,
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: `The condition key.`,
+						},
+						"values": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: `The values of the condition key.`,
+						},
+					},
+				},
+				Description: `The conditions under which the statement takes effect.`,
+			},
+		},
+	}
+}
+
+func buildV5PolicyDocumentStatements(statements []interface{}) []*v5PolicyStatement {
+	result := make([]*v5PolicyStatement, 0, len(statements))
+	for _, v := range statements {
+		statement := v5PolicyStatement{
+			Sid:       utils.PathSearch("sid", v, "").(string),
+			Effect:    utils.PathSearch("effect", v, "").(string),
+			Action:    utils.ExpandToStringList(utils.PathSearch("action", v, make([]interface{}, 0)).([]interface{})),
+			NotAction: utils.ExpandToStringList(utils.PathSearch("not_action", v, make([]interface{}, 0)).([]interface{})),
+			Resource:  utils.ExpandToStringList(utils.PathSearch("resource", v, make([]interface{}, 0)).([]interface{})),
+		}
+
+		conditions := utils.PathSearch("condition", v, make([]interface{}, 0)).([]interface{})
+		if len(conditions) > 0 {
+			statement.Condition = make(map[string]map[string]v5PolicyStringList)
+		}
+		for _, condition := range conditions {
+			operator := utils.PathSearch("operator", condition, "").(string)
+			key := utils.PathSearch("key", condition, "").(string)
+			values := utils.ExpandToStringList(utils.PathSearch("values", condition, make([]interface{}, 0)).([]interface{}))
+			if statement.Condition[operator] == nil {
+				statement.Condition[operator] = make(map[string]v5PolicyStringList)
+			}
+			// The values of the same operator and key are merged.
+			statement.Condition[operator][key] = append(statement.Condition[operator][key], values...)
+		}
+		result = append(result, &statement)
+	}
+	return result
+}
+
+func parseV5PolicyDocuments(documents []interface{}, argName string) ([][]*v5PolicyStatement, error) {
+	result := make([][]*v5PolicyStatement, 0, len(documents))
+	for i, v := range documents {
+		document, err := parseV5PolicyDocument(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s (%d): %s", argName, i, err)
+		}
+		if err := checkV5PolicyDuplicateSids(document.Statement); err != nil {
+			return nil, fmt.Errorf("invalid %s (%d): %s", argName, i, err)
+		}
+		result = append(result, document.Statement)
+	}
+	return result, nil
+}
+
+// buildV5PolicyDocument merges the statements of the source documents, the statement blocks and the override
+// documents in order, the later statements replace the former statements with the same Sid.
+func buildV5PolicyDocument(d *schema.ResourceData) (*v5PolicyDocument, error) {
+	sources, err := parseV5PolicyDocuments(d.Get("source_policy_documents").([]interface{}), "source_policy_documents")
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := parseV5PolicyDocuments(d.Get("override_policy_documents").([]interface{}),
+		"override_policy_documents")
+	if err != nil {
+		return nil, err
+	}
+
+	document := v5PolicyDocument{
+		Version:   d.Get("version").(string),
+		Statement: make([]*v5PolicyStatement, 0),
+	}
+	for _, statements := range sources {
+		if err := checkV5PolicyDuplicateSids(append(document.Statement, statements...)); err != nil {
+			return nil, fmt.Errorf("invalid source_policy_documents: %s", err)
+		}
+		document.Statement = append(document.Statement, statements...)
+	}
+
+	statements := buildV5PolicyDocumentStatements(d.Get("statement").([]interface{}))
+	if err := checkV5PolicyDuplicateSids(statements); err != nil {
+		return nil, fmt.Errorf("invalid statement: %s", err)
+	}
+	document.Statement = mergeV5PolicyStatements(document.Statement, statements)
+
+	for _, statements := range overrides {
+		document.Statement = mergeV5PolicyStatements(document.Statement, statements)
+	}
+
+	if err := document.normalize(); err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+func listV5RegisteredServiceCodes(client *golangsdk.ServiceClient) (map[string]bool, error) {
+	var (
+		httpUrl = "v5/authorization-schemas/registered-services"
+		limit   = 200
+		marker  = ""
+		result  = make(map[string]bool)
+	)
+	listPath := client.Endpoint + fmt.Sprintf("%s?limit=%d", httpUrl, limit)
+	for {
+		reqOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+		}
+		r, err := client.Request("GET", listPath+buildV5RegisteredServicesQueryParams(marker), &reqOpt)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := utils.FlattenResponse(r)
+		if err != nil {
+			return nil, err
+		}
+
+		services := utils.PathSearch("service_codes", resp, make([]interface{}, 0)).([]interface{})
+		for _, v := range services {
+			result[strings.ToLower(v.(string))] = true
+		}
+		marker = utils.PathSearch("page_info.next_marker", resp, "").(string)
+		if len(services) < limit || marker == "" {
+			return result, nil
+		}
+	}
+}
+
+// listV5AuthorizationSchemaActions returns the names and the aliases of the actions supported by the service.
+func listV5AuthorizationSchemaActions(client *golangsdk.ServiceClient, serviceCode string) ([]string, error) {
+	getPath := client.Endpoint + "v5/authorization-schemas/services/{service_code}"
+	getPath = strings.ReplaceAll(getPath, "{service_code}", serviceCode)
+	getOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	resp, err := client.Request("GET", getPath, &getOpt)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := utils.FlattenResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	names := utils.PathSearch("actions[*].name", respBody, make([]interface{}, 0)).([]interface{})
+	aliases := utils.PathSearch("actions[*].aliases[]", respBody, make([]interface{}, 0)).([]interface{})
+	return utils.ExpandToStringList(append(names, aliases...)), nil
+}
+
+// validateV5PolicyActions checks whether the actions of the statements are supported. The service of each action must
+// be registered, and each action must match at least one action of the authorization schema of the service.
+func validateV5PolicyActions(client *golangsdk.ServiceClient, statements []*v5PolicyStatement) error {
+	actions := make([]string, 0)
+	for _, statement := range statements {
+		actions = append(actions, statement.Action...)
+		actions = append(actions, statement.NotAction...)
+	}
+
+	var (
+		serviceCodes  map[string]bool
+		schemaActions = make(map[string][]string)
+		mErr          *multierror.Error
+	)
+	for _, action := range v5PolicyStringList(actions).normalize() {
+		serviceCode, _, found := strings.Cut(strings.ToLower(action), ":")
+		if !found {
+			if action != "*" {
+				mErr = multierror.Append(mErr, fmt.Errorf("the format of the action (%s) is invalid, it must be "+
+					"'<service>:<resource_type>:<action>'", action))
+			}
+			continue
+		}
+		if strings.ContainsAny(serviceCode, "*?") {
+			continue
+		}
+
+		if serviceCodes == nil {
+			var err error
+			if serviceCodes, err = listV5RegisteredServiceCodes(client); err != nil {
+				return fmt.Errorf("error retrieving registered services: %s", err)
+			}
+		}
+		if !serviceCodes[serviceCode] {
+			mErr = multierror.Append(mErr, fmt.Errorf("the service (%s) of the action (%s) is not registered",
+				serviceCode, action))
+			continue
+		}
+
+		supported, ok := schemaActions[serviceCode]
+		if !ok {
+			var err error
+			if supported, err = listV5AuthorizationSchemaActions(client, serviceCode); err != nil {
+				return fmt.Errorf("error retrieving the authorization schema of the service (%s): %s", serviceCode, err)
+			}
+			schemaActions[serviceCode] = supported
+		}
+		if !matchAnyV5PolicyAction(action, supported) {
+			mErr = multierror.Append(mErr, fmt.Errorf("the action (%s) does not match any action of the service (%s)",
+				action, serviceCode))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+func matchAnyV5PolicyAction(pattern string, actions []string) bool {
+	for _, action := range actions {
+		if matchV5PolicyPattern(pattern, action) {
+			return true
+		}
+	}
+	return false
+}
+
+func dataSourceV5PolicyDocumentRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	document, err := buildV5PolicyDocument(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("validate_actions").(bool) {
+		cfg := meta.(*config.Config)
+		client, err := cfg.NewServiceClient("iam", cfg.GetRegion(d))
+		if err != nil {
+			return diag.Errorf("error creating IAM client: %s", err)
+		}
+		if err := validateV5PolicyActions(client, document.Statement); err != nil {
+			return diag.Errorf("error validating the actions of the policy document: %s", err)
+		}
+	}
+
+	content, err := document.String()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	randomId, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(randomId)
+
+	return diag.FromErr(d.Set("json", content))
+}
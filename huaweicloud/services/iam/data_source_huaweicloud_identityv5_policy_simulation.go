@@ -0,0 +1,434 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/organizations"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var v5PolicySimulationPrincipals = []string{"user_id", "group_id", "agency_id", "policy_documents"}
+
+// @API IAM GET /v5/users/{user_id}/attached-policies
+// @API IAM GET /v5/groups
+// @API IAM GET /v5/groups/{group_id}/attached-policies
+// @API IAM GET /v5/agencies/{agency_id}/attached-policies
+// @API IAM GET /v5/policies/{policy_id}/versions
+// @API Organizations GET /v1/organizations/policies/{policy_id}
+func DataSourceV5PolicySimulation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceV5PolicySimulationRead,
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"group_id", "agency_id"},
+				AtLeastOneOf:  v5PolicySimulationPrincipals,
+				Description:   `The ID of the user whose attached policies and group policies are evaluated.`,
+			},
+			"group_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"agency_id"},
+				Description:   `The ID of the user group whose attached policies are evaluated.`,
+			},
+			"agency_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The ID of the agency whose attached policies are evaluated.`,
+			},
+			"policy_documents": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+				Description: `The additional identity policy documents to be evaluated.`,
+			},
+			"organizations_policies": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"level": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: `The level of the organization hierarchy to which the policies are attached.`,
+						},
+						"policy_ids": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: `The IDs of the service control policies attached to the level.`,
+						},
+					},
+				},
+				Description: `The organization service control policies which limit the permissions, grouped by the levels.`,
+			},
+			"request": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        v5PolicySimulationRequestSchema(),
+				Description: `The requests to be evaluated.`,
+			},
+			"evaluated_policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The sources of the policies which are evaluated.`,
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        v5PolicySimulationResultSchema(),
+				Description: `The evaluation results of the requests.`,
+			},
+		},
+	}
+}
+
+func v5PolicySimulationRequestSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"action": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The action to be evaluated.`,
+			},
+			"resource": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "*",
+				Description: `The URN of the resource to be evaluated.`,
+			},
+			"context": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: `The condition key of the request context.`,
+						},
+						"values": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: `The values of the condition key.`,
+						},
+					},
+				},
+				Description: `The request context which is used to evaluate the conditions.`,
+			},
+		},
+	}
+}
+
+func v5PolicySimulationResultSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"action": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The evaluated action.`,
+			},
+			"resource": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The evaluated resource.`,
+			},
+			"decision": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The decision of the request.`,
+			},
+			"allowed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: `Whether the request is allowed.`,
+			},
+			"policy_source": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The source of the policy which contains the deciding statement.`,
+			},
+			"statement_index": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The index of the deciding statement in the policy.`,
+			},
+			"statement_sid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The Sid of the deciding statement.`,
+			},
+			"statement": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The deciding statement, in JSON format.`,
+			},
+		},
+	}
+}
+
+// getV5PolicyDefaultDocument returns the document JSON of the default version of the identity policy.
+func getV5PolicyDefaultDocument(client *golangsdk.ServiceClient, policyId string) (string, error) {
+	versions, err := listV5PolicyVersions(client, policyId)
+	if err != nil {
+		return "", err
+	}
+	document := utils.PathSearch("[?is_default]|[0].document", versions, "").(string)
+	if document == "" {
+		return "", fmt.Errorf("the default version of the policy is not found")
+	}
+	return document, nil
+}
+
+// listV5PrincipalAttachedPolicies returns the policies attached to the principal. The policies attached to the groups
+// of the user are also returned.
+func listV5PrincipalAttachedPolicies(client *golangsdk.ServiceClient, d *schema.ResourceData) ([]interface{}, error) {
+	if userId, ok := d.GetOk("user_id"); ok {
+		policies, err := listV5UserAttachedPolicies(client, userId.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving user (%s) attached policies: %s", userId, err)
+		}
+
+		// The groups are filtered by the user_id.
+		groups, err := listV5Groups(client, d)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving the groups of the user (%s): %s", userId, err)
+		}
+		for _, group := range groups {
+			groupId := utils.PathSearch("group_id", group, "").(string)
+			groupPolicies, err := listV5GroupAttachedPolicies(client, groupId)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving group (%s) attached policies: %s", groupId, err)
+			}
+			policies = append(policies, groupPolicies...)
+		}
+		return policies, nil
+	}
+
+	if groupId, ok := d.GetOk("group_id"); ok {
+		policies, err := listV5GroupAttachedPolicies(client, groupId.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving group (%s) attached policies: %s", groupId, err)
+		}
+		return policies, nil
+	}
+
+	if agencyId, ok := d.GetOk("agency_id"); ok {
+		policies, err := listV5AgencyAttachedPolicies(client, agencyId.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving agency (%s) attached policies: %s", agencyId, err)
+		}
+		return policies, nil
+	}
+	return nil, nil
+}
+
+// buildV5IdentityEvaluationPolicies returns the identity policies to be evaluated, including the policies attached to
+// the principal and the policy documents.
+func buildV5IdentityEvaluationPolicies(cfg *config.Config, d *schema.ResourceData) ([]*v5EvaluationPolicy, error) {
+	client, err := cfg.NewServiceClient("iam", cfg.GetRegion(d))
+	if err != nil {
+		return nil, fmt.Errorf("error creating IAM client: %s", err)
+	}
+
+	attachedPolicies, err := listV5PrincipalAttachedPolicies(client, d)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*v5EvaluationPolicy, 0, len(attachedPolicies))
+	evaluated := make(map[string]bool)
+	for _, policy := range attachedPolicies {
+		policyId := utils.PathSearch("policy_id", policy, "").(string)
+		if evaluated[policyId] {
+			continue
+		}
+		evaluated[policyId] = true
+
+		policyName := utils.PathSearch("policy_name", policy, "").(string)
+		content, err := getV5PolicyDefaultDocument(client, policyId)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving the document of the policy (%s): %s", policyName, err)
+		}
+		// Skipping the policy could turn an explicit deny into an allow, so the simulation fails instead.
+		document, err := parseV5PolicyDocument(content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the document of the attached policy (%s): %s", policyName, err)
+		}
+		result = append(result, &v5EvaluationPolicy{
+			Source:   "identity:" + policyName,
+			Document: document,
+		})
+	}
+
+	for i, v := range d.Get("policy_documents").([]interface{}) {
+		document, err := parseV5PolicyDocument(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy_documents (%d): %s", i, err)
+		}
+		result = append(result, &v5EvaluationPolicy{
+			Source:   fmt.Sprintf("document:%d", i),
+			Document: document,
+		})
+	}
+	return result, nil
+}
+
+// buildV5OrganizationsEvaluationLevels returns the organization policies grouped by the levels of the organization
+// hierarchy, the policies of the same ID are retrieved once.
+func buildV5OrganizationsEvaluationLevels(cfg *config.Config, d *schema.ResourceData) ([]*v5EvaluationLevel, error) {
+	levels := d.Get("organizations_policies").([]interface{})
+	if len(levels) == 0 {
+		return nil, nil
+	}
+
+	client, err := cfg.NewServiceClient("organizations", cfg.GetRegion(d))
+	if err != nil {
+		return nil, fmt.Errorf("error creating Organizations client: %s", err)
+	}
+
+	policies := make(map[string]*v5EvaluationPolicy)
+	result := make([]*v5EvaluationLevel, 0, len(levels))
+	for _, v := range levels {
+		level := &v5EvaluationLevel{
+			Level: utils.PathSearch("level", v, "").(string),
+		}
+		policyIds := utils.ExpandToStringList(utils.PathSearch("policy_ids", v, make([]interface{}, 0)).([]interface{}))
+		for _, policyId := range policyIds {
+			if policy, ok := policies[policyId]; ok {
+				level.Policies = append(level.Policies, policy)
+				continue
+			}
+
+			respBody, err := organizations.GetPolicyById(client, policyId)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving organizations policy (%s): %s", policyId, err)
+			}
+			document, err := parseV5PolicyDocument(utils.PathSearch("policy.content", respBody, "").(string))
+			if err != nil {
+				return nil, fmt.Errorf("invalid content of the organizations policy (%s): %s", policyId, err)
+			}
+			policies[policyId] = &v5EvaluationPolicy{
+				Source:   "organizations:" + policyId,
+				Document: document,
+			}
+			level.Policies = append(level.Policies, policies[policyId])
+		}
+		result = append(result, level)
+	}
+	return result, nil
+}
+
+func buildV5EvaluationRequests(requests []interface{}) []*v5EvaluationRequest {
+	result := make([]*v5EvaluationRequest, 0, len(requests))
+	for _, v := range requests {
+		request := v5EvaluationRequest{
+			Action:   utils.PathSearch("action", v, "").(string),
+			Resource: utils.PathSearch("resource", v, "").(string),
+			Context:  make(map[string][]string),
+		}
+		for _, c := range utils.PathSearch("context", v, make([]interface{}, 0)).([]interface{}) {
+			key := utils.PathSearch("key", c, "").(string)
+			values := utils.ExpandToStringList(utils.PathSearch("values", c, make([]interface{}, 0)).([]interface{}))
+			request.Context[key] = append(request.Context[key], values...)
+		}
+		result = append(result, &request)
+	}
+	return result
+}
+
+func flattenV5EvaluationResult(request *v5EvaluationRequest,
+	result *v5EvaluationResult) (map[string]interface{}, error) {
+	flattened := map[string]interface{}{
+		"action":          request.Action,
+		"resource":        request.Resource,
+		"decision":        result.Decision,
+		"allowed":         result.Decision == v5PolicyDecisionAllowed,
+		"policy_source":   result.Source,
+		"statement_index": result.StatementIndex,
+	}
+	if result.Statement != nil {
+		statement, err := json.Marshal(result.Statement)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling the deciding statement: %s", err)
+		}
+		flattened["statement_sid"] = result.Statement.Sid
+		flattened["statement"] = string(statement)
+	}
+	return flattened, nil
+}
+
+func dataSourceV5PolicySimulationRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+
+	identityPolicies, err := buildV5IdentityEvaluationPolicies(cfg, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	orgLevels, err := buildV5OrganizationsEvaluationLevels(cfg, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	evaluatedPolicies := make([]string, 0, len(identityPolicies))
+	for _, policy := range identityPolicies {
+		evaluatedPolicies = append(evaluatedPolicies, policy.Source)
+	}
+	evaluated := make(map[string]bool)
+	for _, level := range orgLevels {
+		for _, policy := range level.Policies {
+			if !evaluated[policy.Source] {
+				evaluated[policy.Source] = true
+				evaluatedPolicies = append(evaluatedPolicies, policy.Source)
+			}
+		}
+	}
+
+	requests := buildV5EvaluationRequests(d.Get("request").([]interface{}))
+	results := make([]map[string]interface{}, 0, len(requests))
+	for _, request := range requests {
+		result, err := evaluateV5Policies(identityPolicies, orgLevels, request)
+		if err != nil {
+			return diag.Errorf("error evaluating the request (%s): %s", request.Action, err)
+		}
+		flattened, err := flattenV5EvaluationResult(request, result)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		results = append(results, flattened)
+	}
+
+	randomId, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(randomId)
+
+	mErr := multierror.Append(nil,
+		d.Set("evaluated_policies", evaluatedPolicies),
+		d.Set("results", results),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting IAM policy simulation fields: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package iam
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const v5PolicyDocumentVersion = "5.0"
+
+// v5PolicyDocument is the document of the IAM v5 identity policy.
+type v5PolicyDocument struct {
+	Version   string               `json:"Version"`
+	Statement []*v5PolicyStatement `json:"Statement"`
+}
+
+// v5PolicyStatement is the statement of the IAM v5 policy document, the order of the fields is the order of the
+// elements in the canonical JSON.
+type v5PolicyStatement struct {
+	Sid       string                                   `json:"Sid,omitempty"`
+	Effect    string                                   `json:"Effect"`
+	Action    v5PolicyStringList                       `json:"Action,omitempty"`
+	NotAction v5PolicyStringList                       `json:"NotAction,omitempty"`
+	Resource  v5PolicyStringList                       `json:"Resource,omitempty"`
+	Condition map[string]map[string]v5PolicyStringList `json:"Condition,omitempty"`
+}
+
+// v5PolicyStringList is the element of the policy document which can be a single string or a list of strings.
+type v5PolicyStringList []string
+
+func (l *v5PolicyStringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = v5PolicyStringList{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("the value must be a string or a list of strings: %s", string(data))
+	}
+	*l = list
+	return nil
+}
+
+// normalize sorts the values and removes the duplicate values, the order of the values does not affect the policy.
+func (l v5PolicyStringList) normalize() v5PolicyStringList {
+	if len(l) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(l))
+	result := make(v5PolicyStringList, 0, len(l))
+	for _, v := range l {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// parseV5PolicyDocument parses the policy document JSON, the elements which are not supported are rejected.
+func parseV5PolicyDocument(content string) (*v5PolicyDocument, error) {
+	var document v5PolicyDocument
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("error parsing the policy document: %s", err)
+	}
+	if err := document.normalize(); err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (d *v5PolicyDocument) normalize() error {
+	if d.Version == "" {
+		d.Version = v5PolicyDocumentVersion
+	}
+
+	for i, statement := range d.Statement {
+		if statement == nil {
+			return fmt.Errorf("the statement (%d) of the policy document is empty", i)
+		}
+		if err := statement.normalize(); err != nil {
+			if statement.Sid != "" {
+				return fmt.Errorf("invalid statement (%s): %s", statement.Sid, err)
+			}
+			return fmt.Errorf("invalid statement (%d): %s", i, err)
+		}
+	}
+	return nil
+}
+
+func (s *v5PolicyStatement) normalize() error {
+	switch strings.ToLower(s.Effect) {
+	case "allow":
+		s.Effect = "Allow"
+	case "deny":
+		s.Effect = "Deny"
+	default:
+		return fmt.Errorf("the effect must be Allow or Deny, but got %q", s.Effect)
+	}
+
+	s.Action = s.Action.normalize()
+	s.NotAction = s.NotAction.normalize()
+	s.Resource = s.Resource.normalize()
+	if (len(s.Action) == 0) == (len(s.NotAction) == 0) {
+		return fmt.Errorf("exactly one of Action and NotAction must be specified")
+	}
+
+	for operator, keys := range s.Condition {
+		if len(keys) == 0 {
+			delete(s.Condition, operator)
+			continue
+		}
+		for key, values := range keys {
+			keys[key] = values.normalize()
+		}
+	}
+	if len(s.Condition) == 0 {
+		s.Condition = nil
+	}
+	return nil
+}
+
+// String returns the canonical JSON of the policy document, the keys of the conditions are sorted by the marshaling.
+func (d *v5PolicyDocument) String() (string, error) {
+	if d.Statement == nil {
+		d.Statement = make([]*v5PolicyStatement, 0)
+	}
+	content, err := json.Marshal(d)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling the policy document: %s", err)
+	}
+	return string(content), nil
+}
+
+// mergeV5PolicyStatements merges the statements into the base statements. A statement replaces the base statement
+// with the same non-empty Sid, the other statements are appended.
+func mergeV5PolicyStatements(base, statements []*v5PolicyStatement) []*v5PolicyStatement {
+	result := append(make([]*v5PolicyStatement, 0, len(base)+len(statements)), base...)
+	for _, statement := range statements {
+		replaced := false
+		if statement.Sid != "" {
+			for i, v := range result {
+				if v.Sid == statement.Sid {
+					result[i] = statement
+					replaced = true
+					break
+				}
+			}
+		}
+		if !replaced {
+			result = append(result, statement)
+		}
+	}
+	return result
+}
+
+// checkV5PolicyDuplicateSids checks whether the statements contain duplicate Sids.
+func checkV5PolicyDuplicateSids(statements []*v5PolicyStatement) error {
+	sids := make(map[string]bool)
+	for _, statement := range statements {
+		if statement.Sid == "" {
+			continue
+		}
+		if sids[statement.Sid] {
+			return fmt.Errorf("found duplicate Sid (%s)", statement.Sid)
+		}
+		sids[statement.Sid] = true
+	}
+	return nil
+}
+
+// matchV5PolicyPattern checks whether the value matches the pattern case-insensitively, the asterisk (*) in the
+// pattern matches any sequence of characters and the question mark (?) matches any single character.
+func matchV5PolicyPattern(pattern, value string) bool {
+	return matchV5PolicyCasePattern(strings.ToLower(pattern), strings.ToLower(value))
+}
+
+// matchV5PolicyCasePattern is the case-sensitive version of matchV5PolicyPattern.
+func matchV5PolicyCasePattern(pattern, value string) bool {
+	p, v := []rune(pattern), []rune(value)
+	pIdx, vIdx, starIdx, matchIdx := 0, 0, -1, 0
+	for vIdx < len(v) {
+		switch {
+		case pIdx < len(p) && (p[pIdx] == '?' || p[pIdx] == v[vIdx]):
+			pIdx++
+			vIdx++
+		case pIdx < len(p) && p[pIdx] == '*':
+			starIdx, matchIdx = pIdx, vIdx
+			pIdx++
+		case starIdx != -1:
+			pIdx = starIdx + 1
+			matchIdx++
+			vIdx = matchIdx
+		default:
+			return false
+		}
+	}
+	for pIdx < len(p) && p[pIdx] == '*' {
+		pIdx++
+	}
+	return pIdx == len(p)
+}
+
+// matchAnyV5PolicyPattern checks whether the value matches any of the patterns.
+func matchAnyV5PolicyPattern(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matchV5PolicyPattern(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package iam
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func TestV5PolicyDocumentFunc_mergeV5PolicyStatements(t *testing.T) {
+	var (
+		allowEcs  = &v5PolicyStatement{Sid: "AllowEcs", Effect: "Allow", Action: v5PolicyStringList{"ecs:*:*"}}
+		allowObs  = &v5PolicyStatement{Sid: "AllowObs", Effect: "Allow", Action: v5PolicyStringList{"obs:*:*"}}
+		denyEcs   = &v5PolicyStatement{Sid: "AllowEcs", Effect: "Deny", Action: v5PolicyStringList{"ecs:*:delete*"}}
+		noSidVpc  = &v5PolicyStatement{Effect: "Allow", Action: v5PolicyStringList{"vpc:*:get*"}}
+		noSidVpc2 = &v5PolicyStatement{Effect: "Deny", Action: v5PolicyStringList{"vpc:*:delete*"}}
+	)
+
+	testCases := []struct {
+		name       string
+		base       []*v5PolicyStatement
+		statements []*v5PolicyStatement
+		expected   []*v5PolicyStatement
+	}{
+		{
+			name:       "empty base",
+			base:       nil,
+			statements: []*v5PolicyStatement{allowEcs},
+			expected:   []*v5PolicyStatement{allowEcs},
+		},
+		{
+			name:       "empty statements",
+			base:       []*v5PolicyStatement{allowEcs, allowObs},
+			statements: nil,
+			expected:   []*v5PolicyStatement{allowEcs, allowObs},
+		},
+		{
+			name:       "replace the statement with the same Sid in place",
+			base:       []*v5PolicyStatement{allowEcs, allowObs},
+			statements: []*v5PolicyStatement{denyEcs},
+			expected:   []*v5PolicyStatement{denyEcs, allowObs},
+		},
+		{
+			name:       "append the statement with a new Sid",
+			base:       []*v5PolicyStatement{allowEcs},
+			statements: []*v5PolicyStatement{allowObs},
+			expected:   []*v5PolicyStatement{allowEcs, allowObs},
+		},
+		{
+			name:       "always append the statements without Sid",
+			base:       []*v5PolicyStatement{noSidVpc},
+			statements: []*v5PolicyStatement{noSidVpc2},
+			expected:   []*v5PolicyStatement{noSidVpc, noSidVpc2},
+		},
+	}
+
+	for _, tc := range testCases {
+		base := append([]*v5PolicyStatement(nil), tc.base...)
+		result := mergeV5PolicyStatements(tc.base, tc.statements)
+		if !reflect.DeepEqual(result, tc.expected) {
+			t.Fatalf("[%s] The merged statements are not as expected, want %s, but got %s", tc.name,
+				utils.Green(utils.JsonToString(tc.expected)), utils.Yellow(utils.JsonToString(result)))
+		}
+		// The base statements must not be modified by the merge.
+		if !reflect.DeepEqual(tc.base, base) {
+			t.Fatalf("[%s] The base statements are modified, want %s, but got %s", tc.name,
+				utils.Green(utils.JsonToString(base)), utils.Yellow(utils.JsonToString(tc.base)))
+		}
+	}
+}
+
+func TestV5PolicyDocumentFunc_normalizeStringList(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    v5PolicyStringList
+		expected v5PolicyStringList
+	}{
+		{
+			name:     "nil list",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "empty list",
+			input:    v5PolicyStringList{},
+			expected: nil,
+		},
+		{
+			name:     "sort the values",
+			input:    v5PolicyStringList{"obs:*:*", "ecs:*:*"},
+			expected: v5PolicyStringList{"ecs:*:*", "obs:*:*"},
+		},
+		{
+			name:     "remove the duplicate values",
+			input:    v5PolicyStringList{"ecs:*:*", "obs:*:*", "ecs:*:*"},
+			expected: v5PolicyStringList{"ecs:*:*", "obs:*:*"},
+		},
+	}
+
+	for _, tc := range testCases {
+		result := tc.input.normalize()
+		if !reflect.DeepEqual(result, tc.expected) {
+			t.Fatalf("[%s] The normalized values are not as expected, want %s, but got %s", tc.name,
+				utils.Green(tc.expected), utils.Yellow(result))
+		}
+	}
+}
+
+func TestV5PolicyDocumentFunc_normalizeStatement(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     *v5PolicyStatement
+		expected  *v5PolicyStatement
+		expectErr bool
+	}{
+		{
+			name: "normalize the effect and the values",
+			input: &v5PolicyStatement{
+				Effect: "allow",
+				Action: v5PolicyStringList{"obs:*:*", "ecs:*:*", "obs:*:*"},
+				Condition: map[string]map[string]v5PolicyStringList{
+					"StringEquals": {"g:DomainName": {"b", "a"}},
+					"Bool":         {},
+				},
+			},
+			expected: &v5PolicyStatement{
+				Effect: "Allow",
+				Action: v5PolicyStringList{"ecs:*:*", "obs:*:*"},
+				Condition: map[string]map[string]v5PolicyStringList{
+					"StringEquals": {"g:DomainName": {"a", "b"}},
+				},
+			},
+		},
+		{
+			name: "remove the empty conditions",
+			input: &v5PolicyStatement{
+				Effect:    "DENY",
+				NotAction: v5PolicyStringList{"iam:*:*"},
+				Condition: map[string]map[string]v5PolicyStringList{
+					"Bool": {},
+				},
+			},
+			expected: &v5PolicyStatement{
+				Effect:    "Deny",
+				NotAction: v5PolicyStringList{"iam:*:*"},
+			},
+		},
+		{
+			name:      "invalid effect",
+			input:     &v5PolicyStatement{Effect: "Permit", Action: v5PolicyStringList{"ecs:*:*"}},
+			expectErr: true,
+		},
+		{
+			name:      "neither Action nor NotAction",
+			input:     &v5PolicyStatement{Effect: "Allow"},
+			expectErr: true,
+		},
+		{
+			name: "both Action and NotAction",
+			input: &v5PolicyStatement{
+				Effect:    "Allow",
+				Action:    v5PolicyStringList{"ecs:*:*"},
+				NotAction: v5PolicyStringList{"iam:*:*"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.input.normalize()
+		if tc.expectErr {
+			if err == nil {
+				t.Fatalf("[%s] An error is expected, but got nil", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("[%s] Unexpected error is returned: %s", tc.name, err)
+		}
+		if !reflect.DeepEqual(tc.input, tc.expected) {
+			t.Fatalf("[%s] The normalized statement is not as expected, want %s, but got %s", tc.name,
+				utils.Green(utils.JsonToString(tc.expected)), utils.Yellow(utils.JsonToString(tc.input)))
+		}
+	}
+}
+
+func TestV5PolicyDocumentFunc_matchV5PolicyPattern(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		value    string
+		expected bool
+	}{
+		{pattern: "ecs:servers:list", value: "ecs:servers:list", expected: true},
+		{pattern: "ecs:servers:list", value: "ECS:Servers:List", expected: true},
+		{pattern: "ecs:servers:list", value: "ecs:servers:get", expected: false},
+		{pattern: "*", value: "", expected: true},
+		{pattern: "*", value: "ecs:servers:list", expected: true},
+		{pattern: "ecs:*", value: "ecs:servers:list", expected: true},
+		{pattern: "ecs:*", value: "evs:volumes:list", expected: false},
+		{pattern: "ecs:*:list*", value: "ecs:servers:listServers", expected: true},
+		{pattern: "ecs:*:list*", value: "ecs:servers:getServer", expected: false},
+		{pattern: "*:*:delete", value: "ecs:servers:delete", expected: true},
+		{pattern: "ecs:server?:list", value: "ecs:servers:list", expected: true},
+		{pattern: "ecs:server?:list", value: "ecs:server:list", expected: false},
+		{pattern: "a*b*c", value: "abbbc", expected: true},
+		{pattern: "a*b*c", value: "acb", expected: false},
+		{pattern: "", value: "", expected: true},
+		{pattern: "", value: "ecs", expected: false},
+	}
+
+	for _, tc := range testCases {
+		if result := matchV5PolicyPattern(tc.pattern, tc.value); result != tc.expected {
+			t.Fatalf("The match result of the pattern (%s) and the value (%s) is not as expected, want %s, but got %s",
+				tc.pattern, tc.value, utils.Green(tc.expected), utils.Yellow(result))
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package iam
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// The request is allowed by an identity policy and is not denied by any policy.
+	v5PolicyDecisionAllowed = "allowed"
+	// The request is denied by a Deny statement of an identity policy or an organization policy.
+	v5PolicyDecisionExplicitDeny = "explicit_deny"
+	// The request is not allowed by any identity policy, or not allowed by the organization policies of a level.
+	v5PolicyDecisionImplicitDeny = "implicit_deny"
+)
+
+// v5EvaluationPolicy is the policy document which takes part in the evaluation.
+type v5EvaluationPolicy struct {
+	// The source of the policy, such as identity:<policy_name>, document:<index> or organizations:<policy_id>.
+	Source   string
+	Document *v5PolicyDocument
+}
+
+// v5EvaluationLevel is the organization policies attached to one level of the organization hierarchy, such as the
+// root, an organizational unit or the account.
+type v5EvaluationLevel struct {
+	Level    string
+	Policies []*v5EvaluationPolicy
+}
+
+// v5EvaluationRequest is the request to be evaluated, the keys of the context are case-insensitive.
+type v5EvaluationRequest struct {
+	Action   string
+	Resource string
+	Context  map[string][]string
+}
+
+// v5EvaluationResult is the decision of the request and the statement which decides it.
+type v5EvaluationResult struct {
+	Decision       string
+	Source         string
+	StatementIndex int
+	Statement      *v5PolicyStatement
+}
+
+// evaluateV5Policies evaluates the request with the identity policies and the organization policies. An explicit deny
+// in any policy overrides any allow. The organization policies are grouped by the levels of the organization hierarchy,
+// and the request must be allowed by at least one statement of each level.
+func evaluateV5Policies(identityPolicies []*v5EvaluationPolicy, orgLevels []*v5EvaluationLevel,
+	request *v5EvaluationRequest) (*v5EvaluationResult, error) {
+	identityAllow, identityDeny, err := findV5MatchedStatements(identityPolicies, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		orgDeny         *v5EvaluationResult
+		notAllowedLevel *v5EvaluationLevel
+	)
+	for _, level := range orgLevels {
+		levelAllow, levelDeny, err := findV5MatchedStatements(level.Policies, request)
+		if err != nil {
+			return nil, err
+		}
+		if levelDeny != nil && orgDeny == nil {
+			orgDeny = levelDeny
+		}
+		if levelAllow == nil && notAllowedLevel == nil {
+			notAllowedLevel = level
+		}
+	}
+
+	switch {
+	case orgDeny != nil:
+		orgDeny.Decision = v5PolicyDecisionExplicitDeny
+		return orgDeny, nil
+	case identityDeny != nil:
+		identityDeny.Decision = v5PolicyDecisionExplicitDeny
+		return identityDeny, nil
+	case notAllowedLevel != nil:
+		return &v5EvaluationResult{
+			Decision:       v5PolicyDecisionImplicitDeny,
+			Source:         "organizations_level:" + notAllowedLevel.Level,
+			StatementIndex: -1,
+		}, nil
+	case identityAllow != nil:
+		identityAllow.Decision = v5PolicyDecisionAllowed
+		return identityAllow, nil
+	}
+	return &v5EvaluationResult{Decision: v5PolicyDecisionImplicitDeny, StatementIndex: -1}, nil
+}
+
+// findV5MatchedStatements returns the first matched Allow statement and the first matched Deny statement.
+func findV5MatchedStatements(policies []*v5EvaluationPolicy,
+	request *v5EvaluationRequest) (allow, deny *v5EvaluationResult, err error) {
+	for _, policy := range policies {
+		for i, statement := range policy.Document.Statement {
+			matched, err := matchV5PolicyStatement(statement, request)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error evaluating the statement (%d) of the policy (%s): %s",
+					i, policy.Source, err)
+			}
+			if !matched {
+				continue
+			}
+
+			result := &v5EvaluationResult{
+				Source:         policy.Source,
+				StatementIndex: i,
+				Statement:      statement,
+			}
+			if statement.Effect == "Deny" && deny == nil {
+				deny = result
+			}
+			if statement.Effect == "Allow" && allow == nil {
+				allow = result
+			}
+		}
+	}
+	return allow, deny, nil
+}
+
+func matchV5PolicyStatement(statement *v5PolicyStatement, request *v5EvaluationRequest) (bool, error) {
+	if len(statement.Action) > 0 && !matchAnyV5PolicyPattern(statement.Action, request.Action) {
+		return false, nil
+	}
+	if len(statement.NotAction) > 0 && matchAnyV5PolicyPattern(statement.NotAction, request.Action) {
+		return false, nil
+	}
+	// The statement without resources applies to all resources.
+	if len(statement.Resource) > 0 && !matchAnyV5PolicyPattern(statement.Resource, request.Resource) {
+		return false, nil
+	}
+
+	for operator, keys := range statement.Condition {
+		for key, values := range keys {
+			matched, err := evaluateV5PolicyCondition(operator, values, lookupV5RequestContext(request, key))
+			if err != nil {
+				return false, err
+			}
+			if !matched {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func lookupV5RequestContext(request *v5EvaluationRequest, key string) []string {
+	for k, v := range request.Context {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return nil
+}
+
+// The comparison function of each condition operator, which reports whether the context value matches the condition
+// value. The negated operators are mapped to the positive operators.
+var v5PolicyConditionComparators = map[string]func(contextValue, conditionValue string) (bool, error){
+	"StringEquals": func(c, v string) (bool, error) { return c == v, nil },
+	"StringEqualsIgnoreCase": func(c, v string) (bool, error) {
+		return strings.EqualFold(c, v), nil
+	},
+	"StringLike":      func(c, v string) (bool, error) { return matchV5PolicyCasePattern(v, c), nil },
+	"StringStartWith": func(c, v string) (bool, error) { return strings.HasPrefix(c, v), nil },
+	"StringEndWith":   func(c, v string) (bool, error) { return strings.HasSuffix(c, v), nil },
+	"NumberEquals":    compareV5PolicyNumbers(func(c, v float64) bool { return c == v }),
+	"NumberLessThan":  compareV5PolicyNumbers(func(c, v float64) bool { return c < v }),
+	"NumberLessThanEquals": compareV5PolicyNumbers(func(c, v float64) bool {
+		return c <= v
+	}),
+	"NumberGreaterThan": compareV5PolicyNumbers(func(c, v float64) bool { return c > v }),
+	"NumberGreaterThanEquals": compareV5PolicyNumbers(func(c, v float64) bool {
+		return c >= v
+	}),
+	"DateEquals":            compareV5PolicyDates(func(c, v time.Time) bool { return c.Equal(v) }),
+	"DateLessThan":          compareV5PolicyDates(func(c, v time.Time) bool { return c.Before(v) }),
+	"DateLessThanEquals":    compareV5PolicyDates(func(c, v time.Time) bool { return !c.After(v) }),
+	"DateGreaterThan":       compareV5PolicyDates(func(c, v time.Time) bool { return c.After(v) }),
+	"DateGreaterThanEquals": compareV5PolicyDates(func(c, v time.Time) bool { return !c.Before(v) }),
+	"Bool":                  func(c, v string) (bool, error) { return strings.EqualFold(c, v), nil },
+	"IpAddress":             matchV5PolicyIpAddress,
+}
+
+// The negated operators and the corresponding positive operators.
+var v5PolicyNegatedConditionOperators = map[string]string{
+	"StringNotEquals":           "StringEquals",
+	"StringNotEqualsIgnoreCase": "StringEqualsIgnoreCase",
+	"StringNotLike":             "StringLike",
+	"StringNotStartWith":        "StringStartWith",
+	"StringNotEndWith":          "StringEndWith",
+	"NumberNotEquals":           "NumberEquals",
+	"DateNotEquals":             "DateEquals",
+	"NotIpAddress":              "IpAddress",
+}
+
+// evaluateV5PolicyCondition evaluates the condition operator with the values of the request context key.
+// The operator can be prefixed with ForAllValues: or ForAnyValue: to compare multi-valued keys, and can be suffixed
+// with IfExists to ignore the missing keys.
+func evaluateV5PolicyCondition(operator string, conditionValues, contextValues []string) (bool, error) {
+	baseOperator := operator
+	setOperator := ""
+	if prefix, rest, found := strings.Cut(baseOperator, ":"); found {
+		setOperator, baseOperator = prefix, rest
+	}
+	ifExists := strings.HasSuffix(baseOperator, "IfExists")
+	baseOperator = strings.TrimSuffix(baseOperator, "IfExists")
+
+	if baseOperator == "Null" {
+		if len(conditionValues) != 1 {
+			return false, fmt.Errorf("the Null operator requires exactly one value")
+		}
+		return strings.EqualFold(conditionValues[0], "true") == (len(contextValues) == 0), nil
+	}
+
+	negated := false
+	if positive, ok := v5PolicyNegatedConditionOperators[baseOperator]; ok {
+		negated, baseOperator = true, positive
+	}
+	comparator, ok := v5PolicyConditionComparators[baseOperator]
+	if !ok {
+		return false, fmt.Errorf("unsupported condition operator: %s", operator)
+	}
+
+	if len(contextValues) == 0 {
+		switch {
+		case ifExists, setOperator == "ForAllValues":
+			return true, nil
+		case setOperator == "ForAnyValue":
+			return false, nil
+		}
+		// The negated operator is satisfied if the key is missing.
+		return negated, nil
+	}
+
+	// Each result reports whether the context value satisfies the (negated) operator.
+	results := make([]bool, 0, len(contextValues))
+	for _, contextValue := range contextValues {
+		matched := false
+		for _, conditionValue := range conditionValues {
+			m, err := comparator(contextValue, conditionValue)
+			if err != nil {
+				return false, fmt.Errorf("invalid value of the condition operator (%s): %s", operator, err)
+			}
+			if m {
+				matched = true
+				break
+			}
+		}
+		results = append(results, matched != negated)
+	}
+
+	switch setOperator {
+	case "ForAllValues":
+		return allV5PolicyResults(results, true), nil
+	case "ForAnyValue":
+		return !allV5PolicyResults(results, false), nil
+	case "":
+		if negated {
+			return allV5PolicyResults(results, true), nil
+		}
+		return !allV5PolicyResults(results, false), nil
+	}
+	return false, fmt.Errorf("unsupported condition operator: %s", operator)
+}
+
+func allV5PolicyResults(results []bool, expected bool) bool {
+	for _, result := range results {
+		if result != expected {
+			return false
+		}
+	}
+	return true
+}
+
+func compareV5PolicyNumbers(compare func(c, v float64) bool) func(string, string) (bool, error) {
+	return func(contextValue, conditionValue string) (bool, error) {
+		c, err := strconv.ParseFloat(contextValue, 64)
+		if err != nil {
+			// The context value which is not a number does not match.
+			return false, nil
+		}
+		v, err := strconv.ParseFloat(conditionValue, 64)
+		if err != nil {
+			return false, fmt.Errorf("%q is not a number", conditionValue)
+		}
+		return compare(c, v), nil
+	}
+}
+
+func parseV5PolicyDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither in RFC3339 format nor an epoch time", value)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+func compareV5PolicyDates(compare func(c, v time.Time) bool) func(string, string) (bool, error) {
+	return func(contextValue, conditionValue string) (bool, error) {
+		c, err := parseV5PolicyDate(contextValue)
+		if err != nil {
+			return false, nil
+		}
+		v, err := parseV5PolicyDate(conditionValue)
+		if err != nil {
+			return false, err
+		}
+		return compare(c, v), nil
+	}
+}
+
+func matchV5PolicyIpAddress(contextValue, conditionValue string) (bool, error) {
+	if !strings.Contains(conditionValue, "/") {
+		conditionValue += "/32"
+		if strings.Contains(conditionValue, ":") {
+			conditionValue = strings.TrimSuffix(conditionValue, "/32") + "/128"
+		}
+	}
+	_, network, err := net.ParseCIDR(conditionValue)
+	if err != nil {
+		return false, fmt.Errorf("%q is not a valid IP address or CIDR", conditionValue)
+	}
+	ip := net.ParseIP(contextValue)
+	return ip != nil && network.Contains(ip), nil
+}
@@ -0,0 +1,103 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func buildV5TestEvaluationPolicy(source, effect string, actions ...string) *v5EvaluationPolicy {
+	return &v5EvaluationPolicy{
+		Source: source,
+		Document: &v5PolicyDocument{
+			Version: v5PolicyDocumentVersion,
+			Statement: []*v5PolicyStatement{
+				{Effect: effect, Action: actions},
+			},
+		},
+	}
+}
+
+func TestV5PolicyEvaluationFunc_evaluateV5Policies(t *testing.T) {
+	var (
+		identityAllowEcs = buildV5TestEvaluationPolicy("identity:allow-ecs", "Allow", "ecs:*:*")
+		identityDenyList = buildV5TestEvaluationPolicy("identity:deny-list", "Deny", "ecs:*:list*")
+		scpAllowAll      = buildV5TestEvaluationPolicy("organizations:allow-all", "Allow", "*")
+		scpAllowObs      = buildV5TestEvaluationPolicy("organizations:allow-obs", "Allow", "obs:*:*")
+		scpDenyEcs       = buildV5TestEvaluationPolicy("organizations:deny-ecs", "Deny", "ecs:*:*")
+		request          = &v5EvaluationRequest{Action: "ecs:cloudServers:list", Resource: "*"}
+	)
+
+	testCases := []struct {
+		name             string
+		identityPolicies []*v5EvaluationPolicy
+		orgLevels        []*v5EvaluationLevel
+		expectedDecision string
+		expectedSource   string
+	}{
+		{
+			name:             "allowed by the identity policy",
+			identityPolicies: []*v5EvaluationPolicy{identityAllowEcs},
+			expectedDecision: v5PolicyDecisionAllowed,
+			expectedSource:   "identity:allow-ecs",
+		},
+		{
+			name:             "not allowed by any identity policy",
+			expectedDecision: v5PolicyDecisionImplicitDeny,
+		},
+		{
+			name:             "explicit deny overrides allow",
+			identityPolicies: []*v5EvaluationPolicy{identityAllowEcs, identityDenyList},
+			expectedDecision: v5PolicyDecisionExplicitDeny,
+			expectedSource:   "identity:deny-list",
+		},
+		{
+			name:             "allowed by every level",
+			identityPolicies: []*v5EvaluationPolicy{identityAllowEcs},
+			orgLevels: []*v5EvaluationLevel{
+				{Level: "root", Policies: []*v5EvaluationPolicy{scpAllowAll}},
+				{Level: "account", Policies: []*v5EvaluationPolicy{scpAllowObs, scpAllowAll}},
+			},
+			expectedDecision: v5PolicyDecisionAllowed,
+			expectedSource:   "identity:allow-ecs",
+		},
+		{
+			name:             "not allowed by one of the levels",
+			identityPolicies: []*v5EvaluationPolicy{identityAllowEcs},
+			orgLevels: []*v5EvaluationLevel{
+				{Level: "root", Policies: []*v5EvaluationPolicy{scpAllowAll}},
+				{Level: "account", Policies: []*v5EvaluationPolicy{scpAllowObs}},
+			},
+			expectedDecision: v5PolicyDecisionImplicitDeny,
+			expectedSource:   "organizations_level:account",
+		},
+		{
+			name:             "denied by one of the levels",
+			identityPolicies: []*v5EvaluationPolicy{identityAllowEcs},
+			orgLevels: []*v5EvaluationLevel{
+				{Level: "root", Policies: []*v5EvaluationPolicy{scpAllowAll}},
+				{Level: "account", Policies: []*v5EvaluationPolicy{scpAllowAll, scpDenyEcs}},
+			},
+			expectedDecision: v5PolicyDecisionExplicitDeny,
+			expectedSource:   "organizations:deny-ecs",
+		},
+		{
+			name: "allowed by the levels but not by the identity policies",
+			orgLevels: []*v5EvaluationLevel{
+				{Level: "root", Policies: []*v5EvaluationPolicy{scpAllowAll}},
+			},
+			expectedDecision: v5PolicyDecisionImplicitDeny,
+		},
+	}
+
+	for _, tc := range testCases {
+		result, err := evaluateV5Policies(tc.identityPolicies, tc.orgLevels, request)
+		if err != nil {
+			t.Fatalf("[%s] Unexpected error is returned: %s", tc.name, err)
+		}
+		if result.Decision != tc.expectedDecision || result.Source != tc.expectedSource {
+			t.Fatalf("[%s] The evaluation result is not as expected, want %s (%s), but got %s (%s)", tc.name,
+				utils.Green(tc.expectedDecision), tc.expectedSource, utils.Yellow(result.Decision), result.Source)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// ResourceAclSet is the impl for huaweicloud_dms_kafka_acl_set resource, which authoritatively manages the permission
+// bindings between all users and topics of the Kafka instance. The bindings which are granted outside of this
+// resource are reported as drift and warnings, they are revoked by the next apply after the drift is shown in the plan.
+// @API Kafka GET /v2/{project_id}/instances/{instance_id}/topics
+// @API Kafka GET /v1/{project_id}/instances/{instance_id}/topics/{topic_name}/accesspolicy
+// @API Kafka POST /v1/{project_id}/instances/{instance_id}/topics/accesspolicy
+// @API Kafka GET /v2/{project_id}/instances/{instance_id}
+func ResourceAclSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAclSetCreate,
+		ReadContext:   resourceAclSetRead,
+		UpdateContext: resourceAclSetUpdate,
+		DeleteContext: resourceAclSetDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: `The region where the Kafka instance is located.`,
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The ID of the Kafka instance to which the ACLs belong.`,
+			},
+			"acls": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        aclSetAclSchema(false),
+				Description: `All permission bindings between the users and the topics of the Kafka instance.`,
+			},
+			"unmanaged_acls": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        aclSetAclSchema(true),
+				Description: `The permission bindings which are granted outside of this resource since the last refresh.`,
+			},
+		},
+	}
+}
+
+func aclSetAclSchema(computed bool) *schema.Resource {
+	sc := schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"topic_name": {
+				Type:        schema.TypeString,
+				Required:    !computed,
+				Computed:    computed,
+				Description: `The name of the topic.`,
+			},
+			"user_name": {
+				Type:        schema.TypeString,
+				Required:    !computed,
+				Computed:    computed,
+				Description: `The name of the user.`,
+			},
+			"access_policy": {
+				Type:        schema.TypeString,
+				Required:    !computed,
+				Computed:    computed,
+				Description: `The permission type of the user for the topic.`,
+			},
+		},
+	}
+	if !computed {
+		sc.Schema["access_policy"].ValidateFunc = validation.StringInSlice([]string{"pub", "sub", "all"}, false)
+	}
+	return &sc
+}
+
+// buildAclSetPolicies groups the ACLs by the topic names, each topic has the access policies of the users.
+func buildAclSetPolicies(acls []interface{}) (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string)
+	for _, v := range acls {
+		topicName := utils.PathSearch("topic_name", v, "").(string)
+		userName := utils.PathSearch("user_name", v, "").(string)
+		if _, ok := result[topicName]; !ok {
+			result[topicName] = make(map[string]string)
+		}
+		if _, ok := result[topicName][userName]; ok {
+			return nil, fmt.Errorf("duplicate ACLs are found for the user (%s) and the topic (%s)", userName,
+				topicName)
+		}
+		result[topicName][userName] = utils.PathSearch("access_policy", v, "").(string)
+	}
+	return result, nil
+}
+
+// listAclSetAcls queries the ACLs of all common topics of the Kafka instance.
+func listAclSetAcls(client *golangsdk.ServiceClient, instanceId string) ([]interface{}, error) {
+	topics, err := listCommonTopics(client, instanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0)
+	for _, topic := range topics {
+		topicName := utils.PathSearch("name", topic, "").(string)
+		policies, err := GetDmsKafkaPermissions(client, instanceId, topicName)
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				continue
+			}
+			return nil, fmt.Errorf("error retrieving permissions of the topic (%s): %s", topicName, err)
+		}
+		for _, policy := range policies {
+			result = append(result, map[string]interface{}{
+				"topic_name":    topicName,
+				"user_name":     utils.PathSearch("user_name", policy, nil),
+				"access_policy": utils.PathSearch("access_policy", policy, nil),
+			})
+		}
+	}
+	return result, nil
+}
+
+// buildAclSetTopicsBodyParams returns the access policies of the topics whose ACLs are changed from the current
+// ones to the desired ones, the access policies of a topic are replaced as a whole.
+func buildAclSetTopicsBodyParams(current, desired map[string]map[string]string) []interface{} {
+	topicNames := make([]string, 0)
+	for topicName, policies := range desired {
+		if !isAclSetPoliciesEqual(current[topicName], policies) {
+			topicNames = append(topicNames, topicName)
+		}
+	}
+	for topicName, policies := range current {
+		if _, ok := desired[topicName]; !ok && len(policies) > 0 {
+			topicNames = append(topicNames, topicName)
+		}
+	}
+	sort.Strings(topicNames)
+
+	result := make([]interface{}, 0, len(topicNames))
+	for _, topicName := range topicNames {
+		userNames := make([]string, 0, len(desired[topicName]))
+		for userName := range desired[topicName] {
+			userNames = append(userNames, userName)
+		}
+		sort.Strings(userNames)
+
+		policies := make([]interface{}, 0, len(userNames))
+		for _, userName := range userNames {
+			policies = append(policies, map[string]interface{}{
+				"user_name":     userName,
+				"access_policy": desired[topicName][userName],
+			})
+		}
+		result = append(result, map[string]interface{}{
+			"name":     topicName,
+			"policies": policies,
+		})
+	}
+	return result
+}
+
+func isAclSetPoliciesEqual(policies, other map[string]string) bool {
+	if len(policies) != len(other) {
+		return false
+	}
+	for userName, accessPolicy := range policies {
+		if other[userName] != accessPolicy {
+			return false
+		}
+	}
+	return true
+}
+
+// applyAclSetPolicies replaces the current ACLs of the Kafka instance with the desired ones. If keepUnmanaged is true,
+// the current ACLs which are not in the desired ones are kept.
+func applyAclSetPolicies(ctx context.Context, client *golangsdk.ServiceClient, d *schema.ResourceData,
+	desired map[string]map[string]string, keepUnmanaged bool, timeout string) error {
+	instanceId := d.Get("instance_id").(string)
+	currentAcls, err := listAclSetAcls(client, instanceId)
+	if err != nil {
+		return fmt.Errorf("error querying ACLs of the kafka instance (%s): %s", instanceId, err)
+	}
+	current, err := buildAclSetPolicies(currentAcls)
+	if err != nil {
+		return err
+	}
+	if keepUnmanaged {
+		desired = mergeAclSetPolicies(current, desired)
+	}
+
+	topics := buildAclSetTopicsBodyParams(current, desired)
+	if len(topics) == 0 {
+		return nil
+	}
+	if err = updateKafkaPermissions(client, instanceId, map[string]interface{}{"topics": topics}); err != nil {
+		return fmt.Errorf("error setting ACLs of the kafka instance (%s): %s", instanceId, err)
+	}
+	return waitForKafkaTopicAccessPolicyComplete(ctx, client, d, instanceId, timeout)
+}
+
+// mergeAclSetPolicies returns the current access policies overridden by the desired ones.
+func mergeAclSetPolicies(current, desired map[string]map[string]string) map[string]map[string]string {
+	result := make(map[string]map[string]string)
+	for _, policies := range []map[string]map[string]string{current, desired} {
+		for topicName, userPolicies := range policies {
+			if _, ok := result[topicName]; !ok {
+				result[topicName] = make(map[string]string)
+			}
+			for userName, accessPolicy := range userPolicies {
+				result[topicName][userName] = accessPolicy
+			}
+		}
+	}
+	return result
+}
+
+func resourceAclSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.DmsV2Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	desired, err := buildAclSetPolicies(d.Get("acls").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("instance_id").(string))
+
+	// The ACLs which already exist are kept and reported as the unmanaged ACLs by the read.
+	if err = applyAclSetPolicies(ctx, client, d, desired, true, schema.TimeoutCreate); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAclSetRead(ctx, d, meta)
+}
+
+func resourceAclSetRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var (
+		cfg        = meta.(*config.Config)
+		region     = cfg.GetRegion(d)
+		instanceId = d.Id()
+	)
+	client, err := cfg.DmsV2Client(region)
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	acls, err := listAclSetAcls(client, instanceId)
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, fmt.Sprintf("error retrieving ACLs of the kafka instance (%s)",
+			instanceId))
+	}
+
+	managedAcls := d.Get("acls").(*schema.Set)
+	unmanagedAcls := make([]interface{}, 0)
+	if managedAcls.Len() > 0 {
+		for _, acl := range acls {
+			if !managedAcls.Contains(acl) {
+				unmanagedAcls = append(unmanagedAcls, acl)
+			}
+		}
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("instance_id", instanceId),
+		d.Set("acls", acls),
+		d.Set("unmanaged_acls", unmanagedAcls),
+	)
+	if err = mErr.ErrorOrNil(); err != nil {
+		return diag.FromErr(err)
+	}
+	return buildUnmanagedAclsWarning(instanceId, unmanagedAcls)
+}
+
+// buildUnmanagedAclsWarning returns the warning of the ACLs which are not managed by this resource.
+func buildUnmanagedAclsWarning(instanceId string, unmanagedAcls []interface{}) diag.Diagnostics {
+	if len(unmanagedAcls) == 0 {
+		return nil
+	}
+
+	bindings := make([]string, 0, len(unmanagedAcls))
+	for _, acl := range unmanagedAcls {
+		bindings = append(bindings, fmt.Sprintf("%s/%s:%s", utils.PathSearch("topic_name", acl, ""),
+			utils.PathSearch("user_name", acl, ""), utils.PathSearch("access_policy", acl, "")))
+	}
+	sort.Strings(bindings)
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unmanaged ACLs found",
+			Detail: fmt.Sprintf("ACLs %v (topic/user:access_policy) of the kafka instance (%s) are not managed by "+
+				"this resource, add them to the configuration to keep them, otherwise they will be revoked by the "+
+				"next apply.", bindings, instanceId),
+		},
+	}
+}
+
+func resourceAclSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.DmsV2Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	if d.HasChange("acls") {
+		desired, err := buildAclSetPolicies(d.Get("acls").(*schema.Set).List())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err = applyAclSetPolicies(ctx, client, d, desired, false, schema.TimeoutUpdate); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceAclSetRead(ctx, d, meta)
+}
+
+func resourceAclSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.DmsV2Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	// Only the ACLs of the topics which are managed by this resource are revoked.
+	desired := make(map[string]map[string]string)
+	for _, v := range d.Get("acls").(*schema.Set).List() {
+		desired[utils.PathSearch("topic_name", v, "").(string)] = make(map[string]string)
+	}
+	current, err := buildAclSetPolicies(d.Get("acls").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	topics := buildAclSetTopicsBodyParams(current, desired)
+	if len(topics) == 0 {
+		return nil
+	}
+	instanceId := d.Get("instance_id").(string)
+	if err = updateKafkaPermissions(client, instanceId, map[string]interface{}{"topics": topics}); err != nil {
+		return common.CheckDeletedDiag(d, common.ConvertExpected400ErrInto404Err(
+			err, "failed_topics|[0].error_msg", "Topic policy is empty."),
+			fmt.Sprintf("error revoking ACLs of the kafka instance (%s)", instanceId))
+	}
+	if err = waitForKafkaTopicAccessPolicyComplete(ctx, client, d, instanceId, schema.TimeoutDelete); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
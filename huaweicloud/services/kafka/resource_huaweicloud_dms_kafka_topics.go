@@ -0,0 +1,548 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// ResourceTopics is the impl for huaweicloud_dms_kafka_topics resource, which manages the configured topics of the
+// Kafka instance in batches. The topics which are created outside of this resource are never deleted, they are only
+// reported in `unmanaged_topics` and as warnings.
+// @API Kafka POST /v2/{project_id}/instances/{instance_id}/topics
+// @API Kafka GET /v2/{project_id}/instances/{instance_id}/topics
+// @API Kafka PUT /v2/{project_id}/instances/{instance_id}/topics
+// @API Kafka POST /v2/{project_id}/instances/{instance_id}/topics/delete
+func ResourceTopics() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTopicsCreate,
+		ReadContext:   resourceTopicsRead,
+		UpdateContext: resourceTopicsUpdate,
+		DeleteContext: resourceTopicsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		CustomizeDiff: checkTopicsChange,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: `The region where the topics are located.`,
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The ID of the Kafka instance to which the topics belong.`,
+			},
+			"topics": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        topicsTopicSchema(),
+				Set:         topicsTopicHash,
+				Description: `The common topics of the Kafka instance managed by this resource.`,
+			},
+			"unmanaged_topics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The names of the common topics which are not managed by this resource.`,
+			},
+		},
+	}
+}
+
+func topicsTopicSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The name of the topic.`,
+			},
+			"partitions": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: `The partition number.`,
+			},
+			"replicas": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: `The replica number.`,
+			},
+			"aging_time": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: `The aging time in hours.`,
+			},
+			"sync_replication": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: `Whether to enable synchronous replication.`,
+			},
+			"sync_flushing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: `Whether to enable synchronous flushing.`,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: `The description of the topic.`,
+			},
+			"configs": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: `The configuration name.`,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: `The configuration value.`,
+						},
+					},
+				},
+				Description: `The other topic configurations.`,
+			},
+		},
+	}
+}
+
+// topicsTopicHash hashes the topic by its name, so that the changes of a topic are shown as in-place updates instead
+// of a pair of removal and addition.
+func topicsTopicHash(v interface{}) int {
+	return schema.HashString(utils.PathSearch("name", v, "").(string))
+}
+
+// buildTopicsMap returns the topics keyed by the topic names.
+func buildTopicsMap(topics *schema.Set) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{})
+	for _, v := range topics.List() {
+		topic := v.(map[string]interface{})
+		result[topic["name"].(string)] = topic
+	}
+	return result
+}
+
+func sortedTopicNames(topics map[string]map[string]interface{}) []string {
+	result := make([]string, 0, len(topics))
+	for name := range topics {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// checkTopicsChange checks whether the changes of the existing topics are supported, the partitions can only be added
+// and the replicas can not be changed.
+func checkTopicsChange(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("topics") || !d.NewValueKnown("topics") {
+		return nil
+	}
+
+	oldVal, newVal := d.GetChange("topics")
+	oldTopics := buildTopicsMap(oldVal.(*schema.Set))
+	var mErr *multierror.Error
+	for name, newTopic := range buildTopicsMap(newVal.(*schema.Set)) {
+		oldTopic, ok := oldTopics[name]
+		if !ok {
+			continue
+		}
+		if newTopic["partitions"].(int) < oldTopic["partitions"].(int) {
+			mErr = multierror.Append(mErr, fmt.Errorf("the partitions of topic %s can only be added", name))
+		}
+		if replicas := newTopic["replicas"].(int); replicas != 0 && replicas != oldTopic["replicas"].(int) {
+			mErr = multierror.Append(mErr, fmt.Errorf("the replicas of topic %s can not be changed", name))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+func buildTopicsCreateBodyParams(topic map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                  topic["name"],
+		"partition":           topic["partitions"],
+		"replication":         utils.ValueIgnoreEmpty(topic["replicas"]),
+		"retention_time":      utils.ValueIgnoreEmpty(topic["aging_time"]),
+		"sync_replication":    topic["sync_replication"],
+		"sync_message_flush":  topic["sync_flushing"],
+		"topic_desc":          utils.ValueIgnoreEmpty(topic["description"]),
+		"topic_other_configs": buildTopicConfigs(topic["configs"].(*schema.Set).List()),
+	}
+}
+
+func buildTopicsUpdateBodyParams(oldTopic, newTopic map[string]interface{}) map[string]interface{} {
+	params := map[string]interface{}{
+		"id":                 newTopic["name"],
+		"retention_time":     newTopic["aging_time"],
+		"sync_replication":   newTopic["sync_replication"],
+		"sync_message_flush": newTopic["sync_flushing"],
+		"topic_desc":         newTopic["description"],
+	}
+	if newTopic["partitions"].(int) != oldTopic["partitions"].(int) {
+		params["new_partition_numbers"] = newTopic["partitions"]
+	}
+	if !newTopic["configs"].(*schema.Set).Equal(oldTopic["configs"]) {
+		params["topic_other_configs"] = buildTopicConfigs(newTopic["configs"].(*schema.Set).List())
+	}
+	return params
+}
+
+// isTopicChanged checks whether the topic is changed, the unknown optional values are ignored.
+func isTopicChanged(oldTopic, newTopic map[string]interface{}) bool {
+	for _, key := range []string{"partitions", "aging_time", "sync_replication", "sync_flushing", "description"} {
+		if oldTopic[key] != newTopic[key] {
+			return true
+		}
+	}
+	return !newTopic["configs"].(*schema.Set).Equal(oldTopic["configs"])
+}
+
+// createTopics creates the topics one by one and waits for them to be available.
+func createTopics(ctx context.Context, client *golangsdk.ServiceClient, instanceId string,
+	topics []map[string]interface{}, timeout time.Duration) error {
+	for _, topic := range topics {
+		topicName := topic["name"].(string)
+		if _, err := createTopic(client, instanceId, buildTopicsCreateBodyParams(topic)); err != nil {
+			return fmt.Errorf("error creating topic (%s) for the kafka instance (%s): %s", topicName, instanceId, err)
+		}
+
+		stateConf := &retry.StateChangeConf{
+			Pending:      []string{"PENDING"},
+			Target:       []string{"SUCCESS"},
+			Refresh:      kafkaTopicCreateRefreshFunc(client, instanceId, topicName),
+			Timeout:      timeout,
+			Delay:        1 * time.Second,
+			PollInterval: 10 * time.Second,
+		}
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("error waiting for topic (%s) of the kafka instance (%s) to be created: %s", topicName,
+				instanceId, err)
+		}
+	}
+	return nil
+}
+
+// deleteTopics deletes the topics in a batch.
+func deleteTopics(client *golangsdk.ServiceClient, instanceId string, topicNames []string) error {
+	if len(topicNames) == 0 {
+		return nil
+	}
+
+	httpUrl := "v2/{project_id}/instances/{instance_id}/topics/delete"
+	deletePath := client.Endpoint + httpUrl
+	deletePath = strings.ReplaceAll(deletePath, "{project_id}", client.ProjectID)
+	deletePath = strings.ReplaceAll(deletePath, "{instance_id}", instanceId)
+	deleteOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+		JSONBody: map[string]interface{}{
+			"topics": topicNames,
+		},
+	}
+
+	resp, err := client.Request("POST", deletePath, &deleteOpt)
+	if err != nil {
+		return err
+	}
+	respBody, err := utils.FlattenResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	failedTopics := utils.PathSearch("topics[?!success].id", respBody, make([]interface{}, 0)).([]interface{})
+	if len(failedTopics) > 0 {
+		return fmt.Errorf("unable to delete topics %v", failedTopics)
+	}
+	return nil
+}
+
+func resourceTopicsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dms", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	instanceId := d.Get("instance_id").(string)
+	existingTopics, err := listCommonTopics(client, instanceId)
+	if err != nil {
+		return diag.Errorf("error querying topics of the kafka instance (%s): %s", instanceId, err)
+	}
+
+	// The topics which already exist are altered instead of created, and the others are kept and reported as the
+	// unmanaged topics by the read.
+	existingTopicsMap := buildExistingTopicsMap(existingTopics)
+	toCreate, toUpdate := make([]map[string]interface{}, 0), make([]interface{}, 0)
+	rawTopics := buildRawConfigTopicsMap(d)
+	for name, topic := range buildTopicsMap(d.Get("topics").(*schema.Set)) {
+		if oldTopic, ok := existingTopicsMap[name]; ok {
+			fillTopicUnsetValues(topic, oldTopic, rawTopics[name])
+			if isTopicChanged(oldTopic, topic) {
+				toUpdate = append(toUpdate, buildTopicsUpdateBodyParams(oldTopic, topic))
+			}
+			continue
+		}
+		toCreate = append(toCreate, topic)
+	}
+
+	d.SetId(instanceId)
+
+	if err = applyTopicsChanges(ctx, client, instanceId, toCreate, toUpdate, nil,
+		d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTopicsRead(ctx, d, meta)
+}
+
+// buildRawConfigTopicsMap returns the configured topics keyed by the topic names, the values which are not configured
+// are nil.
+func buildRawConfigTopicsMap(d *schema.ResourceData) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{})
+	rawTopics, _ := utils.GetNestedObjectFromRawConfig(d.GetRawConfig(), "topics").([]interface{})
+	for _, v := range rawTopics {
+		if rawTopic, ok := v.(map[string]interface{}); ok {
+			result[utils.PathSearch("name", rawTopic, "").(string)] = rawTopic
+		}
+	}
+	return result
+}
+
+// fillTopicUnsetValues uses the values of the existing topic for the optional values which are not configured, so
+// that the existing topic is not altered by the default values.
+func fillTopicUnsetValues(topic, existingTopic, rawTopic map[string]interface{}) {
+	for _, key := range []string{"replicas", "aging_time", "sync_replication", "sync_flushing", "description",
+		"configs"} {
+		if rawTopic == nil || rawTopic[key] == nil {
+			topic[key] = existingTopic[key]
+			continue
+		}
+		// The omitted configs blocks are parsed as an empty list.
+		if rawConfigs, ok := rawTopic[key].([]interface{}); ok && len(rawConfigs) == 0 {
+			topic[key] = existingTopic[key]
+		}
+	}
+}
+
+// applyTopicsChanges deletes, alters and creates the topics in batches.
+func applyTopicsChanges(ctx context.Context, client *golangsdk.ServiceClient, instanceId string,
+	toCreate []map[string]interface{}, toUpdate []interface{}, toDelete []string, timeout time.Duration) error {
+	if err := deleteTopics(client, instanceId, toDelete); err != nil {
+		return fmt.Errorf("error deleting topics of the kafka instance (%s): %s", instanceId, err)
+	}
+	if len(toUpdate) > 0 {
+		bodyParams := map[string]interface{}{
+			"topics": toUpdate,
+		}
+		if err := updateTopic(client, instanceId, bodyParams); err != nil {
+			return fmt.Errorf("error updating topics of the kafka instance (%s): %s", instanceId, err)
+		}
+	}
+	return createTopics(ctx, client, instanceId, toCreate, timeout)
+}
+
+// listCommonTopics queries all topics of the instance except the system topics.
+func listCommonTopics(client *golangsdk.ServiceClient, instanceId string) ([]interface{}, error) {
+	topics, err := listTopics(client, instanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0, len(topics))
+	for _, topic := range topics {
+		if int(utils.PathSearch("topic_type", topic, float64(0)).(float64)) == 1 {
+			continue
+		}
+		result = append(result, topic)
+	}
+	return result, nil
+}
+
+func flattenTopicsTopic(topic interface{}) map[string]interface{} {
+	configs := utils.PathSearch("topic_other_configs", topic, make([]interface{}, 0)).([]interface{})
+	configSet := schema.NewSet(schema.HashResource(topicsTopicSchema().Schema["configs"].Elem.(*schema.Resource)),
+		nil)
+	for _, v := range flattenTopicConfigs(configs) {
+		configSet.Add(map[string]interface{}{
+			"name":  v["name"],
+			"value": v["value"],
+		})
+	}
+
+	return map[string]interface{}{
+		"name":             utils.PathSearch("name", topic, ""),
+		"partitions":       int(utils.PathSearch("partition", topic, float64(0)).(float64)),
+		"replicas":         int(utils.PathSearch("replication", topic, float64(0)).(float64)),
+		"aging_time":       int(utils.PathSearch("retention_time", topic, float64(0)).(float64)),
+		"sync_replication": utils.PathSearch("sync_replication", topic, false),
+		"sync_flushing":    utils.PathSearch("sync_message_flush", topic, false),
+		"description":      utils.PathSearch("topic_desc", topic, ""),
+		"configs":          configSet,
+	}
+}
+
+// buildExistingTopicsMap returns the existing topics, which are flattened in the schema format, keyed by the names.
+func buildExistingTopicsMap(topics []interface{}) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{})
+	for _, topic := range topics {
+		flattened := flattenTopicsTopic(topic)
+		result[flattened["name"].(string)] = flattened
+	}
+	return result
+}
+
+func resourceTopicsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var (
+		cfg        = meta.(*config.Config)
+		region     = cfg.GetRegion(d)
+		instanceId = d.Id()
+	)
+	client, err := cfg.NewServiceClient("dms", region)
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	topics, err := listCommonTopics(client, instanceId)
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, fmt.Sprintf("error retrieving topics of the instance (%s)", instanceId))
+	}
+
+	// Only the topics managed by this resource are saved, the others are reported as the unmanaged topics, so that
+	// they will never be deleted by this resource.
+	managedTopics := buildTopicsMap(d.Get("topics").(*schema.Set))
+	result := make([]interface{}, 0, len(managedTopics))
+	unmanagedTopics := make([]string, 0)
+	for _, topic := range topics {
+		flattened := flattenTopicsTopic(topic)
+		if _, ok := managedTopics[flattened["name"].(string)]; ok {
+			result = append(result, flattened)
+			continue
+		}
+		unmanagedTopics = append(unmanagedTopics, flattened["name"].(string))
+	}
+	sort.Strings(unmanagedTopics)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("instance_id", instanceId),
+		d.Set("topics", result),
+		d.Set("unmanaged_topics", unmanagedTopics),
+	)
+	if err = mErr.ErrorOrNil(); err != nil {
+		return diag.FromErr(err)
+	}
+	return buildUnmanagedTopicsWarning(instanceId, unmanagedTopics)
+}
+
+// buildUnmanagedTopicsWarning returns the warning of the topics which are not managed by this resource.
+func buildUnmanagedTopicsWarning(instanceId string, unmanagedTopics []string) diag.Diagnostics {
+	if len(unmanagedTopics) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unmanaged topics found",
+			Detail: fmt.Sprintf("Topics %v of the kafka instance (%s) are not managed by this resource, add them to "+
+				"the configuration to manage them.", unmanagedTopics, instanceId),
+		},
+	}
+}
+
+func resourceTopicsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dms", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	if d.HasChange("topics") {
+		instanceId := d.Get("instance_id").(string)
+		existingTopics, err := listCommonTopics(client, instanceId)
+		if err != nil {
+			return diag.Errorf("error querying topics of the kafka instance (%s): %s", instanceId, err)
+		}
+
+		// The unmanaged topics which are added to the configuration are altered instead of created.
+		existingTopicsMap := buildExistingTopicsMap(existingTopics)
+		rawTopics := buildRawConfigTopicsMap(d)
+		oldVal, newVal := d.GetChange("topics")
+		oldTopics := buildTopicsMap(oldVal.(*schema.Set))
+		toCreate, toUpdate := make([]map[string]interface{}, 0), make([]interface{}, 0)
+		for name, topic := range buildTopicsMap(newVal.(*schema.Set)) {
+			if oldTopic, ok := oldTopics[name]; ok {
+				if isTopicChanged(oldTopic, topic) {
+					toUpdate = append(toUpdate, buildTopicsUpdateBodyParams(oldTopic, topic))
+				}
+				delete(oldTopics, name)
+				continue
+			}
+			if existingTopic, ok := existingTopicsMap[name]; ok {
+				fillTopicUnsetValues(topic, existingTopic, rawTopics[name])
+				if isTopicChanged(existingTopic, topic) {
+					toUpdate = append(toUpdate, buildTopicsUpdateBodyParams(existingTopic, topic))
+				}
+				continue
+			}
+			toCreate = append(toCreate, topic)
+		}
+
+		if err = applyTopicsChanges(ctx, client, instanceId, toCreate, toUpdate, sortedTopicNames(oldTopics),
+			d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceTopicsRead(ctx, d, meta)
+}
+
+func resourceTopicsDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dms", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	instanceId := d.Get("instance_id").(string)
+	topicNames := sortedTopicNames(buildTopicsMap(d.Get("topics").(*schema.Set)))
+	if err = deleteTopics(client, instanceId, topicNames); err != nil {
+		return common.CheckDeletedDiag(d, err, fmt.Sprintf("error deleting topics of the kafka instance (%s)",
+			instanceId))
+	}
+	return nil
+}
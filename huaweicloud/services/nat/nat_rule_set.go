@@ -0,0 +1,485 @@
+package nat
+
+// This set of code is shared by the rule set resources (huaweicloud_nat_dnat_rule_set, huaweicloud_nat_snat_rule_set,
+// huaweicloud_nat_private_dnat_rule_set and huaweicloud_nat_private_snat_rule_set).
+//
+// Each rule set resource manages the declared rules of a kind under a gateway. The remote rules are matched with the
+// desired ones by the identity key (e.g. the EIP, protocol and external port of a DNAT rule), the matched rules are
+// updated in place if they are changed, the missing ones are created, and the managed ones which are no longer
+// declared are deleted. The rules which are not declared in the configuration are never saved in `rules` or deleted,
+// they are reported as warnings during every refresh.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/pagination"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// The maximum number of rules in a batch creation request.
+const natRuleBatchSize = 100
+
+// natRuleSetDefinition describes how a kind of NAT rules is listed, matched and changed.
+type natRuleSetDefinition struct {
+	// The rule type used in the messages, e.g. "DNAT rule".
+	ruleType string
+	// The key of the gateway ID in the schema and in the query parameters of the list API.
+	gatewayKey string
+	// The key of the rule in the request and response bodies, e.g. "dnat_rule".
+	ruleKey string
+	// The key of the rule list in the request and response bodies, e.g. "dnat_rules".
+	rulesKey string
+
+	// The URL of the gateway query API, which is used to check whether the gateway exists.
+	gatewayUrl string
+	listUrl    string
+	updateUrl  string
+	deleteUrl  string
+	// The URL of the batch creation API, the rules are created one by one if it is empty.
+	batchCreateUrl string
+	// Whether the rules are changed asynchronously and have the PENDING_* statuses.
+	asynchronous bool
+
+	desiredKey func(rule map[string]interface{}) string
+	remoteKey  func(remote interface{}) string
+	// buildCreateBody returns the request body of a rule without the outer key.
+	buildCreateBody func(gatewayId string, rule map[string]interface{}) map[string]interface{}
+	// buildUpdateBody returns the request body of a rule without the outer key, nil is returned if the remote rule is
+	// the same as the desired one.
+	buildUpdateBody func(meta interface{}, region, gatewayId string, rule map[string]interface{},
+		remote interface{}) (map[string]interface{}, error)
+	// flattenRule converts the remote rule to the form of the schema, the state rule with the same key is used to keep
+	// the form of the equivalent values (e.g. the port or the port range).
+	flattenRule func(remote interface{}, stateRule map[string]interface{}) map[string]interface{}
+	// validateRules checks the conflicts among the desired rules during the plan.
+	validateRules func(rules []interface{}) error
+}
+
+func natRuleSetCustomizeDiff(def *natRuleSetDefinition) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		rules := d.Get("rules").(*schema.Set).List()
+		seen := make(map[string]bool, len(rules))
+		for _, v := range rules {
+			rule, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key := def.desiredKey(rule)
+			if seen[key] {
+				return fmt.Errorf("duplicate %ss are found: %s", def.ruleType, key)
+			}
+			seen[key] = true
+		}
+		return def.validateRules(rules)
+	}
+}
+
+func listNatRuleSetRules(client *golangsdk.ServiceClient, def *natRuleSetDefinition,
+	gatewayId string) ([]interface{}, error) {
+	listPath := client.Endpoint + def.listUrl
+	listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+	listPath += fmt.Sprintf("?%s=%s", def.gatewayKey, gatewayId)
+
+	resp, err := pagination.ListAllItems(client, "marker", listPath, &pagination.QueryOpts{MarkerField: ""})
+	if err != nil {
+		return nil, err
+	}
+	respJson, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	var respBody interface{}
+	if err := json.Unmarshal(respJson, &respBody); err != nil {
+		return nil, err
+	}
+	return utils.PathSearch(def.rulesKey, respBody, make([]interface{}, 0)).([]interface{}), nil
+}
+
+func createNatRuleSetRules(client *golangsdk.ServiceClient, def *natRuleSetDefinition,
+	bodies []map[string]interface{}) error {
+	if def.batchCreateUrl == "" {
+		createPath := client.Endpoint + def.listUrl
+		createPath = strings.ReplaceAll(createPath, "{project_id}", client.ProjectID)
+		for _, body := range bodies {
+			createOpt := golangsdk.RequestOpts{
+				KeepResponseBody: true,
+				JSONBody:         map[string]interface{}{def.ruleKey: utils.RemoveNil(body)},
+			}
+			if _, err := client.Request("POST", createPath, &createOpt); err != nil {
+				return fmt.Errorf("error creating %s: %s", def.ruleType, err)
+			}
+		}
+		return nil
+	}
+
+	createPath := client.Endpoint + def.batchCreateUrl
+	createPath = strings.ReplaceAll(createPath, "{project_id}", client.ProjectID)
+	for start := 0; start < len(bodies); start += natRuleBatchSize {
+		end := start + natRuleBatchSize
+		if end > len(bodies) {
+			end = len(bodies)
+		}
+		batch := make([]map[string]interface{}, 0, end-start)
+		for _, body := range bodies[start:end] {
+			batch = append(batch, utils.RemoveNil(body))
+		}
+		createOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+			JSONBody:         map[string]interface{}{def.rulesKey: batch},
+		}
+		if _, err := client.Request("POST", createPath, &createOpt); err != nil {
+			return fmt.Errorf("error creating %ss in batch: %s", def.ruleType, err)
+		}
+	}
+	return nil
+}
+
+func updateNatRuleSetRule(client *golangsdk.ServiceClient, def *natRuleSetDefinition, ruleId string,
+	body map[string]interface{}) error {
+	updatePath := client.Endpoint + def.updateUrl
+	updatePath = strings.ReplaceAll(updatePath, "{project_id}", client.ProjectID)
+	updatePath = strings.ReplaceAll(updatePath, "{rule_id}", ruleId)
+	updateOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody:         map[string]interface{}{def.ruleKey: utils.RemoveNil(body)},
+	}
+	if _, err := client.Request("PUT", updatePath, &updateOpt); err != nil {
+		return fmt.Errorf("error updating %s (%s): %s", def.ruleType, ruleId, err)
+	}
+	return nil
+}
+
+func deleteNatRuleSetRule(client *golangsdk.ServiceClient, def *natRuleSetDefinition, gatewayId,
+	ruleId string) error {
+	deletePath := client.Endpoint + def.deleteUrl
+	deletePath = strings.ReplaceAll(deletePath, "{project_id}", client.ProjectID)
+	deletePath = strings.ReplaceAll(deletePath, "{gateway_id}", gatewayId)
+	deletePath = strings.ReplaceAll(deletePath, "{rule_id}", ruleId)
+	deleteOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	if _, err := client.Request("DELETE", deletePath, &deleteOpt); err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("error deleting %s (%s): %s", def.ruleType, ruleId, err)
+	}
+	return nil
+}
+
+// waitForNatRuleSetStable waits for the deleted rules to disappear and the other rules to leave the PENDING_*
+// statuses.
+func waitForNatRuleSetStable(ctx context.Context, client *golangsdk.ServiceClient, def *natRuleSetDefinition,
+	gatewayId string, deletedIds []string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"PENDING"},
+		Target:  []string{"COMPLETED"},
+		Refresh: func() (interface{}, string, error) {
+			rules, err := listNatRuleSetRules(client, def, gatewayId)
+			if err != nil {
+				return nil, "ERROR", err
+			}
+			for _, rule := range rules {
+				ruleId := utils.PathSearch("id", rule, "").(string)
+				status := utils.PathSearch("status", rule, "").(string)
+				if utils.StrSliceContains(deletedIds, ruleId) || strings.HasPrefix(status, "PENDING") {
+					return rules, "PENDING", nil
+				}
+			}
+			return rules, "COMPLETED", nil
+		},
+		Timeout:      timeout,
+		Delay:        3 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// applyNatRuleSet makes the managed rules of the gateway the same as the desired ones. Only the remote rules matching
+// the managed rules (the rules in the state) are deleted if they are no longer desired, the others are kept.
+func applyNatRuleSet(ctx context.Context, d *schema.ResourceData, meta interface{}, def *natRuleSetDefinition,
+	desiredList, managedList []interface{}, timeout time.Duration) error {
+	var (
+		cfg       = meta.(*config.Config)
+		region    = cfg.GetRegion(d)
+		gatewayId = d.Get(def.gatewayKey).(string)
+	)
+	client, err := cfg.NewServiceClient("nat", region)
+	if err != nil {
+		return fmt.Errorf("error creating NAT client: %s", err)
+	}
+
+	config.MutexKV.Lock(gatewayId)
+	defer config.MutexKV.Unlock(gatewayId)
+
+	remoteList, err := listNatRuleSetRules(client, def, gatewayId)
+	if err != nil {
+		return fmt.Errorf("error retrieving %ss of the gateway (%s): %s", def.ruleType, gatewayId, err)
+	}
+	remoteMap := make(map[string]interface{}, len(remoteList))
+	for _, remote := range remoteList {
+		if key := def.remoteKey(remote); remoteMap[key] == nil {
+			remoteMap[key] = remote
+		}
+	}
+
+	toCreate := make([]map[string]interface{}, 0)
+	toUpdate := make(map[string]map[string]interface{})
+	for _, v := range desiredList {
+		desired := v.(map[string]interface{})
+		key := def.desiredKey(desired)
+		remote, ok := remoteMap[key]
+		if !ok {
+			toCreate = append(toCreate, def.buildCreateBody(gatewayId, desired))
+			continue
+		}
+		delete(remoteMap, key)
+
+		body, err := def.buildUpdateBody(meta, region, gatewayId, desired, remote)
+		if err != nil {
+			return err
+		}
+		if body != nil {
+			toUpdate[utils.PathSearch("id", remote, "").(string)] = body
+		}
+	}
+	toDelete := make([]string, 0)
+	for _, v := range managedList {
+		if remote, ok := remoteMap[def.desiredKey(v.(map[string]interface{}))]; ok {
+			toDelete = append(toDelete, utils.PathSearch("id", remote, "").(string))
+		}
+	}
+	log.Printf("[DEBUG] applying %ss of the gateway (%s): %d to create, %d to update, %d to delete", def.ruleType,
+		gatewayId, len(toCreate), len(toUpdate), len(toDelete))
+
+	// Delete the rules first to release the ports and EIPs used by the new rules.
+	for _, ruleId := range toDelete {
+		if err := deleteNatRuleSetRule(client, def, gatewayId, ruleId); err != nil {
+			return err
+		}
+	}
+	if def.asynchronous && len(toDelete) > 0 {
+		if err := waitForNatRuleSetStable(ctx, client, def, gatewayId, toDelete, timeout); err != nil {
+			return fmt.Errorf("error waiting for the %ss to be deleted: %s", def.ruleType, err)
+		}
+	}
+
+	for ruleId, body := range toUpdate {
+		if err := updateNatRuleSetRule(client, def, ruleId, body); err != nil {
+			return err
+		}
+	}
+	if err := createNatRuleSetRules(client, def, toCreate); err != nil {
+		return err
+	}
+	if def.asynchronous && len(toUpdate)+len(toCreate) > 0 {
+		if err := waitForNatRuleSetStable(ctx, client, def, gatewayId, nil, timeout); err != nil {
+			return fmt.Errorf("error waiting for the %ss to be active: %s", def.ruleType, err)
+		}
+	}
+	return nil
+}
+
+func natRuleSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{},
+	def *natRuleSetDefinition) diag.Diagnostics {
+	gatewayId := d.Get(def.gatewayKey).(string)
+	// The existing rules which are not declared are reported as unmanaged by the read instead of being deleted.
+	err := applyNatRuleSet(ctx, d, meta, def, d.Get("rules").(*schema.Set).List(), nil,
+		d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(gatewayId)
+
+	return natRuleSetRead(ctx, d, meta, def)
+}
+
+func natRuleSetRead(_ context.Context, d *schema.ResourceData, meta interface{},
+	def *natRuleSetDefinition) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("nat", region)
+	if err != nil {
+		return diag.Errorf("error creating NAT client: %s", err)
+	}
+
+	gatewayPath := client.Endpoint + def.gatewayUrl
+	gatewayPath = strings.ReplaceAll(gatewayPath, "{project_id}", client.ProjectID)
+	gatewayPath = strings.ReplaceAll(gatewayPath, "{gateway_id}", d.Id())
+	gatewayOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	if _, err := client.Request("GET", gatewayPath, &gatewayOpt); err != nil {
+		return common.CheckDeletedDiag(d, err, fmt.Sprintf("error retrieving the gateway (%s)", d.Id()))
+	}
+
+	remoteList, err := listNatRuleSetRules(client, def, d.Id())
+	if err != nil {
+		return diag.Errorf("error retrieving %ss of the gateway (%s): %s", def.ruleType, d.Id(), err)
+	}
+
+	stateRules := make(map[string]map[string]interface{})
+	for _, v := range d.Get("rules").(*schema.Set).List() {
+		rule := v.(map[string]interface{})
+		stateRules[def.desiredKey(rule)] = rule
+	}
+
+	// Only the rules managed by this resource are saved, the others are reported as warnings, including the ones after
+	// the import.
+	rules := make([]interface{}, 0, len(stateRules))
+	unmanaged := make([]string, 0)
+	for _, remote := range remoteList {
+		key := def.remoteKey(remote)
+		stateRule, ok := stateRules[key]
+		if !ok {
+			unmanaged = append(unmanaged, utils.PathSearch("id", remote, "").(string))
+			continue
+		}
+		// Only the first remote rule with the same key is managed.
+		delete(stateRules, key)
+		rules = append(rules, def.flattenRule(remote, stateRule))
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set(def.gatewayKey, d.Id()),
+		d.Set("rules", rules),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(unmanaged) > 0 {
+		sort.Strings(unmanaged)
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Found %d unmanaged %ss on the gateway (%s)", len(unmanaged), def.ruleType, d.Id()),
+				Detail: fmt.Sprintf("The %ss (%s) are not managed by this resource, declare them in the "+
+					"configuration to manage them.", def.ruleType, strings.Join(unmanaged, ", ")),
+			},
+		}
+	}
+	return nil
+}
+
+func natRuleSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{},
+	def *natRuleSetDefinition) diag.Diagnostics {
+	oldRules, newRules := d.GetChange("rules")
+	err := applyNatRuleSet(ctx, d, meta, def, newRules.(*schema.Set).List(), oldRules.(*schema.Set).List(),
+		d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return natRuleSetRead(ctx, d, meta, def)
+}
+
+func natRuleSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{},
+	def *natRuleSetDefinition) diag.Diagnostics {
+	err := applyNatRuleSet(ctx, d, meta, def, nil, d.Get("rules").(*schema.Set).List(), d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.Errorf("error deleting %ss of the gateway (%s): %s", def.ruleType, d.Id(), err)
+	}
+	return nil
+}
+
+func natRuleSetImport(def *natRuleSetDefinition) schema.StateContextFunc {
+	return func(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+		return []*schema.ResourceData{d}, d.Set(def.gatewayKey, d.Id())
+	}
+}
+
+// natPortRange is a closed range of ports.
+type natPortRange struct {
+	first int
+	last  int
+}
+
+// parseNatPortRange parses the port range (e.g. "80-90") or the single port (e.g. "80").
+func parseNatPortRange(value string) (*natPortRange, error) {
+	parts := strings.SplitN(strings.TrimSpace(value), "-", 2)
+	first, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid port range: %q", value)
+	}
+	last := first
+	if len(parts) == 2 {
+		if last, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil {
+			return nil, fmt.Errorf("invalid port range: %q", value)
+		}
+	}
+	if first > last {
+		return nil, fmt.Errorf("invalid port range: %q, the start port is greater than the end port", value)
+	}
+	return &natPortRange{first: first, last: last}, nil
+}
+
+// buildNatPortRange returns the port range if it is specified, otherwise the single port.
+func buildNatPortRange(port int, portRange string) (*natPortRange, error) {
+	if portRange != "" {
+		return parseNatPortRange(portRange)
+	}
+	return &natPortRange{first: port, last: port}, nil
+}
+
+func (r *natPortRange) String() string {
+	return fmt.Sprintf("%d-%d", r.first, r.last)
+}
+
+func (r *natPortRange) size() int {
+	return r.last - r.first + 1
+}
+
+func (r *natPortRange) overlaps(other *natPortRange) bool {
+	return r.first <= other.last && other.first <= r.last
+}
+
+// isSameStringSet checks whether the two comma separated strings contain the same elements.
+func isSameStringSet(a, b string) bool {
+	listA, listB := splitNatIds(a), splitNatIds(b)
+	if len(listA) != len(listB) {
+		return false
+	}
+	sort.Strings(listA)
+	sort.Strings(listB)
+	return strings.Join(listA, ",") == strings.Join(listB, ",")
+}
+
+func splitNatIds(value string) []string {
+	result := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// natRemoteInt converts the remote number or numeric string to int.
+func natRemoteInt(remote interface{}, expression string) int {
+	switch v := utils.PathSearch(expression, remote, nil).(type) {
+	case float64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+	return 0
+}
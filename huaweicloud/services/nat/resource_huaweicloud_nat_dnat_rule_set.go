@@ -0,0 +1,320 @@
+package nat
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var publicDnatRuleSetDefinition = &natRuleSetDefinition{
+	ruleType:       "DNAT rule",
+	gatewayKey:     "nat_gateway_id",
+	ruleKey:        "dnat_rule",
+	rulesKey:       "dnat_rules",
+	gatewayUrl:     "v2/{project_id}/nat_gateways/{gateway_id}",
+	listUrl:        "v2/{project_id}/dnat_rules",
+	updateUrl:      "v2/{project_id}/dnat_rules/{rule_id}",
+	deleteUrl:      "v2/{project_id}/nat_gateways/{gateway_id}/dnat_rules/{rule_id}",
+	batchCreateUrl: "v2/{project_id}/dnat_rules/batch",
+	asynchronous:   true,
+
+	desiredKey:      publicDnatRuleSetDesiredKey,
+	remoteKey:       publicDnatRuleSetRemoteKey,
+	buildCreateBody: buildPublicDnatRuleSetCreateBody,
+	buildUpdateBody: buildPublicDnatRuleSetUpdateBody,
+	flattenRule:     flattenPublicDnatRuleSetRule,
+	validateRules:   validatePublicDnatRuleSetRules,
+}
+
+// @API NAT GET /v2/{project_id}/nat_gateways/{nat_gateway_id}
+// @API NAT GET /v2/{project_id}/dnat_rules
+// @API NAT POST /v2/{project_id}/dnat_rules/batch
+// @API NAT PUT /v2/{project_id}/dnat_rules/{dnat_rule_id}
+// @API NAT DELETE /v2/{project_id}/nat_gateways/{nat_gateway_id}/dnat_rules/{dnat_rule_id}
+func ResourceDnatRuleSet() *schema.Resource {
+	def := publicDnatRuleSetDefinition
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetCreate(ctx, d, meta, def)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetRead(ctx, d, meta, def)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetUpdate(ctx, d, meta, def)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetDelete(ctx, d, meta, def)
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: natRuleSetImport(def),
+		},
+
+		CustomizeDiff: natRuleSetCustomizeDiff(def),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The region where the DNAT rules are located.",
+			},
+			"nat_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the NAT gateway to which the DNAT rules belong.",
+			},
+			"rules": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        publicDnatRuleSetRuleSchema(),
+				Description: "All DNAT rules of the NAT gateway.",
+			},
+		},
+	}
+}
+
+func publicDnatRuleSetRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"floating_ip_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the floating IP address.",
+			},
+			"global_eip_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the global EIP connected by the DNAT rule.",
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "any"}, false),
+				Description:  "The protocol type.",
+			},
+			"internal_service_port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The port used by ECSs or BMSs to provide services for external systems.",
+			},
+			"external_service_port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The port used by the floating IP to provide services for external systems.",
+			},
+			"internal_service_port_range": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The port range used by ECSs or BMSs to provide services for external systems.",
+			},
+			"external_service_port_range": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The port range used by the floating IP to provide services for external systems.",
+			},
+			"port_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The port ID of network.",
+			},
+			"private_ip": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The private IP address of a user.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the DNAT rule.",
+			},
+		},
+	}
+}
+
+func publicDnatRuleSetEip(floatingIpId, globalEipId string) string {
+	if floatingIpId != "" {
+		return floatingIpId
+	}
+	return globalEipId
+}
+
+func publicDnatRuleSetDesiredKey(rule map[string]interface{}) string {
+	portRange, err := buildNatPortRange(rule["external_service_port"].(int), rule["external_service_port_range"].(string))
+	portKey := rule["external_service_port_range"].(string)
+	if err == nil {
+		portKey = portRange.String()
+	}
+	return fmt.Sprintf("%s|%s|%s", publicDnatRuleSetEip(rule["floating_ip_id"].(string), rule["global_eip_id"].(string)),
+		rule["protocol"], portKey)
+}
+
+func publicDnatRuleSetRemoteKey(remote interface{}) string {
+	return publicDnatRuleSetDesiredKey(map[string]interface{}{
+		"floating_ip_id":              utils.PathSearch("floating_ip_id", remote, "").(string),
+		"global_eip_id":               utils.PathSearch("global_eip_id", remote, "").(string),
+		"protocol":                    utils.PathSearch("protocol", remote, "").(string),
+		"external_service_port":       natRemoteInt(remote, "external_service_port"),
+		"external_service_port_range": utils.PathSearch("external_service_port_range", remote, "").(string),
+	})
+}
+
+func buildPublicDnatRuleSetCreateBody(gatewayId string, rule map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"nat_gateway_id":              gatewayId,
+		"protocol":                    rule["protocol"],
+		"internal_service_port":       rule["internal_service_port"],
+		"external_service_port":       rule["external_service_port"],
+		"floating_ip_id":              utils.ValueIgnoreEmpty(rule["floating_ip_id"]),
+		"global_eip_id":               utils.ValueIgnoreEmpty(rule["global_eip_id"]),
+		"internal_service_port_range": utils.ValueIgnoreEmpty(rule["internal_service_port_range"]),
+		"external_service_port_range": utils.ValueIgnoreEmpty(rule["external_service_port_range"]),
+		"port_id":                     utils.ValueIgnoreEmpty(rule["port_id"]),
+		"private_ip":                  utils.ValueIgnoreEmpty(rule["private_ip"]),
+		"description":                 utils.ValueIgnoreEmpty(rule["description"]),
+	}
+}
+
+func buildPublicDnatRuleSetUpdateBody(_ interface{}, _, gatewayId string, rule map[string]interface{},
+	remote interface{}) (map[string]interface{}, error) {
+	current := flattenPublicDnatRuleSetRule(remote, rule)
+	isChanged := false
+	for _, key := range []string{"internal_service_port", "internal_service_port_range", "port_id", "private_ip",
+		"description"} {
+		if current[key] != rule[key] {
+			isChanged = true
+			break
+		}
+	}
+	if !isChanged {
+		return nil, nil
+	}
+
+	body := buildPublicDnatRuleSetCreateBody(gatewayId, rule)
+	body["description"] = rule["description"]
+	return body, nil
+}
+
+func flattenPublicDnatRuleSetRule(remote interface{}, stateRule map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{
+		"floating_ip_id":              utils.PathSearch("floating_ip_id", remote, "").(string),
+		"global_eip_id":               utils.PathSearch("global_eip_id", remote, "").(string),
+		"protocol":                    utils.PathSearch("protocol", remote, "").(string),
+		"internal_service_port":       natRemoteInt(remote, "internal_service_port"),
+		"external_service_port":       natRemoteInt(remote, "external_service_port"),
+		"internal_service_port_range": utils.PathSearch("internal_service_port_range", remote, "").(string),
+		"external_service_port_range": utils.PathSearch("external_service_port_range", remote, "").(string),
+		"port_id":                     utils.PathSearch("port_id", remote, "").(string),
+		"private_ip":                  utils.PathSearch("private_ip", remote, "").(string),
+		"description":                 utils.PathSearch("description", remote, "").(string),
+	}
+
+	// The API returns both the port and the port range, and both the port ID and the private IP, only the one used in
+	// the configuration is kept.
+	useRange := result["external_service_port_range"] != "" && result["external_service_port"] == 0
+	usePortId := result["port_id"] != ""
+	if stateRule != nil {
+		useRange = stateRule["external_service_port_range"] != ""
+		usePortId = stateRule["port_id"] != ""
+	}
+	if useRange {
+		result["internal_service_port"] = 0
+		result["external_service_port"] = 0
+	} else {
+		result["internal_service_port_range"] = ""
+		result["external_service_port_range"] = ""
+	}
+	if usePortId {
+		result["private_ip"] = ""
+	} else {
+		result["port_id"] = ""
+	}
+	return result
+}
+
+func validatePublicDnatRuleSetRules(rules []interface{}) error {
+	type dnatEntry struct {
+		key       string
+		eip       string
+		protocol  string
+		portRange *natPortRange
+	}
+
+	var mErr *multierror.Error
+	entries := make([]dnatEntry, 0, len(rules))
+	for _, v := range rules {
+		rule, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := publicDnatRuleSetDesiredKey(rule)
+		if rule["floating_ip_id"] != "" && rule["global_eip_id"] != "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("DNAT rule (%s): only one of floating_ip_id and "+
+				"global_eip_id can be specified", key))
+		}
+		if rule["port_id"] != "" && rule["private_ip"] != "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("DNAT rule (%s): only one of port_id and private_ip can be "+
+				"specified", key))
+		}
+
+		internalRange, err := buildNatPortRange(rule["internal_service_port"].(int),
+			rule["internal_service_port_range"].(string))
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("DNAT rule (%s): %s", key, err))
+			continue
+		}
+		externalRange, err := buildNatPortRange(rule["external_service_port"].(int),
+			rule["external_service_port_range"].(string))
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("DNAT rule (%s): %s", key, err))
+			continue
+		}
+		if internalRange.size() != externalRange.size() {
+			mErr = multierror.Append(mErr, fmt.Errorf("DNAT rule (%s): the internal port range (%s) and the "+
+				"external port range (%s) must have the same size", key, internalRange, externalRange))
+		}
+
+		eip := publicDnatRuleSetEip(rule["floating_ip_id"].(string), rule["global_eip_id"].(string))
+		// The EIP may be unknown during the plan.
+		if eip == "" {
+			continue
+		}
+		entries = append(entries, dnatEntry{key: key, eip: eip, protocol: rule["protocol"].(string),
+			portRange: externalRange})
+	}
+
+	for i := 0; i < len(entries); i++ {
+		for j := i + 1; j < len(entries); j++ {
+			a, b := entries[i], entries[j]
+			if a.eip != b.eip {
+				continue
+			}
+			if a.protocol == "any" || b.protocol == "any" {
+				mErr = multierror.Append(mErr, fmt.Errorf("DNAT rules (%s) and (%s) conflict: the EIP used by the "+
+					"rule with any protocol cannot be used by other DNAT rules", a.key, b.key))
+				continue
+			}
+			if a.protocol == b.protocol && a.portRange.overlaps(b.portRange) {
+				mErr = multierror.Append(mErr, fmt.Errorf("DNAT rules (%s) and (%s) conflict: the external port "+
+					"ranges overlap", a.key, b.key))
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
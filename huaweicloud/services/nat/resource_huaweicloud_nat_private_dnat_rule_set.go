@@ -0,0 +1,246 @@
+package nat
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var privateDnatRuleSetDefinition = &natRuleSetDefinition{
+	ruleType:   "private DNAT rule",
+	gatewayKey: "gateway_id",
+	ruleKey:    "dnat_rule",
+	rulesKey:   "dnat_rules",
+	gatewayUrl: "v3/{project_id}/private-nat/gateways/{gateway_id}",
+	listUrl:    "v3/{project_id}/private-nat/dnat-rules",
+	updateUrl:  "v3/{project_id}/private-nat/dnat-rules/{rule_id}",
+	deleteUrl:  "v3/{project_id}/private-nat/dnat-rules/{rule_id}",
+
+	desiredKey:      privateDnatRuleSetDesiredKey,
+	remoteKey:       privateDnatRuleSetRemoteKey,
+	buildCreateBody: buildPrivateDnatRuleSetCreateBody,
+	buildUpdateBody: buildPrivateDnatRuleSetUpdateBody,
+	flattenRule:     flattenPrivateDnatRuleSetRule,
+	validateRules:   validatePrivateDnatRuleSetRules,
+}
+
+// @API NAT GET /v3/{project_id}/private-nat/gateways/{gateway_id}
+// @API NAT GET /v3/{project_id}/private-nat/dnat-rules
+// @API NAT POST /v3/{project_id}/private-nat/dnat-rules
+// @API NAT PUT /v3/{project_id}/private-nat/dnat-rules/{dnat_rule_id}
+// @API NAT DELETE /v3/{project_id}/private-nat/dnat-rules/{dnat_rule_id}
+func ResourcePrivateDnatRuleSet() *schema.Resource {
+	def := privateDnatRuleSetDefinition
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetCreate(ctx, d, meta, def)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetRead(ctx, d, meta, def)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetUpdate(ctx, d, meta, def)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetDelete(ctx, d, meta, def)
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: natRuleSetImport(def),
+		},
+
+		CustomizeDiff: natRuleSetCustomizeDiff(def),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The region where the DNAT rules are located.",
+			},
+			"gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The private NAT gateway ID to which the DNAT rules belong.",
+			},
+			"rules": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        privateDnatRuleSetRuleSchema(),
+				Description: "All DNAT rules of the private NAT gateway.",
+			},
+		},
+	}
+}
+
+func privateDnatRuleSetRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"transit_ip_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the transit IP for private NAT.",
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "any",
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "any"}, false),
+				Description:  "The protocol type.",
+			},
+			"transit_service_port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The port of the transit IP.",
+			},
+			"internal_service_port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The port of the backend instance.",
+			},
+			"backend_interface_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The network interface ID of the backend instance.",
+			},
+			"backend_private_ip": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The private IP address of the backend instance.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the DNAT rule.",
+			},
+		},
+	}
+}
+
+func privateDnatRuleSetDesiredKey(rule map[string]interface{}) string {
+	return fmt.Sprintf("%s|%s|%d", rule["transit_ip_id"], rule["protocol"], rule["transit_service_port"])
+}
+
+func privateDnatRuleSetRemoteKey(remote interface{}) string {
+	return privateDnatRuleSetDesiredKey(map[string]interface{}{
+		"transit_ip_id":        utils.PathSearch("transit_ip_id", remote, "").(string),
+		"protocol":             utils.PathSearch("protocol", remote, "").(string),
+		"transit_service_port": natRemoteInt(remote, "transit_service_port"),
+	})
+}
+
+func buildPrivateDnatRuleSetCreateBody(gatewayId string, rule map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"gateway_id":            gatewayId,
+		"transit_ip_id":         rule["transit_ip_id"],
+		"protocol":              utils.ValueIgnoreEmpty(rule["protocol"]),
+		"network_interface_id":  utils.ValueIgnoreEmpty(rule["backend_interface_id"]),
+		"private_ip_address":    utils.ValueIgnoreEmpty(rule["backend_private_ip"]),
+		"internal_service_port": convertIntToStr(rule["internal_service_port"].(int)),
+		"transit_service_port":  convertIntToStr(rule["transit_service_port"].(int)),
+		"description":           utils.ValueIgnoreEmpty(rule["description"]),
+	}
+}
+
+func buildPrivateDnatRuleSetUpdateBody(_ interface{}, _, _ string, rule map[string]interface{},
+	remote interface{}) (map[string]interface{}, error) {
+	current := flattenPrivateDnatRuleSetRule(remote, rule)
+	isChanged := false
+	for _, key := range []string{"internal_service_port", "backend_interface_id", "backend_private_ip",
+		"description"} {
+		if current[key] != rule[key] {
+			isChanged = true
+			break
+		}
+	}
+	if !isChanged {
+		return nil, nil
+	}
+
+	return map[string]interface{}{
+		"transit_ip_id":         rule["transit_ip_id"],
+		"protocol":              utils.ValueIgnoreEmpty(rule["protocol"]),
+		"network_interface_id":  utils.ValueIgnoreEmpty(rule["backend_interface_id"]),
+		"private_ip_address":    utils.ValueIgnoreEmpty(rule["backend_private_ip"]),
+		"internal_service_port": convertIntToStr(rule["internal_service_port"].(int)),
+		"transit_service_port":  convertIntToStr(rule["transit_service_port"].(int)),
+		"description":           rule["description"],
+	}, nil
+}
+
+func flattenPrivateDnatRuleSetRule(remote interface{}, stateRule map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{
+		"transit_ip_id":         utils.PathSearch("transit_ip_id", remote, "").(string),
+		"protocol":              utils.PathSearch("protocol", remote, "").(string),
+		"transit_service_port":  natRemoteInt(remote, "transit_service_port"),
+		"internal_service_port": natRemoteInt(remote, "internal_service_port"),
+		"backend_interface_id":  utils.PathSearch("network_interface_id", remote, "").(string),
+		"backend_private_ip":    utils.PathSearch("private_ip_address", remote, "").(string),
+		"description":           utils.PathSearch("description", remote, "").(string),
+	}
+
+	// The API returns both the network interface ID and the private IP, only the one used in the configuration is
+	// kept.
+	useInterfaceId := result["backend_interface_id"] != ""
+	if stateRule != nil {
+		useInterfaceId = stateRule["backend_interface_id"] != ""
+	}
+	if useInterfaceId {
+		result["backend_private_ip"] = ""
+	} else {
+		result["backend_interface_id"] = ""
+	}
+	return result
+}
+
+func validatePrivateDnatRuleSetRules(rules []interface{}) error {
+	var mErr *multierror.Error
+	anyProtocolRules := make(map[string]string)
+	transitIpRules := make(map[string][]string)
+	for _, v := range rules {
+		rule, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := privateDnatRuleSetDesiredKey(rule)
+		if rule["backend_interface_id"] != "" && rule["backend_private_ip"] != "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("private DNAT rule (%s): only one of backend_interface_id "+
+				"and backend_private_ip can be specified", key))
+		}
+
+		transitIpId := rule["transit_ip_id"].(string)
+		// The transit IP may be unknown during the plan.
+		if transitIpId == "" {
+			continue
+		}
+		if rule["protocol"] == "any" {
+			anyProtocolRules[transitIpId] = key
+		}
+		transitIpRules[transitIpId] = append(transitIpRules[transitIpId], key)
+	}
+
+	for transitIpId, anyKey := range anyProtocolRules {
+		for _, key := range transitIpRules[transitIpId] {
+			if key != anyKey {
+				mErr = multierror.Append(mErr, fmt.Errorf("private DNAT rules (%s) and (%s) conflict: the transit "+
+					"IP used by the rule with any protocol cannot be used by other DNAT rules", anyKey, key))
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
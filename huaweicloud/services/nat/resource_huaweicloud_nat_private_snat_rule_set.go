@@ -0,0 +1,226 @@
+package nat
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var privateSnatRuleSetDefinition = &natRuleSetDefinition{
+	ruleType:   "private SNAT rule",
+	gatewayKey: "gateway_id",
+	ruleKey:    "snat_rule",
+	rulesKey:   "snat_rules",
+	gatewayUrl: "v3/{project_id}/private-nat/gateways/{gateway_id}",
+	listUrl:    "v3/{project_id}/private-nat/snat-rules",
+	updateUrl:  "v3/{project_id}/private-nat/snat-rules/{rule_id}",
+	deleteUrl:  "v3/{project_id}/private-nat/snat-rules/{rule_id}",
+
+	desiredKey:      privateSnatRuleSetDesiredKey,
+	remoteKey:       privateSnatRuleSetRemoteKey,
+	buildCreateBody: buildPrivateSnatRuleSetCreateBody,
+	buildUpdateBody: buildPrivateSnatRuleSetUpdateBody,
+	flattenRule:     flattenPrivateSnatRuleSetRule,
+	validateRules:   validatePrivateSnatRuleSetRules,
+}
+
+// @API NAT GET /v3/{project_id}/private-nat/gateways/{gateway_id}
+// @API NAT GET /v3/{project_id}/private-nat/snat-rules
+// @API NAT POST /v3/{project_id}/private-nat/snat-rules
+// @API NAT PUT /v3/{project_id}/private-nat/snat-rules/{snat_rule_id}
+// @API NAT DELETE /v3/{project_id}/private-nat/snat-rules/{snat_rule_id}
+func ResourcePrivateSnatRuleSet() *schema.Resource {
+	def := privateSnatRuleSetDefinition
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetCreate(ctx, d, meta, def)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetRead(ctx, d, meta, def)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetUpdate(ctx, d, meta, def)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetDelete(ctx, d, meta, def)
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: natRuleSetImport(def),
+		},
+
+		CustomizeDiff: natRuleSetCustomizeDiff(def),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The region where the SNAT rules are located.",
+			},
+			"gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The private NAT gateway ID to which the SNAT rules belong.",
+			},
+			"rules": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        privateSnatRuleSetRuleSchema(),
+				Description: "All SNAT rules of the private NAT gateway.",
+			},
+		},
+	}
+}
+
+func privateSnatRuleSetRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"transit_ip_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the transit IPs associated with the private SNAT rule.",
+			},
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The subnet ID of the match rule.",
+			},
+			"cidr": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The CIDR block of the match rule.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the SNAT rule.",
+			},
+		},
+	}
+}
+
+func privateSnatRuleSetDesiredKey(rule map[string]interface{}) string {
+	if subnetId := rule["subnet_id"].(string); subnetId != "" {
+		return "subnet:" + subnetId
+	}
+	return "cidr:" + rule["cidr"].(string)
+}
+
+func privateSnatRuleSetRemoteKey(remote interface{}) string {
+	return privateSnatRuleSetDesiredKey(map[string]interface{}{
+		"subnet_id": utils.PathSearch("virsubnet_id", remote, "").(string),
+		"cidr":      utils.PathSearch("cidr", remote, "").(string),
+	})
+}
+
+func buildPrivateSnatRuleSetCreateBody(gatewayId string, rule map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"gateway_id":     gatewayId,
+		"transit_ip_ids": utils.ExpandToStringList(rule["transit_ip_ids"].([]interface{})),
+		"virsubnet_id":   utils.ValueIgnoreEmpty(rule["subnet_id"]),
+		"cidr":           utils.ValueIgnoreEmpty(rule["cidr"]),
+		"description":    utils.ValueIgnoreEmpty(rule["description"]),
+	}
+}
+
+func buildPrivateSnatRuleSetUpdateBody(_ interface{}, _, _ string, rule map[string]interface{},
+	remote interface{}) (map[string]interface{}, error) {
+	transitIpIds := utils.ExpandToStringList(rule["transit_ip_ids"].([]interface{}))
+	remoteTransitIpIds := utils.ExpandToStringList(utils.PathSearch("transit_ip_associations[*].transit_ip_id",
+		remote, make([]interface{}, 0)).([]interface{}))
+	isTransitIpChanged := !isSameStringSet(strings.Join(transitIpIds, ","), strings.Join(remoteTransitIpIds, ","))
+	if !isTransitIpChanged && rule["description"] == utils.PathSearch("description", remote, "").(string) {
+		return nil, nil
+	}
+
+	return map[string]interface{}{
+		"transit_ip_ids": transitIpIds,
+		"description":    rule["description"],
+	}, nil
+}
+
+func flattenPrivateSnatRuleSetRule(remote interface{}, stateRule map[string]interface{}) map[string]interface{} {
+	transitIpIds := utils.PathSearch("transit_ip_associations[*].transit_ip_id", remote,
+		make([]interface{}, 0)).([]interface{})
+	// The order of the transit IP IDs returned by the API may be different from the configuration.
+	if stateRule != nil {
+		stateTransitIpIds := utils.ExpandToStringList(stateRule["transit_ip_ids"].([]interface{}))
+		if isSameStringSet(strings.Join(stateTransitIpIds, ","),
+			strings.Join(utils.ExpandToStringList(transitIpIds), ",")) {
+			transitIpIds = stateRule["transit_ip_ids"].([]interface{})
+		}
+	}
+
+	result := map[string]interface{}{
+		"transit_ip_ids": transitIpIds,
+		"subnet_id":      utils.PathSearch("virsubnet_id", remote, "").(string),
+		"cidr":           utils.PathSearch("cidr", remote, "").(string),
+		"description":    utils.PathSearch("description", remote, "").(string),
+	}
+	// The CIDR of the subnet is returned when the SNAT rule is connected to a subnet.
+	if result["subnet_id"] != "" {
+		result["cidr"] = ""
+	}
+	return result
+}
+
+func validatePrivateSnatRuleSetRules(rules []interface{}) error {
+	var mErr *multierror.Error
+	// The CIDR blocks can be the proper subsets of others, but cannot be the same network.
+	networks := make(map[string]string)
+	for _, v := range rules {
+		rule, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := privateSnatRuleSetDesiredKey(rule)
+		if rule["subnet_id"] != "" && rule["cidr"] != "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("private SNAT rule (%s): only one of subnet_id and cidr can "+
+				"be specified", key))
+		}
+		seen := make(map[string]bool)
+		for _, transitIpId := range utils.ExpandToStringList(rule["transit_ip_ids"].([]interface{})) {
+			if transitIpId != "" && seen[transitIpId] {
+				mErr = multierror.Append(mErr, fmt.Errorf("private SNAT rule (%s): the transit IP (%s) is "+
+					"specified more than once", key, transitIpId))
+			}
+			seen[transitIpId] = true
+		}
+
+		cidr := rule["cidr"].(string)
+		// The CIDR may be unknown during the plan.
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("private SNAT rule (%s): invalid CIDR: %s", key, err))
+			continue
+		}
+		if otherKey, ok := networks[ipNet.String()]; ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("private SNAT rules (%s) and (%s) conflict: the CIDR "+
+				"blocks are the same network (%s)", otherKey, key, ipNet))
+			continue
+		}
+		networks[ipNet.String()] = key
+	}
+	return mErr.ErrorOrNil()
+}
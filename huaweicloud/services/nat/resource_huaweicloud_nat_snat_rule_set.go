@@ -0,0 +1,271 @@
+package nat
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var publicSnatRuleSetDefinition = &natRuleSetDefinition{
+	ruleType:     "SNAT rule",
+	gatewayKey:   "nat_gateway_id",
+	ruleKey:      "snat_rule",
+	rulesKey:     "snat_rules",
+	gatewayUrl:   "v2/{project_id}/nat_gateways/{gateway_id}",
+	listUrl:      "v2/{project_id}/snat_rules",
+	updateUrl:    "v2/{project_id}/snat_rules/{rule_id}",
+	deleteUrl:    "v2/{project_id}/nat_gateways/{gateway_id}/snat_rules/{rule_id}",
+	asynchronous: true,
+
+	desiredKey:      publicSnatRuleSetDesiredKey,
+	remoteKey:       publicSnatRuleSetRemoteKey,
+	buildCreateBody: buildPublicSnatRuleSetCreateBody,
+	buildUpdateBody: buildPublicSnatRuleSetUpdateBody,
+	flattenRule:     flattenPublicSnatRuleSetRule,
+	validateRules:   validatePublicSnatRuleSetRules,
+}
+
+// @API NAT GET /v2/{project_id}/nat_gateways/{nat_gateway_id}
+// @API NAT GET /v2/{project_id}/snat_rules
+// @API NAT POST /v2/{project_id}/snat_rules
+// @API NAT PUT /v2/{project_id}/snat_rules/{snat_rule_id}
+// @API NAT DELETE /v2/{project_id}/nat_gateways/{nat_gateway_id}/snat_rules/{snat_rule_id}
+// @API EIP GET /v1/{project_id}/publicips/{publicip_id}
+func ResourceSnatRuleSet() *schema.Resource {
+	def := publicSnatRuleSetDefinition
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetCreate(ctx, d, meta, def)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetRead(ctx, d, meta, def)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetUpdate(ctx, d, meta, def)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return natRuleSetDelete(ctx, d, meta, def)
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: natRuleSetImport(def),
+		},
+
+		CustomizeDiff: natRuleSetCustomizeDiff(def),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The region where the SNAT rules are located.",
+			},
+			"nat_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the NAT gateway to which the SNAT rules belong.",
+			},
+			"rules": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        publicSnatRuleSetRuleSchema(),
+				Description: "All SNAT rules of the NAT gateway.",
+			},
+		},
+	}
+}
+
+func publicSnatRuleSetRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"floating_ip_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The IDs of floating IPs connected by SNAT rule, separated by commas.",
+			},
+			"global_eip_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The IDs of global EIPs connected by SNAT rule, separated by commas.",
+			},
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The network IDs of subnet connected by SNAT rule (VPC side).",
+			},
+			"cidr": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The CIDR block connected by SNAT rule (DC side).",
+			},
+			"source_type": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntInSlice([]int{0, 1}),
+				Description:  "The resource type of the SNAT rule.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the SNAT rule.",
+			},
+		},
+	}
+}
+
+func publicSnatRuleSetDesiredKey(rule map[string]interface{}) string {
+	if subnetId := rule["subnet_id"].(string); subnetId != "" {
+		return "subnet:" + subnetId
+	}
+	return "cidr:" + rule["cidr"].(string)
+}
+
+func publicSnatRuleSetRemoteKey(remote interface{}) string {
+	return publicSnatRuleSetDesiredKey(map[string]interface{}{
+		"subnet_id": utils.PathSearch("network_id", remote, "").(string),
+		"cidr":      utils.PathSearch("cidr", remote, "").(string),
+	})
+}
+
+func buildPublicSnatRuleSetCreateBody(gatewayId string, rule map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"nat_gateway_id": gatewayId,
+		"floating_ip_id": utils.ValueIgnoreEmpty(rule["floating_ip_id"]),
+		"global_eip_id":  utils.ValueIgnoreEmpty(rule["global_eip_id"]),
+		"network_id":     utils.ValueIgnoreEmpty(rule["subnet_id"]),
+		"cidr":           utils.ValueIgnoreEmpty(rule["cidr"]),
+		"source_type":    utils.ValueIgnoreEmpty(rule["source_type"]),
+		"description":    utils.ValueIgnoreEmpty(rule["description"]),
+	}
+}
+
+// buildPublicSnatRuleSetUpdateBody builds the update body, the floating IPs are updated by the addresses.
+func buildPublicSnatRuleSetUpdateBody(meta interface{}, region, gatewayId string, rule map[string]interface{},
+	remote interface{}) (map[string]interface{}, error) {
+	floatingIpId := rule["floating_ip_id"].(string)
+	globalEipId := rule["global_eip_id"].(string)
+	isEipChanged := !isSameStringSet(floatingIpId, utils.PathSearch("floating_ip_id", remote, "").(string))
+	isGlobalEipChanged := !isSameStringSet(globalEipId, utils.PathSearch("global_eip_id", remote, "").(string))
+	isDescriptionChanged := rule["description"] != utils.PathSearch("description", remote, "").(string)
+	if !isEipChanged && !isGlobalEipChanged && !isDescriptionChanged {
+		return nil, nil
+	}
+
+	body := map[string]interface{}{
+		"nat_gateway_id": gatewayId,
+		"description":    rule["description"],
+	}
+	if isEipChanged && floatingIpId != "" {
+		eipClient, err := meta.(*config.Config).NewServiceClient("vpc", region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating VPC v1 client: %s", err)
+		}
+
+		eipIds := splitNatIds(floatingIpId)
+		eipAddrs := make([]string, len(eipIds))
+		for i, eipId := range eipIds {
+			eipResp, err := getEipAddress(eipClient, eipId)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching EIP (%s): %s", eipId, err)
+			}
+			eipAddrs[i] = utils.PathSearch("publicip.public_ip_address", eipResp, "").(string)
+		}
+		body["public_ip_address"] = strings.Join(eipAddrs, ",")
+	}
+	if isGlobalEipChanged {
+		body["global_eip_id"] = utils.ValueIgnoreEmpty(globalEipId)
+	}
+	return body, nil
+}
+
+func flattenPublicSnatRuleSetRule(remote interface{}, stateRule map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{
+		"floating_ip_id": utils.PathSearch("floating_ip_id", remote, "").(string),
+		"global_eip_id":  utils.PathSearch("global_eip_id", remote, "").(string),
+		"subnet_id":      utils.PathSearch("network_id", remote, "").(string),
+		"cidr":           utils.PathSearch("cidr", remote, "").(string),
+		"source_type":    natRemoteInt(remote, "source_type"),
+		"description":    utils.PathSearch("description", remote, "").(string),
+	}
+
+	// The order of the EIP IDs returned by the API may be different from the configuration.
+	if stateRule != nil {
+		for _, key := range []string{"floating_ip_id", "global_eip_id"} {
+			if isSameStringSet(stateRule[key].(string), result[key].(string)) {
+				result[key] = stateRule[key]
+			}
+		}
+	}
+	// The CIDR of the subnet is returned when the SNAT rule is connected to a subnet.
+	if result["subnet_id"] != "" {
+		result["cidr"] = ""
+	}
+	return result
+}
+
+func validatePublicSnatRuleSetRules(rules []interface{}) error {
+	var mErr *multierror.Error
+	// The CIDR blocks can be the proper subsets of others, but cannot be the same network.
+	networks := make(map[string]string)
+	for _, v := range rules {
+		rule, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := publicSnatRuleSetDesiredKey(rule)
+		if rule["floating_ip_id"] != "" && rule["global_eip_id"] != "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("SNAT rule (%s): only one of floating_ip_id and "+
+				"global_eip_id can be specified", key))
+		}
+		if rule["subnet_id"] != "" && rule["cidr"] != "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("SNAT rule (%s): only one of subnet_id and cidr can be "+
+				"specified", key))
+		}
+		for _, eipKey := range []string{"floating_ip_id", "global_eip_id"} {
+			seen := make(map[string]bool)
+			for _, eipId := range splitNatIds(rule[eipKey].(string)) {
+				if seen[eipId] {
+					mErr = multierror.Append(mErr, fmt.Errorf("SNAT rule (%s): the EIP (%s) is specified more "+
+						"than once", key, eipId))
+				}
+				seen[eipId] = true
+			}
+		}
+
+		cidr := rule["cidr"].(string)
+		// The CIDR may be unknown during the plan.
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("SNAT rule (%s): invalid CIDR: %s", key, err))
+			continue
+		}
+		if otherKey, ok := networks[ipNet.String()]; ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("SNAT rules (%s) and (%s) conflict: the CIDR "+
+				"blocks are the same network (%s)", otherKey, key, ipNet))
+			continue
+		}
+		networks[ipNet.String()] = key
+	}
+	return mErr.ErrorOrNil()
+}
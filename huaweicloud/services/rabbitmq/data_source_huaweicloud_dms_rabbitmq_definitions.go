@@ -0,0 +1,72 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// DataSourceDmsRabbitmqDefinitions is the impl for huaweicloud_dms_rabbitmq_definitions data source, which exports
+// the definitions of all objects of the RabbitMQ instance for backups.
+// @API RabbitMQ GET /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts
+// @API RabbitMQ GET /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges
+// @API RabbitMQ GET /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/queues
+// @API RabbitMQ GET /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges/{exchange}/binding
+// @API RabbitMQ GET /v2/{project_id}/instances/{instance_id}/users
+func DataSourceDmsRabbitmqDefinitions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDmsRabbitmqDefinitionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: `The region where the RabbitMQ instance is located.`,
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The ID of the RabbitMQ instance.`,
+			},
+			"definitions": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: `The RabbitMQ definitions of all objects of the instance, in JSON format.`,
+			},
+		},
+	}
+}
+
+func dataSourceDmsRabbitmqDefinitionsRead(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("dmsv2", region)
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	definitions, err := exportRabbitmqDefinitions(client, d.Get("instance_id").(string))
+	if err != nil {
+		return diag.Errorf("error exporting definitions of the RabbitMQ instance: %s", err)
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(id)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("definitions", definitions.String()),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
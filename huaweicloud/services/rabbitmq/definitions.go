@@ -0,0 +1,554 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// rabbitmqDefinitions is the subset of the standard RabbitMQ definitions which is supported by the DMS APIs.
+type rabbitmqDefinitions struct {
+	Vhosts      []rabbitmqDefinitionVhost      `json:"vhosts"`
+	Exchanges   []rabbitmqDefinitionExchange   `json:"exchanges"`
+	Queues      []rabbitmqDefinitionQueue      `json:"queues"`
+	Bindings    []rabbitmqDefinitionBinding    `json:"bindings"`
+	Users       []rabbitmqDefinitionUser       `json:"users"`
+	Permissions []rabbitmqDefinitionPermission `json:"permissions"`
+}
+
+type rabbitmqDefinitionVhost struct {
+	Name string `json:"name"`
+}
+
+type rabbitmqDefinitionExchange struct {
+	Name       string                 `json:"name"`
+	Vhost      string                 `json:"vhost"`
+	Type       string                 `json:"type"`
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Internal   bool                   `json:"internal"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+type rabbitmqDefinitionQueue struct {
+	Name       string                 `json:"name"`
+	Vhost      string                 `json:"vhost"`
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+type rabbitmqDefinitionBinding struct {
+	Source          string `json:"source"`
+	Vhost           string `json:"vhost"`
+	Destination     string `json:"destination"`
+	DestinationType string `json:"destination_type"`
+	RoutingKey      string `json:"routing_key"`
+}
+
+type rabbitmqDefinitionUser struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type rabbitmqDefinitionPermission struct {
+	User      string `json:"user"`
+	Vhost     string `json:"vhost"`
+	Configure string `json:"configure"`
+	Write     string `json:"write"`
+	Read      string `json:"read"`
+}
+
+// The queue arguments which are supported by the DMS APIs, and the corresponding request parameters.
+var rabbitmqQueueArguments = map[string]string{
+	"x-dead-letter-exchange":    "dead_letter_exchange",
+	"x-dead-letter-routing-key": "dead_letter_routing_key",
+	"x-message-ttl":             "message_ttl",
+	"x-queue-mode":              "lazy_mode",
+}
+
+func (e rabbitmqDefinitionExchange) key() string {
+	return e.Vhost + "/" + e.Name
+}
+
+func (q rabbitmqDefinitionQueue) key() string {
+	return q.Vhost + "/" + q.Name
+}
+
+func (b rabbitmqDefinitionBinding) key() string {
+	return strings.Join([]string{b.Vhost, b.Source, b.DestinationType, b.Destination, b.RoutingKey}, "/")
+}
+
+func (p rabbitmqDefinitionPermission) key() string {
+	return p.User + "/" + p.Vhost
+}
+
+// isChanged checks whether the remote exchange is different from the declared one, only the declared arguments are
+// compared because the broker may add the other arguments.
+func (e rabbitmqDefinitionExchange) isChanged(declared rabbitmqDefinitionExchange) bool {
+	return e.Type != declared.Type || e.Durable != declared.Durable || e.AutoDelete != declared.AutoDelete ||
+		e.Internal != declared.Internal || isRabbitmqArgumentsChanged(e.Arguments, declared.Arguments)
+}
+
+// isChanged checks whether the remote queue is different from the declared one, only the declared arguments are
+// compared because the broker may add the other arguments.
+func (q rabbitmqDefinitionQueue) isChanged(declared rabbitmqDefinitionQueue) bool {
+	return q.Durable != declared.Durable || q.AutoDelete != declared.AutoDelete ||
+		isRabbitmqArgumentsChanged(q.Arguments, declared.Arguments)
+}
+
+func isRabbitmqArgumentsChanged(remote, declared map[string]interface{}) bool {
+	for argument, value := range declared {
+		if utils.JsonToString(remote[argument]) != utils.JsonToString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterRabbitmqArguments returns the remote arguments which are declared.
+func filterRabbitmqArguments(remote, declared map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for argument := range declared {
+		if value, ok := remote[argument]; ok {
+			result[argument] = value
+		}
+	}
+	return result
+}
+
+// findChangedRabbitmqObjects returns the keys of the exchanges and the queues whose attributes are changed from the
+// old definitions to the new definitions, these objects can only be recreated.
+func findChangedRabbitmqObjects(oldDefinitions, newDefinitions *rabbitmqDefinitions) []string {
+	oldExchanges := make(map[string]rabbitmqDefinitionExchange)
+	for _, v := range oldDefinitions.Exchanges {
+		oldExchanges[v.key()] = v
+	}
+	oldQueues := make(map[string]rabbitmqDefinitionQueue)
+	for _, v := range oldDefinitions.Queues {
+		oldQueues[v.key()] = v
+	}
+
+	result := make([]string, 0)
+	for _, v := range newDefinitions.Exchanges {
+		if old, ok := oldExchanges[v.key()]; ok && utils.JsonToString(old) != utils.JsonToString(v) {
+			result = append(result, fmt.Sprintf("exchange (%s)", v.key()))
+		}
+	}
+	for _, v := range newDefinitions.Queues {
+		if old, ok := oldQueues[v.key()]; ok && utils.JsonToString(old) != utils.JsonToString(v) {
+			result = append(result, fmt.Sprintf("queue (%s)", v.key()))
+		}
+	}
+	return result
+}
+
+// isDefaultRabbitmqExchange returns whether the exchange is created by the broker, such as the default exchange and
+// the amq.* exchanges, which can not be declared or deleted.
+func isDefaultRabbitmqExchange(name string) bool {
+	return name == "" || strings.HasPrefix(name, "amq.")
+}
+
+// parseRabbitmqDefinitions parses the definitions JSON, the unsupported sections such as policies and parameters, and
+// the unsupported fields such as tags of the users are ignored.
+func parseRabbitmqDefinitions(content string) (*rabbitmqDefinitions, error) {
+	var definitions rabbitmqDefinitions
+	if content == "" {
+		return &definitions, nil
+	}
+	if err := json.Unmarshal([]byte(content), &definitions); err != nil {
+		return nil, fmt.Errorf("error parsing the definitions: %s", err)
+	}
+	definitions.normalize()
+	return &definitions, nil
+}
+
+func (d *rabbitmqDefinitions) normalize() {
+	// The empty sections are marshaled as empty arrays rather than null.
+	if d.Vhosts == nil {
+		d.Vhosts = make([]rabbitmqDefinitionVhost, 0)
+	}
+	if d.Exchanges == nil {
+		d.Exchanges = make([]rabbitmqDefinitionExchange, 0)
+	}
+	if d.Queues == nil {
+		d.Queues = make([]rabbitmqDefinitionQueue, 0)
+	}
+	if d.Bindings == nil {
+		d.Bindings = make([]rabbitmqDefinitionBinding, 0)
+	}
+	if d.Users == nil {
+		d.Users = make([]rabbitmqDefinitionUser, 0)
+	}
+	if d.Permissions == nil {
+		d.Permissions = make([]rabbitmqDefinitionPermission, 0)
+	}
+	for i := range d.Exchanges {
+		if d.Exchanges[i].Arguments == nil {
+			d.Exchanges[i].Arguments = make(map[string]interface{})
+		}
+	}
+	for i := range d.Queues {
+		if d.Queues[i].Arguments == nil {
+			d.Queues[i].Arguments = make(map[string]interface{})
+		}
+	}
+	for i := range d.Bindings {
+		// Both Queue and queue are accepted by the API, but the type is always returned in lowercase.
+		d.Bindings[i].DestinationType = strings.ToLower(d.Bindings[i].DestinationType)
+	}
+
+	sort.Slice(d.Vhosts, func(i, j int) bool { return d.Vhosts[i].Name < d.Vhosts[j].Name })
+	sort.Slice(d.Exchanges, func(i, j int) bool { return d.Exchanges[i].key() < d.Exchanges[j].key() })
+	sort.Slice(d.Queues, func(i, j int) bool { return d.Queues[i].key() < d.Queues[j].key() })
+	sort.Slice(d.Bindings, func(i, j int) bool { return d.Bindings[i].key() < d.Bindings[j].key() })
+	sort.Slice(d.Users, func(i, j int) bool { return d.Users[i].Name < d.Users[j].Name })
+	sort.Slice(d.Permissions, func(i, j int) bool { return d.Permissions[i].key() < d.Permissions[j].key() })
+}
+
+func (d *rabbitmqDefinitions) String() string {
+	d.normalize()
+	content, err := json.Marshal(d)
+	if err != nil {
+		log.Printf("[ERROR] error marshaling the RabbitMQ definitions: %s", err)
+		return ""
+	}
+	return string(content)
+}
+
+// validate checks whether the definitions can be applied by the DMS APIs.
+func (d *rabbitmqDefinitions) validate() error {
+	var mErr *multierror.Error
+	users := make(map[string]bool)
+	for _, user := range d.Users {
+		users[user.Name] = true
+		if user.Password == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("the password of the user (%s) is required, the password_hash "+
+				"is not supported", user.Name))
+		}
+	}
+	for _, permission := range d.Permissions {
+		if !users[permission.User] {
+			mErr = multierror.Append(mErr, fmt.Errorf("the user (%s) of the permission is not defined",
+				permission.User))
+		}
+	}
+	for _, exchange := range d.Exchanges {
+		if isDefaultRabbitmqExchange(exchange.Name) {
+			mErr = multierror.Append(mErr, fmt.Errorf("the default exchange (%s) can not be declared", exchange.Name))
+		}
+	}
+	for _, queue := range d.Queues {
+		for argument := range queue.Arguments {
+			if _, ok := rabbitmqQueueArguments[argument]; !ok {
+				mErr = multierror.Append(mErr, fmt.Errorf("the argument (%s) of the queue (%s) is not supported",
+					argument, queue.Name))
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// filter returns the objects of the definitions which are also included by the managed definitions. Only the managed
+// arguments of the exchanges and the queues are kept, and the passwords which are not returned by the API are filled by
+// the managed ones.
+func (d *rabbitmqDefinitions) filter(managed *rabbitmqDefinitions) *rabbitmqDefinitions {
+	result := rabbitmqDefinitions{}
+
+	vhosts := make(map[string]bool)
+	for _, v := range managed.Vhosts {
+		vhosts[v.Name] = true
+	}
+	for _, v := range d.Vhosts {
+		if vhosts[v.Name] {
+			result.Vhosts = append(result.Vhosts, v)
+		}
+	}
+
+	exchanges := make(map[string]rabbitmqDefinitionExchange)
+	for _, v := range managed.Exchanges {
+		exchanges[v.key()] = v
+	}
+	for _, v := range d.Exchanges {
+		if declared, ok := exchanges[v.key()]; ok {
+			v.Arguments = filterRabbitmqArguments(v.Arguments, declared.Arguments)
+			result.Exchanges = append(result.Exchanges, v)
+		}
+	}
+
+	queues := make(map[string]rabbitmqDefinitionQueue)
+	for _, v := range managed.Queues {
+		queues[v.key()] = v
+	}
+	for _, v := range d.Queues {
+		if declared, ok := queues[v.key()]; ok {
+			v.Arguments = filterRabbitmqArguments(v.Arguments, declared.Arguments)
+			result.Queues = append(result.Queues, v)
+		}
+	}
+
+	bindings := make(map[string]bool)
+	for _, v := range managed.Bindings {
+		bindings[v.key()] = true
+	}
+	for _, v := range d.Bindings {
+		if bindings[v.key()] {
+			result.Bindings = append(result.Bindings, v)
+		}
+	}
+
+	passwords := make(map[string]string)
+	for _, v := range managed.Users {
+		passwords[v.Name] = v.Password
+	}
+	for _, v := range d.Users {
+		if password, ok := passwords[v.Name]; ok {
+			if v.Password == "" {
+				v.Password = password
+			}
+			result.Users = append(result.Users, v)
+		}
+	}
+
+	permissions := make(map[string]bool)
+	for _, v := range managed.Permissions {
+		permissions[v.key()] = true
+	}
+	for _, v := range d.Permissions {
+		if permissions[v.key()] {
+			result.Permissions = append(result.Permissions, v)
+		}
+	}
+	return &result
+}
+
+// normalizeRabbitmqDefinitions is the state function of the definitions, the invalid JSON is kept as it is and
+// reported by the validation.
+func normalizeRabbitmqDefinitions(v interface{}) string {
+	definitions, err := parseRabbitmqDefinitions(v.(string))
+	if err != nil {
+		return v.(string)
+	}
+	return definitions.String()
+}
+
+// encodeRabbitmqPathName encodes the name used in the request path, the slashes are replaced by the API convention.
+func encodeRabbitmqPathName(name string) string {
+	return url.PathEscape(strings.ReplaceAll(name, "/", "__F_SLASH__"))
+}
+
+// listRabbitmqItems queries all items of the paged list API.
+func listRabbitmqItems(client *golangsdk.ServiceClient, listPath, itemsKey string) ([]interface{}, error) {
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+
+	listPath += fmt.Sprintf("?limit=%d", pageLimit)
+	result := make([]interface{}, 0)
+	offset := 0
+	for {
+		listResp, err := client.Request("GET", listPath+fmt.Sprintf("&offset=%d", offset), &listOpt)
+		if err != nil {
+			return nil, err
+		}
+		listRespBody, err := utils.FlattenResponse(listResp)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, utils.PathSearch(itemsKey, listRespBody, make([]interface{}, 0)).([]interface{})...)
+
+		// `total` means the number of all items, and type is float64.
+		offset += pageLimit
+		total := utils.PathSearch("total", listRespBody, float64(0))
+		if int(total.(float64)) <= offset {
+			return result, nil
+		}
+	}
+}
+
+func buildRabbitmqVhostPath(client *golangsdk.ServiceClient, instanceId, vhost, httpUrl string) string {
+	path := client.Endpoint + httpUrl
+	path = strings.ReplaceAll(path, "{project_id}", client.ProjectID)
+	path = strings.ReplaceAll(path, "{instance_id}", instanceId)
+	return strings.ReplaceAll(path, "{vhost}", encodeRabbitmqPathName(vhost))
+}
+
+func listRabbitmqDefinitionVhosts(client *golangsdk.ServiceClient, instanceId string) ([]rabbitmqDefinitionVhost,
+	error) {
+	listPath := buildRabbitmqVhostPath(client, instanceId, "", "v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts")
+	items, err := listRabbitmqItems(client, listPath, "items")
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving vhosts: %s", err)
+	}
+
+	result := make([]rabbitmqDefinitionVhost, 0, len(items))
+	for _, v := range items {
+		result = append(result, rabbitmqDefinitionVhost{Name: utils.PathSearch("name", v, "").(string)})
+	}
+	return result, nil
+}
+
+// listRabbitmqDefinitionExchanges queries all exchanges of the vhost, including the default exchanges.
+func listRabbitmqDefinitionExchanges(client *golangsdk.ServiceClient, instanceId,
+	vhost string) ([]rabbitmqDefinitionExchange, error) {
+	listPath := buildRabbitmqVhostPath(client, instanceId, vhost,
+		"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges")
+	items, err := listRabbitmqItems(client, listPath, "items")
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving exchanges of the vhost (%s): %s", vhost, err)
+	}
+
+	result := make([]rabbitmqDefinitionExchange, 0, len(items))
+	for _, v := range items {
+		result = append(result, rabbitmqDefinitionExchange{
+			Name:       utils.PathSearch("name", v, "").(string),
+			Vhost:      vhost,
+			Type:       utils.PathSearch("type", v, "").(string),
+			Durable:    utils.PathSearch("durable", v, false).(bool),
+			AutoDelete: utils.PathSearch("auto_delete", v, false).(bool),
+			Internal:   utils.PathSearch("internal", v, false).(bool),
+			Arguments:  utils.PathSearch("arguments", v, make(map[string]interface{})).(map[string]interface{}),
+		})
+	}
+	return result, nil
+}
+
+func listRabbitmqDefinitionQueues(client *golangsdk.ServiceClient, instanceId,
+	vhost string) ([]rabbitmqDefinitionQueue, error) {
+	listPath := buildRabbitmqVhostPath(client, instanceId, vhost,
+		"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/queues")
+	items, err := listRabbitmqItems(client, listPath, "items")
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving queues of the vhost (%s): %s", vhost, err)
+	}
+
+	result := make([]rabbitmqDefinitionQueue, 0, len(items))
+	for _, v := range items {
+		result = append(result, rabbitmqDefinitionQueue{
+			Name:       utils.PathSearch("name", v, "").(string),
+			Vhost:      vhost,
+			Durable:    utils.PathSearch("durable", v, false).(bool),
+			AutoDelete: utils.PathSearch("auto_delete", v, false).(bool),
+			Arguments:  utils.PathSearch("arguments", v, make(map[string]interface{})).(map[string]interface{}),
+		})
+	}
+	return result, nil
+}
+
+// listRabbitmqExchangeBindingItems queries the raw bindings of the exchange, the properties key of each binding is
+// used to unbind it.
+func listRabbitmqExchangeBindingItems(client *golangsdk.ServiceClient, instanceId, vhost,
+	exchange string) ([]interface{}, error) {
+	listPath := buildRabbitmqVhostPath(client, instanceId, vhost,
+		"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges/{exchange}/binding")
+	listPath = strings.ReplaceAll(listPath, "{exchange}", encodeRabbitmqPathName(exchange))
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+
+	listResp, err := client.Request("GET", listPath, &listOpt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bindings of the exchange (%s) in the vhost (%s): %s", exchange,
+			vhost, err)
+	}
+	listRespBody, err := utils.FlattenResponse(listResp)
+	if err != nil {
+		return nil, err
+	}
+	return utils.PathSearch("items", listRespBody, make([]interface{}, 0)).([]interface{}), nil
+}
+
+func flattenRabbitmqDefinitionBinding(vhost, exchange string, item interface{}) rabbitmqDefinitionBinding {
+	return rabbitmqDefinitionBinding{
+		Source:          exchange,
+		Vhost:           vhost,
+		Destination:     utils.PathSearch("destination", item, "").(string),
+		DestinationType: strings.ToLower(utils.PathSearch("destination_type", item, "").(string)),
+		RoutingKey:      utils.PathSearch("routing_key", item, "").(string),
+	}
+}
+
+// listRabbitmqDefinitionUsers queries all users and their permissions.
+func listRabbitmqDefinitionUsers(client *golangsdk.ServiceClient, instanceId string) ([]rabbitmqDefinitionUser,
+	[]rabbitmqDefinitionPermission, error) {
+	listPath := buildRabbitmqVhostPath(client, instanceId, "", "v2/{project_id}/instances/{instance_id}/users")
+	items, err := listRabbitmqItems(client, listPath, "users")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving users: %s", err)
+	}
+
+	users := make([]rabbitmqDefinitionUser, 0, len(items))
+	permissions := make([]rabbitmqDefinitionPermission, 0)
+	for _, v := range items {
+		name := utils.PathSearch("access_key", v, "").(string)
+		users = append(users, rabbitmqDefinitionUser{
+			Name:     name,
+			Password: utils.PathSearch("secret_key", v, "").(string),
+		})
+		for _, vhost := range utils.PathSearch("vhosts", v, make([]interface{}, 0)).([]interface{}) {
+			permissions = append(permissions, rabbitmqDefinitionPermission{
+				User:      name,
+				Vhost:     utils.PathSearch("vhost", vhost, "").(string),
+				Configure: utils.PathSearch("conf", vhost, "").(string),
+				Write:     utils.PathSearch("write", vhost, "").(string),
+				Read:      utils.PathSearch("read", vhost, "").(string),
+			})
+		}
+	}
+	return users, permissions, nil
+}
+
+// exportRabbitmqDefinitions queries the definitions of all objects of the instance, the default exchanges are not
+// exported but their bindings are.
+func exportRabbitmqDefinitions(client *golangsdk.ServiceClient, instanceId string) (*rabbitmqDefinitions, error) {
+	vhosts, err := listRabbitmqDefinitionVhosts(client, instanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := rabbitmqDefinitions{Vhosts: vhosts}
+	for _, vhost := range vhosts {
+		exchanges, err := listRabbitmqDefinitionExchanges(client, instanceId, vhost.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, exchange := range exchanges {
+			if exchange.Name == "" {
+				continue
+			}
+			items, err := listRabbitmqExchangeBindingItems(client, instanceId, vhost.Name, exchange.Name)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				definitions.Bindings = append(definitions.Bindings,
+					flattenRabbitmqDefinitionBinding(vhost.Name, exchange.Name, item))
+			}
+			if !isDefaultRabbitmqExchange(exchange.Name) {
+				definitions.Exchanges = append(definitions.Exchanges, exchange)
+			}
+		}
+
+		queues, err := listRabbitmqDefinitionQueues(client, instanceId, vhost.Name)
+		if err != nil {
+			return nil, err
+		}
+		definitions.Queues = append(definitions.Queues, queues...)
+	}
+
+	definitions.Users, definitions.Permissions, err = listRabbitmqDefinitionUsers(client, instanceId)
+	if err != nil {
+		return nil, err
+	}
+	definitions.normalize()
+	return &definitions, nil
+}
@@ -0,0 +1,692 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+//nolint:revive
+// @API RabbitMQ GET /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts
+// @API RabbitMQ PUT /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts
+// @API RabbitMQ POST /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts
+// @API RabbitMQ GET /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges
+// @API RabbitMQ PUT /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges
+// @API RabbitMQ POST /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges
+// @API RabbitMQ GET /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/queues
+// @API RabbitMQ PUT /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/queues
+// @API RabbitMQ POST /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/queues
+// @API RabbitMQ GET /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges/{exchange}/binding
+// @API RabbitMQ POST /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges/{exchange}/binding
+// @API RabbitMQ DELETE /v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges/{exchange}/destination-type/{destination_type}/destination/{destination}/properties-key/{properties_key}/unbinding
+// @API RabbitMQ GET /v2/{project_id}/instances/{instance_id}/users
+// @API RabbitMQ POST /v2/{project_id}/instances/{instance_id}/users
+// @API RabbitMQ PUT /v2/{project_id}/instances/{instance_id}/users/{user_name}
+// @API RabbitMQ DELETE /v2/{project_id}/instances/{instance_id}/users/{user_name}
+
+// ResourceDmsRabbitmqDefinitions is the impl for huaweicloud_dms_rabbitmq_definitions resource, which reconciles the
+// vhosts, exchanges, queues, bindings, users and permissions of the RabbitMQ definitions JSON against the instance.
+func ResourceDmsRabbitmqDefinitions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDmsRabbitmqDefinitionsCreate,
+		ReadContext:   resourceDmsRabbitmqDefinitionsRead,
+		UpdateContext: resourceDmsRabbitmqDefinitionsUpdate,
+		DeleteContext: resourceDmsRabbitmqDefinitionsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: checkRabbitmqDefinitionsChange,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: `The region where the RabbitMQ instance is located.`,
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The ID of the RabbitMQ instance.`,
+			},
+			"definitions": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validateRabbitmqDefinitions,
+				StateFunc:    normalizeRabbitmqDefinitions,
+				Description:  `The RabbitMQ definitions of the managed objects, in JSON format.`,
+			},
+			"recreate_on_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: `Whether to recreate the exchanges and the queues whose attributes are changed, the messages ` +
+					`of the recreated queues are discarded.`,
+			},
+		},
+	}
+}
+
+func validateRabbitmqDefinitions(v interface{}, k string) (ws []string, errs []error) {
+	definitions, err := parseRabbitmqDefinitions(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q is invalid: %s", k, err)}
+	}
+	if err = definitions.validate(); err != nil {
+		return nil, []error{fmt.Errorf("%q is invalid: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// checkRabbitmqDefinitionsChange rejects the attribute changes of the exchanges and the queues during the plan, unless
+// the recreate_on_change is enabled.
+func checkRabbitmqDefinitionsChange(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("definitions") || !d.NewValueKnown("definitions") ||
+		d.Get("recreate_on_change").(bool) {
+		return nil
+	}
+
+	oldRaw, newRaw := d.GetChange("definitions")
+	oldDefinitions, err := parseRabbitmqDefinitions(oldRaw.(string))
+	if err != nil {
+		return nil
+	}
+	newDefinitions, err := parseRabbitmqDefinitions(newRaw.(string))
+	if err != nil {
+		return nil
+	}
+	if changed := findChangedRabbitmqObjects(oldDefinitions, newDefinitions); len(changed) > 0 {
+		return fmt.Errorf("the attributes of %s can not be updated, set recreate_on_change to true to recreate "+
+			"them, the messages of the recreated queues are discarded", strings.Join(changed, ", "))
+	}
+	return nil
+}
+
+// ignoreRabbitmqObjectNotFound ignores the error of the object which has already been deleted.
+func ignoreRabbitmqObjectNotFound(err error) error {
+	if _, ok := err.(golangsdk.ErrDefault404); ok {
+		return nil
+	}
+	return err
+}
+
+func createRabbitmqDefinitionVhost(client *golangsdk.ServiceClient, instanceId, name string) error {
+	createPath := buildRabbitmqVhostPath(client, instanceId, "", "v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts")
+	createOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody:         map[string]interface{}{"name": name},
+	}
+	if _, err := client.Request("PUT", createPath, &createOpt); err != nil {
+		return fmt.Errorf("error creating vhost (%s): %s", name, err)
+	}
+	return nil
+}
+
+func createRabbitmqDefinitionExchange(client *golangsdk.ServiceClient, instanceId string,
+	exchange rabbitmqDefinitionExchange) error {
+	createPath := buildRabbitmqVhostPath(client, instanceId, exchange.Vhost,
+		"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges")
+	bodyParams := map[string]interface{}{
+		"name":        exchange.Name,
+		"type":        exchange.Type,
+		"durable":     exchange.Durable,
+		"auto_delete": exchange.AutoDelete,
+		"internal":    exchange.Internal,
+	}
+	if len(exchange.Arguments) > 0 {
+		bodyParams["arguments"] = exchange.Arguments
+	}
+	createOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody:         bodyParams,
+	}
+	if _, err := client.Request("PUT", createPath, &createOpt); err != nil {
+		return fmt.Errorf("error creating exchange (%s) in the vhost (%s): %s", exchange.Name, exchange.Vhost, err)
+	}
+	return nil
+}
+
+func createRabbitmqDefinitionQueue(client *golangsdk.ServiceClient, instanceId string,
+	queue rabbitmqDefinitionQueue) error {
+	createPath := buildRabbitmqVhostPath(client, instanceId, queue.Vhost,
+		"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/queues")
+	bodyParams := map[string]interface{}{
+		"name":        queue.Name,
+		"durable":     queue.Durable,
+		"auto_delete": queue.AutoDelete,
+	}
+	for argument, value := range queue.Arguments {
+		bodyParams[rabbitmqQueueArguments[argument]] = value
+	}
+	createOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody:         bodyParams,
+	}
+	if _, err := client.Request("PUT", createPath, &createOpt); err != nil {
+		return fmt.Errorf("error creating queue (%s) in the vhost (%s): %s", queue.Name, queue.Vhost, err)
+	}
+	return nil
+}
+
+// deleteRabbitmqDefinitionObjects deletes the exchanges, queues or vhosts in batches, the objectPath is the path of
+// the object collection.
+func deleteRabbitmqDefinitionObjects(client *golangsdk.ServiceClient, instanceId, vhost, objectPath string,
+	names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	deletePath := buildRabbitmqVhostPath(client, instanceId, vhost, objectPath)
+	deleteOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          []int{204},
+		JSONBody: map[string]interface{}{
+			"name": names,
+		},
+	}
+	_, err := client.Request("POST", deletePath, &deleteOpt)
+	return ignoreRabbitmqObjectNotFound(err)
+}
+
+func createRabbitmqDefinitionBinding(client *golangsdk.ServiceClient, instanceId string,
+	binding rabbitmqDefinitionBinding) error {
+	createPath := buildRabbitmqVhostPath(client, instanceId, binding.Vhost,
+		"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges/{exchange}/binding")
+	createPath = strings.ReplaceAll(createPath, "{exchange}", encodeRabbitmqPathName(binding.Source))
+	createOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody: map[string]interface{}{
+			"destination":      binding.Destination,
+			"destination_type": binding.DestinationType,
+			// routing_key have to send empty string if it's empty
+			"routing_key": binding.RoutingKey,
+		},
+	}
+	if _, err := client.Request("POST", createPath, &createOpt); err != nil {
+		return fmt.Errorf("error binding the exchange (%s) to the %s (%s) in the vhost (%s): %s", binding.Source,
+			binding.DestinationType, binding.Destination, binding.Vhost, err)
+	}
+	return nil
+}
+
+func deleteRabbitmqDefinitionBinding(client *golangsdk.ServiceClient, instanceId string,
+	binding rabbitmqDefinitionBinding, propertiesKey string) error {
+	propertiesKey = url.PathEscape(propertiesKey)
+	propertiesKey = strings.ReplaceAll(propertiesKey, "%2F", "__F_SLASH__")
+	propertiesKey = strings.ReplaceAll(propertiesKey, "%5C", "__B_SLASH__")
+
+	deletePath := buildRabbitmqVhostPath(client, instanceId, binding.Vhost,
+		"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges/{exchange}"+
+			"/destination-type/{destination_type}/destination/{destination}/properties-key/{properties_key}/unbinding")
+	deletePath = strings.ReplaceAll(deletePath, "{exchange}", encodeRabbitmqPathName(binding.Source))
+	deletePath = strings.ReplaceAll(deletePath, "{destination_type}", binding.DestinationType)
+	deletePath = strings.ReplaceAll(deletePath, "{destination}", encodeRabbitmqPathName(binding.Destination))
+	deletePath = strings.ReplaceAll(deletePath, "{properties_key}", propertiesKey)
+	deleteOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          []int{204},
+	}
+	if _, err := client.Request("DELETE", deletePath, &deleteOpt); ignoreRabbitmqObjectNotFound(err) != nil {
+		return fmt.Errorf("error unbinding the exchange (%s) from the %s (%s) in the vhost (%s): %s", binding.Source,
+			binding.DestinationType, binding.Destination, binding.Vhost, err)
+	}
+	return nil
+}
+
+func buildRabbitmqDefinitionUserBodyParams(user rabbitmqDefinitionUser,
+	permissions []rabbitmqDefinitionPermission) map[string]interface{} {
+	vhosts := make([]map[string]interface{}, 0)
+	for _, permission := range permissions {
+		if permission.User != user.Name {
+			continue
+		}
+		vhosts = append(vhosts, map[string]interface{}{
+			"vhost": permission.Vhost,
+			"conf":  permission.Configure,
+			"write": permission.Write,
+			"read":  permission.Read,
+		})
+	}
+	return map[string]interface{}{
+		"access_key": user.Name,
+		"secret_key": user.Password,
+		"vhosts":     vhosts,
+	}
+}
+
+func applyRabbitmqDefinitionUser(client *golangsdk.ServiceClient, instanceId string, user rabbitmqDefinitionUser,
+	permissions []rabbitmqDefinitionPermission, exists bool) error {
+	var (
+		method  = "POST"
+		httpUrl = "v2/{project_id}/instances/{instance_id}/users"
+	)
+	if exists {
+		method = "PUT"
+		httpUrl = "v2/{project_id}/instances/{instance_id}/users/{user_name}"
+	}
+	requestPath := buildRabbitmqVhostPath(client, instanceId, "", httpUrl)
+	requestPath = strings.ReplaceAll(requestPath, "{user_name}", user.Name)
+	requestOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody:         buildRabbitmqDefinitionUserBodyParams(user, permissions),
+	}
+	if _, err := client.Request(method, requestPath, &requestOpt); err != nil {
+		return fmt.Errorf("error applying user (%s): %s", user.Name, err)
+	}
+	return nil
+}
+
+func deleteRabbitmqDefinitionUser(client *golangsdk.ServiceClient, instanceId, name string) error {
+	deletePath := buildRabbitmqVhostPath(client, instanceId, "",
+		"v2/{project_id}/instances/{instance_id}/users/{user_name}")
+	deletePath = strings.ReplaceAll(deletePath, "{user_name}", name)
+	deleteOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          []int{204},
+	}
+	_, err := client.Request("DELETE", deletePath, &deleteOpt)
+	err = common.ConvertExpected400ErrInto404Err(err, "error_code", "DMS.00500972")
+	if ignoreRabbitmqObjectNotFound(err) != nil {
+		return fmt.Errorf("error deleting user (%s): %s", name, err)
+	}
+	return nil
+}
+
+// applyRabbitmqDefinitions reconciles the objects of the instance from the old definitions to the new definitions.
+// The missing objects are created, the objects whose attributes are different are recreated if recreateOnChange is
+// true, and the objects which are only included by the old definitions are deleted.
+func applyRabbitmqDefinitions(client *golangsdk.ServiceClient, instanceId string,
+	oldDefinitions, newDefinitions *rabbitmqDefinitions, recreateOnChange bool) error {
+	if err := applyRabbitmqDefinitionVhosts(client, instanceId, newDefinitions); err != nil {
+		return err
+	}
+	if err := applyRabbitmqDefinitionExchanges(client, instanceId, newDefinitions, recreateOnChange); err != nil {
+		return err
+	}
+	if err := applyRabbitmqDefinitionQueues(client, instanceId, newDefinitions, recreateOnChange); err != nil {
+		return err
+	}
+	if err := applyRabbitmqDefinitionBindings(client, instanceId, oldDefinitions, newDefinitions); err != nil {
+		return err
+	}
+	if err := applyRabbitmqDefinitionUsers(client, instanceId, oldDefinitions, newDefinitions); err != nil {
+		return err
+	}
+	return deleteRabbitmqDefinitionRemovedObjects(client, instanceId, oldDefinitions, newDefinitions)
+}
+
+func applyRabbitmqDefinitionVhosts(client *golangsdk.ServiceClient, instanceId string,
+	newDefinitions *rabbitmqDefinitions) error {
+	remoteVhosts, err := listRabbitmqDefinitionVhosts(client, instanceId)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, v := range remoteVhosts {
+		existing[v.Name] = true
+	}
+	for _, v := range newDefinitions.Vhosts {
+		if existing[v.Name] {
+			continue
+		}
+		if err = createRabbitmqDefinitionVhost(client, instanceId, v.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyRabbitmqDefinitionExchanges(client *golangsdk.ServiceClient, instanceId string,
+	newDefinitions *rabbitmqDefinitions, recreateOnChange bool) error {
+	remoteExchanges := make(map[string]map[string]rabbitmqDefinitionExchange)
+	for _, exchange := range newDefinitions.Exchanges {
+		if _, ok := remoteExchanges[exchange.Vhost]; !ok {
+			exchanges, err := listRabbitmqDefinitionExchanges(client, instanceId, exchange.Vhost)
+			if err != nil {
+				return err
+			}
+			remoteExchanges[exchange.Vhost] = make(map[string]rabbitmqDefinitionExchange)
+			for _, v := range exchanges {
+				remoteExchanges[exchange.Vhost][v.Name] = v
+			}
+		}
+
+		remote, ok := remoteExchanges[exchange.Vhost][exchange.Name]
+		if ok {
+			if !remote.isChanged(exchange) {
+				continue
+			}
+			// The attributes of the exchange can not be updated, so it is recreated.
+			if !recreateOnChange {
+				return fmt.Errorf("the attributes of the exchange (%s) are different from the definitions, set "+
+					"recreate_on_change to true to recreate it", exchange.key())
+			}
+			log.Printf("[WARN] the attributes of the exchange (%s) are changed, recreating it", exchange.key())
+			err := deleteRabbitmqDefinitionObjects(client, instanceId, exchange.Vhost,
+				"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges", []string{exchange.Name})
+			if err != nil {
+				return fmt.Errorf("error deleting exchange (%s): %s", exchange.key(), err)
+			}
+		}
+		if err := createRabbitmqDefinitionExchange(client, instanceId, exchange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyRabbitmqDefinitionQueues(client *golangsdk.ServiceClient, instanceId string,
+	newDefinitions *rabbitmqDefinitions, recreateOnChange bool) error {
+	remoteQueues := make(map[string]map[string]rabbitmqDefinitionQueue)
+	for _, queue := range newDefinitions.Queues {
+		if _, ok := remoteQueues[queue.Vhost]; !ok {
+			queues, err := listRabbitmqDefinitionQueues(client, instanceId, queue.Vhost)
+			if err != nil {
+				return err
+			}
+			remoteQueues[queue.Vhost] = make(map[string]rabbitmqDefinitionQueue)
+			for _, v := range queues {
+				remoteQueues[queue.Vhost][v.Name] = v
+			}
+		}
+
+		remote, ok := remoteQueues[queue.Vhost][queue.Name]
+		if ok {
+			if !remote.isChanged(queue) {
+				continue
+			}
+			// The attributes of the queue can not be updated, so it is recreated and the messages are discarded.
+			if !recreateOnChange {
+				return fmt.Errorf("the attributes of the queue (%s) are different from the definitions, set "+
+					"recreate_on_change to true to recreate it and discard its messages", queue.key())
+			}
+			log.Printf("[WARN] the attributes of the queue (%s) are changed, recreating it", queue.key())
+			err := deleteRabbitmqDefinitionObjects(client, instanceId, queue.Vhost,
+				"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/queues", []string{queue.Name})
+			if err != nil {
+				return fmt.Errorf("error deleting queue (%s): %s", queue.key(), err)
+			}
+		}
+		if err := createRabbitmqDefinitionQueue(client, instanceId, queue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyRabbitmqDefinitionBindings(client *golangsdk.ServiceClient, instanceId string,
+	oldDefinitions, newDefinitions *rabbitmqDefinitions) error {
+	desired := make(map[string]bool)
+	for _, v := range newDefinitions.Bindings {
+		desired[v.key()] = true
+	}
+
+	// The bindings of the exchanges are queried once, the raw items are kept for the properties keys.
+	remoteBindings := make(map[string]map[string]interface{})
+	getRemoteBindings := func(vhost, exchange string) (map[string]interface{}, error) {
+		exchangeKey := vhost + "/" + exchange
+		if result, ok := remoteBindings[exchangeKey]; ok {
+			return result, nil
+		}
+		items, err := listRabbitmqExchangeBindingItems(client, instanceId, vhost, exchange)
+		if err != nil {
+			return nil, ignoreRabbitmqObjectNotFound(err)
+		}
+		result := make(map[string]interface{})
+		for _, item := range items {
+			result[flattenRabbitmqDefinitionBinding(vhost, exchange, item).key()] = item
+		}
+		remoteBindings[exchangeKey] = result
+		return result, nil
+	}
+
+	for _, binding := range oldDefinitions.Bindings {
+		if desired[binding.key()] {
+			continue
+		}
+		bindings, err := getRemoteBindings(binding.Vhost, binding.Source)
+		if err != nil {
+			return err
+		}
+		item, ok := bindings[binding.key()]
+		if !ok {
+			continue
+		}
+		propertiesKey := utils.PathSearch("properties_key", item, "").(string)
+		if err = deleteRabbitmqDefinitionBinding(client, instanceId, binding, propertiesKey); err != nil {
+			return err
+		}
+	}
+
+	for _, binding := range newDefinitions.Bindings {
+		bindings, err := getRemoteBindings(binding.Vhost, binding.Source)
+		if err != nil {
+			return err
+		}
+		if _, ok := bindings[binding.key()]; ok {
+			continue
+		}
+		if err = createRabbitmqDefinitionBinding(client, instanceId, binding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyRabbitmqDefinitionUsers(client *golangsdk.ServiceClient, instanceId string,
+	oldDefinitions, newDefinitions *rabbitmqDefinitions) error {
+	remoteUsers, remotePermissions, err := listRabbitmqDefinitionUsers(client, instanceId)
+	if err != nil {
+		return err
+	}
+	remote := rabbitmqDefinitions{Users: remoteUsers, Permissions: remotePermissions}
+	remote.normalize()
+
+	existing := make(map[string]rabbitmqDefinitionUser)
+	for _, v := range remoteUsers {
+		existing[v.Name] = v
+	}
+	for _, user := range newDefinitions.Users {
+		remoteUser, ok := existing[user.Name]
+		if ok && (remoteUser.Password == "" || remoteUser.Password == user.Password) &&
+			utils.JsonToString(buildRabbitmqDefinitionUserBodyParams(user, remote.Permissions)["vhosts"]) ==
+				utils.JsonToString(buildRabbitmqDefinitionUserBodyParams(user, newDefinitions.Permissions)["vhosts"]) {
+			continue
+		}
+		if err = applyRabbitmqDefinitionUser(client, instanceId, user, newDefinitions.Permissions, ok); err != nil {
+			return err
+		}
+	}
+
+	desired := make(map[string]bool)
+	for _, v := range newDefinitions.Users {
+		desired[v.Name] = true
+	}
+	for _, user := range oldDefinitions.Users {
+		if desired[user.Name] {
+			continue
+		}
+		if _, ok := existing[user.Name]; !ok {
+			continue
+		}
+		if err = deleteRabbitmqDefinitionUser(client, instanceId, user.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteRabbitmqDefinitionRemovedObjects deletes the queues, exchanges and vhosts which are only included by the old
+// definitions, the default vhost is never deleted.
+func deleteRabbitmqDefinitionRemovedObjects(client *golangsdk.ServiceClient, instanceId string,
+	oldDefinitions, newDefinitions *rabbitmqDefinitions) error {
+	desiredQueues := make(map[string]bool)
+	for _, v := range newDefinitions.Queues {
+		desiredQueues[v.key()] = true
+	}
+	removedQueues := make(map[string][]string)
+	for _, v := range oldDefinitions.Queues {
+		if !desiredQueues[v.key()] {
+			removedQueues[v.Vhost] = append(removedQueues[v.Vhost], v.Name)
+		}
+	}
+	for vhost, names := range removedQueues {
+		err := deleteRabbitmqDefinitionObjects(client, instanceId, vhost,
+			"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/queues", names)
+		if err != nil {
+			return fmt.Errorf("error deleting queues %v of the vhost (%s): %s", names, vhost, err)
+		}
+	}
+
+	desiredExchanges := make(map[string]bool)
+	for _, v := range newDefinitions.Exchanges {
+		desiredExchanges[v.key()] = true
+	}
+	removedExchanges := make(map[string][]string)
+	for _, v := range oldDefinitions.Exchanges {
+		if !desiredExchanges[v.key()] {
+			removedExchanges[v.Vhost] = append(removedExchanges[v.Vhost], v.Name)
+		}
+	}
+	for vhost, names := range removedExchanges {
+		err := deleteRabbitmqDefinitionObjects(client, instanceId, vhost,
+			"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts/{vhost}/exchanges", names)
+		if err != nil {
+			return fmt.Errorf("error deleting exchanges %v of the vhost (%s): %s", names, vhost, err)
+		}
+	}
+
+	desiredVhosts := make(map[string]bool)
+	for _, v := range newDefinitions.Vhosts {
+		desiredVhosts[v.Name] = true
+	}
+	removedVhosts := make([]string, 0)
+	for _, v := range oldDefinitions.Vhosts {
+		if !desiredVhosts[v.Name] && v.Name != "/" {
+			removedVhosts = append(removedVhosts, v.Name)
+		}
+	}
+	err := deleteRabbitmqDefinitionObjects(client, instanceId, "",
+		"v2/rabbitmq/{project_id}/instances/{instance_id}/vhosts", removedVhosts)
+	if err != nil {
+		return fmt.Errorf("error deleting vhosts %v: %s", removedVhosts, err)
+	}
+	return nil
+}
+
+func resourceDmsRabbitmqDefinitionsCreate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dmsv2", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	instanceId := d.Get("instance_id").(string)
+	definitions, err := parseRabbitmqDefinitions(d.Get("definitions").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = applyRabbitmqDefinitions(client, instanceId, &rabbitmqDefinitions{}, definitions,
+		d.Get("recreate_on_change").(bool))
+	if err != nil {
+		return diag.Errorf("error applying definitions to the RabbitMQ instance (%s): %s", instanceId, err)
+	}
+
+	d.SetId(instanceId)
+
+	return resourceDmsRabbitmqDefinitionsRead(ctx, d, meta)
+}
+
+func resourceDmsRabbitmqDefinitionsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("dmsv2", region)
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	remote, err := exportRabbitmqDefinitions(client, d.Id())
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "error retrieving definitions of the RabbitMQ instance")
+	}
+
+	// All objects are managed after the import, otherwise only the objects included by the definitions are refreshed,
+	// and the objects which are removed outside are reported as the changes of the definitions.
+	if managed := d.Get("definitions").(string); managed != "" {
+		managedDefinitions, err := parseRabbitmqDefinitions(managed)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		remote = remote.filter(managedDefinitions)
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("instance_id", d.Id()),
+		d.Set("definitions", remote.String()),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+func resourceDmsRabbitmqDefinitionsUpdate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dmsv2", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	if d.HasChange("definitions") {
+		oldRaw, newRaw := d.GetChange("definitions")
+		oldDefinitions, err := parseRabbitmqDefinitions(oldRaw.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		newDefinitions, err := parseRabbitmqDefinitions(newRaw.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = applyRabbitmqDefinitions(client, d.Id(), oldDefinitions, newDefinitions, d.Get("recreate_on_change").(bool))
+		if err != nil {
+			return diag.Errorf("error applying definitions to the RabbitMQ instance (%s): %s", d.Id(), err)
+		}
+	}
+
+	return resourceDmsRabbitmqDefinitionsRead(ctx, d, meta)
+}
+
+func resourceDmsRabbitmqDefinitionsDelete(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dmsv2", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	definitions, err := parseRabbitmqDefinitions(d.Get("definitions").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err = applyRabbitmqDefinitions(client, d.Id(), definitions, &rabbitmqDefinitions{}, false); err != nil {
+		return common.CheckDeletedDiag(d, err, fmt.Sprintf("error deleting definitions of the RabbitMQ instance (%s)",
+			d.Id()))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package rds
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// @API RDS GET /v3/{project_id}/instances
+// @API RDS GET /v3/{project_id}/instances/{instance_id}/major-version/available-version
+// @API RDS POST /v3/{project_id}/instances/{instance_id}/major-version/check
+// @API RDS GET /v3/{project_id}/instances/{instance_id}/major-version/status
+func DataSourceRdsMajorVersionUpgradeCheck() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRdsMajorVersionUpgradeCheckRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: `The region where the RDS instance is located.`,
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The ID of the RDS instance to be upgraded.`,
+			},
+			"target_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The target major version to be checked.`,
+			},
+			"available_versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The major versions to which the instance can be upgraded.`,
+			},
+			"check_result": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The result of the upgrade check.`,
+			},
+			"check_report": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The report of the upgrade check.`,
+			},
+			"check_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The time when the upgrade check is finished.`,
+			},
+		},
+	}
+}
+
+func dataSourceRdsMajorVersionUpgradeCheckRead(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("rds", region)
+	if err != nil {
+		return diag.Errorf("error creating RDS client: %s", err)
+	}
+
+	instanceId := d.Get("instance_id").(string)
+	versions, err := listMajorVersionAvailableVersions(client, instanceId)
+	if err != nil {
+		return diag.Errorf("error retrieving available major versions of RDS instance (%s): %s", instanceId, err)
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(id)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("available_versions", versions),
+	)
+
+	if targetVersion, ok := d.GetOk("target_version"); ok {
+		report, err := checkMajorVersionUpgrade(ctx, client, instanceId, targetVersion.(string),
+			d.Timeout(schema.TimeoutRead))
+		if err != nil {
+			return diag.Errorf("error checking major version upgrade of RDS instance (%s): %s", instanceId, err)
+		}
+		mErr = multierror.Append(mErr,
+			d.Set("check_result", utils.PathSearch("status", report, nil)),
+			d.Set("check_report", utils.PathSearch("report", report, nil)),
+			d.Set("check_time", utils.PathSearch("end_time", report, nil)),
+		)
+	}
+
+	return diag.FromErr(mErr.ErrorOrNil())
+}
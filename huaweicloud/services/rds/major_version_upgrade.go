@@ -0,0 +1,196 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// The actions of the major version upgrade task, which are used to query the task status.
+const (
+	majorVersionActionCheck   = "check"
+	majorVersionActionUpgrade = "upgrade"
+)
+
+func listMajorVersionAvailableVersions(client *golangsdk.ServiceClient, instanceId string) ([]interface{}, error) {
+	httpUrl := "v3/{project_id}/instances/{instance_id}/major-version/available-version"
+	getPath := client.Endpoint + httpUrl
+	getPath = strings.ReplaceAll(getPath, "{project_id}", client.ProjectID)
+	getPath = strings.ReplaceAll(getPath, "{instance_id}", instanceId)
+
+	getOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+	}
+	getResp, err := client.Request("GET", getPath, &getOpt)
+	if err != nil {
+		return nil, err
+	}
+	getRespBody, err := utils.FlattenResponse(getResp)
+	if err != nil {
+		return nil, err
+	}
+	return utils.PathSearch("available_versions", getRespBody, make([]interface{}, 0)).([]interface{}), nil
+}
+
+// checkMajorVersionUpgrade submits the upgrade check of the target version and waits for the check report.
+func checkMajorVersionUpgrade(ctx context.Context, client *golangsdk.ServiceClient, instanceId, targetVersion string,
+	timeout time.Duration) (interface{}, error) {
+	httpUrl := "v3/{project_id}/instances/{instance_id}/major-version/check"
+	checkPath := client.Endpoint + httpUrl
+	checkPath = strings.ReplaceAll(checkPath, "{project_id}", client.ProjectID)
+	checkPath = strings.ReplaceAll(checkPath, "{instance_id}", instanceId)
+
+	checkOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+		JSONBody: map[string]interface{}{
+			"target_version": targetVersion,
+		},
+	}
+	retryFunc := func() (interface{}, bool, error) {
+		_, err := client.Request("POST", checkPath, &checkOpt)
+		retry, err := handleMultiOperationsError(err)
+		return nil, retry, err
+	}
+	_, err := common.RetryContextWithWaitForState(&common.RetryContextWithWaitForStateParam{
+		Ctx:          ctx,
+		RetryFunc:    retryFunc,
+		WaitFunc:     rdsInstanceStateRefreshFunc(client, instanceId),
+		WaitTarget:   []string{"ACTIVE"},
+		Timeout:      timeout,
+		DelayTimeout: 1 * time.Second,
+		PollInterval: 10 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return waitForMajorVersionTaskFinished(ctx, client, instanceId, majorVersionActionCheck, timeout)
+}
+
+func getMajorVersionTaskStatus(client *golangsdk.ServiceClient, instanceId, action string) (interface{}, error) {
+	httpUrl := "v3/{project_id}/instances/{instance_id}/major-version/status?action={action}"
+	getPath := client.Endpoint + httpUrl
+	getPath = strings.ReplaceAll(getPath, "{project_id}", client.ProjectID)
+	getPath = strings.ReplaceAll(getPath, "{instance_id}", instanceId)
+	getPath = strings.ReplaceAll(getPath, "{action}", action)
+
+	getOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+	}
+	getResp, err := client.Request("GET", getPath, &getOpt)
+	if err != nil {
+		return nil, err
+	}
+	return utils.FlattenResponse(getResp)
+}
+
+func waitForMajorVersionTaskFinished(ctx context.Context, client *golangsdk.ServiceClient, instanceId, action string,
+	timeout time.Duration) (interface{}, error) {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"running"},
+		Target:  []string{"success", "failed"},
+		Refresh: func() (interface{}, string, error) {
+			respBody, err := getMajorVersionTaskStatus(client, instanceId, action)
+			if err != nil {
+				return nil, "ERROR", err
+			}
+			return respBody, utils.PathSearch("status", respBody, "").(string), nil
+		},
+		Timeout:      timeout,
+		Delay:        10 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	respBody, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for the major version %s task of RDS instance (%s) to be finished: %s",
+			action, instanceId, err)
+	}
+	return respBody, nil
+}
+
+// parseDbVersion splits the version into numeric parts, e.g. "5.7" is parsed as [5, 7], nil is returned if the
+// version contains any non-numeric part, such as the SQL Server versions.
+func parseDbVersion(version string) []int {
+	if version == "" {
+		return nil
+	}
+
+	parts := strings.Split(version, ".")
+	result := make([]int, 0, len(parts))
+	for _, part := range parts {
+		num, err := strconv.Atoi(part)
+		if err != nil {
+			return nil
+		}
+		result = append(result, num)
+	}
+	return result
+}
+
+// getDbMajorVersionParts returns the numeric parts of the major version, e.g. the major version of MySQL 8.0.28 is
+// 8.0, and the one of PostgreSQL 16.2 is 16. The major versions of PostgreSQL before 10 consist of two parts.
+func getDbMajorVersionParts(dbType string, parts []int) []int {
+	count := 2
+	if strings.EqualFold(dbType, "PostgreSQL") && parts[0] >= 10 {
+		count = 1
+	}
+	if len(parts) < count {
+		return parts
+	}
+	return parts[:count]
+}
+
+// compareDbVersionParts compares the numeric parts of two versions, a version is lower than its extension, e.g. 8.0 is
+// lower than 8.0.28.
+func compareDbVersionParts(parts, otherParts []int) int {
+	for i := 0; i < len(parts) && i < len(otherParts); i++ {
+		if parts[i] != otherParts[i] {
+			return parts[i] - otherParts[i]
+		}
+	}
+	return len(parts) - len(otherParts)
+}
+
+// isMajorVersionUpgrade checks whether the major version of the new version is higher than the one of the old version.
+func isMajorVersionUpgrade(dbType, oldVersion, newVersion string) bool {
+	oldParts, newParts := parseDbVersion(oldVersion), parseDbVersion(newVersion)
+	if oldParts == nil || newParts == nil {
+		return false
+	}
+	return compareDbVersionParts(getDbMajorVersionParts(dbType, oldParts), getDbMajorVersionParts(dbType, newParts)) < 0
+}
+
+// isSameMajorVersion checks whether the two versions have the same major version, the non-numeric versions are
+// compared as they are.
+func isSameMajorVersion(dbType, version, otherVersion string) bool {
+	parts, otherParts := parseDbVersion(version), parseDbVersion(otherVersion)
+	if parts == nil || otherParts == nil {
+		return version == otherVersion
+	}
+	return compareDbVersionParts(getDbMajorVersionParts(dbType, parts), getDbMajorVersionParts(dbType, otherParts)) == 0
+}
+
+// isNewerMinorVersion checks whether the version is a newer minor version of the major version of the base version,
+// e.g. 8.0.28 is a newer minor version of 8.0, while 8.0 is not a newer minor version of 5.7.
+func isNewerMinorVersion(dbType, version, baseVersion string) bool {
+	if !isSameMajorVersion(dbType, version, baseVersion) {
+		return false
+	}
+	parts, baseParts := parseDbVersion(version), parseDbVersion(baseVersion)
+	if parts == nil || baseParts == nil {
+		return false
+	}
+	return compareDbVersionParts(parts, baseParts) > 0
+}
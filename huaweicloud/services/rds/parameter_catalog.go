@@ -0,0 +1,155 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// The resource used to restart the RDS instances to make the changed parameters take effect.
+const rdsInstanceRestartResource = "huaweicloud_rds_instance_restart"
+
+// getRdsParameterCatalog returns the parameter catalog of the engine version, which is built from the default
+// parameter template of the engine version.
+func getRdsParameterCatalog(client *golangsdk.ServiceClient, engine, version string) (common.ParameterCatalog, error) {
+	return common.GetParameterCatalog(client, engine, version, func() (common.ParameterCatalog, error) {
+		httpUrl := "v3/{project_id}/configurations"
+		listPath := client.Endpoint + httpUrl
+		listPath = strings.ReplaceAll(listPath, "{project_id}", client.ProjectID)
+
+		opt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+			MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+		}
+		listResp, err := client.Request("GET", listPath, &opt)
+		if err != nil {
+			return nil, err
+		}
+		listRespBody, err := utils.FlattenResponse(listResp)
+		if err != nil {
+			return nil, err
+		}
+
+		configId := ""
+		configurations := utils.PathSearch("configurations[?!user_defined]", listRespBody,
+			make([]interface{}, 0)).([]interface{})
+		for _, v := range configurations {
+			// The default templates are provided for the major versions, e.g. 8.0 is used for 8.0.28.
+			if strings.EqualFold(utils.PathSearch("datastore_name", v, "").(string), engine) &&
+				isSameMajorVersion(engine, utils.PathSearch("datastore_version_name", v, "").(string), version) {
+				configId = utils.PathSearch("id", v, "").(string)
+				break
+			}
+		}
+		if configId == "" {
+			return nil, fmt.Errorf("unable to find the default parameter template of %s (%s)", engine, version)
+		}
+
+		getPath := client.Endpoint + "v3/{project_id}/configurations/{config_id}"
+		getPath = strings.ReplaceAll(getPath, "{project_id}", client.ProjectID)
+		getPath = strings.ReplaceAll(getPath, "{config_id}", configId)
+		getResp, err := client.Request("GET", getPath, &opt)
+		if err != nil {
+			return nil, err
+		}
+		getRespBody, err := utils.FlattenResponse(getResp)
+		if err != nil {
+			return nil, err
+		}
+
+		catalog := make(common.ParameterCatalog)
+		parameters := utils.PathSearch("configuration_parameters", getRespBody, make([]interface{}, 0)).([]interface{})
+		for _, v := range parameters {
+			name := utils.PathSearch("name", v, "").(string)
+			catalog[name] = common.ParameterDefinition{
+				Name:            name,
+				Type:            utils.PathSearch("type", v, "").(string),
+				ValueRange:      utils.PathSearch("value_range", v, "").(string),
+				RestartRequired: utils.PathSearch("restart_required", v, false).(bool),
+				Readonly:        utils.PathSearch("readonly", v, false).(bool),
+			}
+		}
+		return catalog, nil
+	})
+}
+
+// validateRdsParameters validates the parameters against the parameter catalog of the engine version, the validation
+// is skipped if the catalog is unavailable. The parameters which require a restart are reported as a warning after
+// they are applied, because the CustomizeDiff can not return warnings.
+func validateRdsParameters(d *schema.ResourceDiff, meta interface{}, engine, version string,
+	params map[string]string) error {
+	if len(params) == 0 || engine == "" || version == "" {
+		return nil
+	}
+
+	cfg := meta.(*config.Config)
+	region := d.Get("region").(string)
+	if region == "" {
+		region = cfg.Region
+	}
+	client, err := cfg.NewServiceClient("rds", region)
+	if err != nil {
+		return fmt.Errorf("error creating RDS client: %s", err)
+	}
+
+	catalog, err := getRdsParameterCatalog(client, engine, version)
+	if err != nil {
+		log.Printf("[WARN] unable to query the parameter catalog of %s (%s), skip validating the parameters: %s",
+			engine, version, err)
+		return nil
+	}
+	if _, err := common.ValidateParameters(catalog, params); err != nil {
+		return fmt.Errorf("invalid parameters for %s (%s): %s", engine, version, err)
+	}
+	return nil
+}
+
+// checkRdsInstanceParameters validates the added and changed parameters of the RDS instance. The parameters of an
+// existing instance are validated against the version in the state, which is the version the instance is running,
+// because the configured version may differ from it, e.g. before or after a major version upgrade.
+func checkRdsInstanceParameters(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("parameters") || !d.NewValueKnown("parameters") {
+		return nil
+	}
+
+	version := ""
+	if d.Id() != "" {
+		oldVersion, _ := d.GetChange("db.0.version")
+		version = oldVersion.(string)
+	}
+	if version == "" {
+		if !d.NewValueKnown("db.0.version") {
+			return nil
+		}
+		version = d.Get("db.0.version").(string)
+	}
+
+	oldVal, newVal := d.GetChange("parameters")
+	params := make(map[string]string)
+	for _, v := range newVal.(*schema.Set).Difference(oldVal.(*schema.Set)).List() {
+		param := v.(map[string]interface{})
+		params[param["name"].(string)] = param["value"].(string)
+	}
+	return validateRdsParameters(d, meta, d.Get("db.0.type").(string), version, params)
+}
+
+// checkRdsConfigurationValues validates the added and changed values of the RDS parameter template.
+func checkRdsConfigurationValues(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("values") || !d.NewValueKnown("values") || !d.NewValueKnown("datastore") {
+		return nil
+	}
+
+	oldVal, newVal := d.GetChange("values")
+	params := common.GetChangedParameters(oldVal.(map[string]interface{}), newVal.(map[string]interface{}))
+	return validateRdsParameters(d, meta, d.Get("datastore.0.type").(string), d.Get("datastore.0.version").(string),
+		params)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -31,6 +32,7 @@ type ctxType string
 
 // ResourceRdsInstance is the impl for huaweicloud_rds_instance resource
 // @API RDS POST /v3/{project_id}/instances
+// @API RDS GET /v3/{project_id}/instances/{instance_id}/restore-time
 // @API RDS GET /v3/{project_id}/jobs
 // @API RDS GET /v3/{project_id}/instances
 // @API RDS PUT /v3/{project_id}/instances/{instance_id}/alias
@@ -100,7 +102,13 @@ func ResourceRdsInstance() *schema.Resource {
 			Default: schema.DefaultTimeout(15 * time.Minute),
 		},
 
-		CustomizeDiff: config.MergeDefaultTags(),
+		CustomizeDiff: customdiff.All(
+			config.MergeDefaultTags(),
+			checkDbVersionChange,
+			checkRdsInstanceParameters,
+			common.CheckManagedMasterPasswordRotation,
+			common.CheckRestoreFromTime("rds", "restore_time"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -138,14 +146,15 @@ func ResourceRdsInstance() *schema.Resource {
 							DiffSuppressFunc: utils.SuppressCaseDiffs(),
 						},
 						"version": {
-							Type:     schema.TypeString,
-							Required: true,
-							ForceNew: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressDbVersionUpgradedDiff,
 						},
 						"password": {
-							Type:      schema.TypeString,
-							Sensitive: true,
-							Optional:  true,
+							Type:          schema.TypeString,
+							Sensitive:     true,
+							Optional:      true,
+							ConflictsWith: []string{"manage_master_password"},
 						},
 						"port": {
 							Type:     schema.TypeInt,
@@ -199,6 +208,7 @@ func ResourceRdsInstance() *schema.Resource {
 				ForceNew:      true,
 				ConflictsWith: []string{"period"},
 				MaxItems:      1,
+				Deprecated:    "use restore_from instead",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"instance_id": {
@@ -220,6 +230,7 @@ func ResourceRdsInstance() *schema.Resource {
 					},
 				},
 			},
+			"restore_from": common.RestoreFromSchema([]string{"restore", "period"}),
 			"vpc_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -405,7 +416,9 @@ func ResourceRdsInstance() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.StringInSlice([]string{"true", "false"}, false),
 			},
-			"tags": common.TagsSchema(),
+			"manage_master_password":     common.ManageMasterPasswordSchema(),
+			"master_password_version_id": common.MasterPasswordVersionSchema(),
+			"tags":                       common.TagsSchema(),
 			"time_zone": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -635,6 +648,13 @@ func resourceRdsInstanceCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 	requestBody := buildCreateInstanceBodyParams(d, cfg, region)
 	requestBody["password"] = utils.ValueIgnoreEmpty(d.Get("db.0.password"))
+	if common.IsManagedMasterPassword(d) {
+		password, err := common.CreateManagedMasterPassword(d, cfg, region, d.Get("name").(string)+"-master-password")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		requestBody["password"] = password
+	}
 	createOpt.JSONBody = utils.RemoveNil(requestBody)
 
 	createResp, err := client.Request("POST", createPath, &createOpt)
@@ -867,6 +887,10 @@ func buildCreateInstanceUnchangeableParamBodyParams(d *schema.ResourceData) map[
 }
 
 func buildCreateInstanceRestoreBodyParams(d *schema.ResourceData) map[string]interface{} {
+	if restorePoint := common.BuildRestorePointBodyParams(d); restorePoint != nil {
+		return restorePoint
+	}
+
 	restoreRaw := d.Get("restore").([]interface{})
 	if len(restoreRaw) == 0 {
 		return nil
@@ -1087,18 +1111,16 @@ func setRdsInstanceParameters(ctx context.Context, d *schema.ResourceData, clien
 			log.Printf("error saving parameters to RDS instance (%s): %s", d.Id(), err)
 		}
 		if len(paramRestart) > 0 && ctx.Value(ctxType("parametersChanged")) == "true" {
-			diagnostics = append(diagnostics, diag.Diagnostic{
-				Severity: diag.Warning,
-				Summary:  "Parameters Changed",
-				Detail:   fmt.Sprintf("Parameters %s changed which needs reboot.", paramRestart),
-			})
+			diagnostics = append(diagnostics, common.BuildParameterRestartWarning(paramRestart,
+				rdsInstanceRestartResource)...)
 		}
 	}
 	if configurationRestart && ctx.Value(ctxType("configurationChanged")) == "true" {
 		diagnostics = append(diagnostics, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "Configuration Changed",
-			Detail:   "Configuration changed which needs reboot.",
+			Detail: fmt.Sprintf("Configuration changed which needs reboot, please restart the instance by the "+
+				"%s resource to make it take effect.", rdsInstanceRestartResource),
 		})
 	}
 	if len(diagnostics) > 0 {
@@ -1334,6 +1356,11 @@ func resourceRdsInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
+	versionWarnings, err := checkRdsInstanceDbVersion(d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err = updateRdsInstanceName(ctx, d, client); err != nil {
 		return diag.FromErr(err)
 	}
@@ -1393,6 +1420,14 @@ func resourceRdsInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
+	err = common.UpdateManagedMasterPassword(d, cfg, region, d.Get("name").(string)+"-master-password",
+		func(password string) error {
+			return resetRdsRootPassword(ctx, d, client, password)
+		})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	if d.HasChange("tags") {
 		tagErr := utils.UpdateResourceTags(client, d, "instances", instanceID)
 		if tagErr != nil {
@@ -1489,7 +1524,7 @@ func resourceRdsInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	return resourceRdsInstanceRead(ctx, d, meta)
+	return append(versionWarnings, resourceRdsInstanceRead(ctx, d, meta)...)
 }
 
 func resourceRdsInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -1547,6 +1582,9 @@ func resourceRdsInstanceDelete(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error waiting for rds instance (%s) to be deleted: %s ", id, err)
 	}
 
+	if err = common.DeleteManagedMasterPassword(d, cfg, cfg.GetRegion(d)); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
@@ -2724,8 +2762,58 @@ func rdsInstanceStateRefreshFunc(client *golangsdk.ServiceClient, instanceID str
 	}
 }
 
+// suppressDbVersionUpgradedDiff suppresses the diff when the remote version is a newer minor version of the configured
+// major version, e.g. the configured version is 8.0 and the remote version is 8.0.28.
+func suppressDbVersionUpgradedDiff(_, oldVal, newVal string, d *schema.ResourceData) bool {
+	if oldVal == "" || newVal == "" {
+		return false
+	}
+	return isNewerMinorVersion(d.Get("db.0.type").(string), oldVal, newVal)
+}
+
+// checkDbVersionChange forces a new instance when the DB version is changed, except for the major version upgrade,
+// which is performed by the huaweicloud_rds_instance_major_version_upgrade resource.
+func checkDbVersionChange(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("db.0.version") {
+		return nil
+	}
+
+	oldVal, newVal := d.GetChange("db.0.version")
+	if isMajorVersionUpgrade(d.Get("db.0.type").(string), oldVal.(string), newVal.(string)) {
+		return nil
+	}
+	return d.ForceNew("db.0.version")
+}
+
+// checkRdsInstanceDbVersion returns a warning if the instance has not been upgraded to the new major version, the
+// upgrade is performed by the huaweicloud_rds_instance_major_version_upgrade resource, which may be applied after the
+// instance.
+func checkRdsInstanceDbVersion(d *schema.ResourceData, client *golangsdk.ServiceClient) (diag.Diagnostics, error) {
+	if !d.HasChange("db.0.version") {
+		return nil, nil
+	}
+
+	instance, err := GetRdsInstanceByID(client, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving RDS instance (%s): %s", d.Id(), err)
+	}
+	version := utils.PathSearch("datastore.version", instance, "").(string)
+	newVersion := d.Get("db.0.version").(string)
+	if !isMajorVersionUpgrade(d.Get("db.0.type").(string), version, newVersion) {
+		return nil, nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The version of RDS instance (%s) is still %s", d.Id(), version),
+			Detail: fmt.Sprintf("The version is not changed by the instance resource, please upgrade it to %s by the "+
+				"huaweicloud_rds_instance_major_version_upgrade resource.", newVersion),
+		},
+	}, nil
+}
+
 func updateRdsRootPassword(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient) error {
-	if !d.HasChange("db.0.password") {
+	if !d.HasChange("db.0.password") || common.IsManagedMasterPassword(d) {
 		return nil
 	}
 
@@ -2743,6 +2831,23 @@ func updateRdsRootPassword(ctx context.Context, d *schema.ResourceData, client *
 	return nil
 }
 
+// resetRdsRootPassword sets the root password generated by the managed master password mode.
+func resetRdsRootPassword(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient,
+	password string) error {
+	_, err := updateRdsInstanceField(ctx, d, client, updateInstanceFieldParams{
+		httpUrl:          "v3/{project_id}/instances/{instance_id}/password",
+		httpMethod:       "POST",
+		pathParams:       map[string]string{"instance_id": d.Id()},
+		updateBodyParams: map[string]interface{}{"db_user_pwd": password},
+		isRetry:          true,
+		timeout:          schema.TimeoutUpdate,
+	})
+	if err != nil {
+		return fmt.Errorf("error resetting instance(%s) root password: %s", d.Id(), err)
+	}
+	return nil
+}
+
 func buildUpdateInstancePasswordBodyParams(d *schema.ResourceData) map[string]interface{} {
 	bodyParams := map[string]interface{}{
 		"db_user_pwd": d.Get("db.0.password"),
@@ -0,0 +1,237 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+var dbInstanceMajorVersionUpgradeNonUpdatableParams = []string{"instance_id", "target_version",
+	"is_change_private_ip", "statistics_collection_mode", "skip_check"}
+
+// @API RDS GET /v3/{project_id}/instances
+// @API RDS GET /v3/{project_id}/jobs
+// @API RDS POST /v3/{project_id}/instances/{instance_id}/major-version/check
+// @API RDS GET /v3/{project_id}/instances/{instance_id}/major-version/status
+// @API RDS POST /v3/{project_id}/instances/{instance_id}/major-version/upgrade
+func ResourceRdsInstanceMajorVersionUpgrade() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRdsInstanceMajorVersionUpgradeCreate,
+		ReadContext:   resourceRdsInstanceMajorVersionUpgradeRead,
+		UpdateContext: resourceRdsInstanceMajorVersionUpgradeUpdate,
+		DeleteContext: resourceRdsInstanceMajorVersionUpgradeDelete,
+
+		CustomizeDiff: config.FlexibleForceNew(dbInstanceMajorVersionUpgradeNonUpdatableParams),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"target_version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"is_change_private_ip": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"statistics_collection_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"skip_check": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enable_force_new": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"true", "false"}, false),
+				Description:  utils.SchemaDesc("", utils.SchemaDescInput{Internal: true}),
+			},
+		},
+	}
+}
+
+func resourceRdsInstanceMajorVersionUpgradeCreate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("rds", region)
+	if err != nil {
+		return diag.Errorf("error creating RDS client: %s", err)
+	}
+
+	var (
+		instanceId    = d.Get("instance_id").(string)
+		targetVersion = d.Get("target_version").(string)
+		timeout       = d.Timeout(schema.TimeoutCreate)
+	)
+
+	if !d.Get("skip_check").(bool) {
+		report, err := checkMajorVersionUpgrade(ctx, client, instanceId, targetVersion, timeout)
+		if err != nil {
+			return diag.Errorf("error checking major version upgrade of RDS instance (%s): %s", instanceId, err)
+		}
+		if status := utils.PathSearch("status", report, "").(string); status != "success" {
+			return diag.Errorf("the major version upgrade check of RDS instance (%s) is %s: %v", instanceId, status,
+				utils.PathSearch("report", report, nil))
+		}
+	}
+
+	if err = upgradeMajorVersion(ctx, d, client); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(instanceId)
+
+	if err = waitForMajorVersionUpgraded(ctx, client, instanceId, targetVersion, timeout); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceRdsInstanceMajorVersionUpgradeRead(ctx, d, meta)
+}
+
+func upgradeMajorVersion(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+	var (
+		httpUrl    = "v3/{project_id}/instances/{instance_id}/major-version/upgrade"
+		instanceId = d.Get("instance_id").(string)
+	)
+	upgradePath := client.Endpoint + httpUrl
+	upgradePath = strings.ReplaceAll(upgradePath, "{project_id}", client.ProjectID)
+	upgradePath = strings.ReplaceAll(upgradePath, "{instance_id}", instanceId)
+
+	upgradeOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders:      map[string]string{"Content-Type": "application/json"},
+		JSONBody: utils.RemoveNil(map[string]interface{}{
+			"target_version":             d.Get("target_version"),
+			"is_change_private_ip":       d.Get("is_change_private_ip"),
+			"statistics_collection_mode": utils.ValueIgnoreEmpty(d.Get("statistics_collection_mode")),
+		}),
+	}
+	retryFunc := func() (interface{}, bool, error) {
+		res, err := client.Request("POST", upgradePath, &upgradeOpt)
+		retry, err := handleMultiOperationsError(err)
+		return res, retry, err
+	}
+	upgradeResp, err := common.RetryContextWithWaitForState(&common.RetryContextWithWaitForStateParam{
+		Ctx:          ctx,
+		RetryFunc:    retryFunc,
+		WaitFunc:     rdsInstanceStateRefreshFunc(client, instanceId),
+		WaitTarget:   []string{"ACTIVE"},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		DelayTimeout: 1 * time.Second,
+		PollInterval: 10 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("error upgrading major version of RDS instance (%s): %s", instanceId, err)
+	}
+
+	upgradeRespBody, err := utils.FlattenResponse(upgradeResp.(*http.Response))
+	if err != nil {
+		return err
+	}
+	jobId := utils.PathSearch("job_id", upgradeRespBody, "").(string)
+	if jobId == "" {
+		return fmt.Errorf("unable to find the job ID of the major version upgrade from the API response")
+	}
+	return checkRDSInstanceJobFinish(client, jobId, d.Timeout(schema.TimeoutCreate))
+}
+
+func waitForMajorVersionUpgraded(ctx context.Context, client *golangsdk.ServiceClient, instanceId,
+	targetVersion string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"PENDING"},
+		Target:  []string{"COMPLETED"},
+		Refresh: func() (interface{}, string, error) {
+			instance, err := GetRdsInstanceByID(client, instanceId)
+			if err != nil {
+				return nil, "ERROR", err
+			}
+			var (
+				dbType  = utils.PathSearch("datastore.type", instance, "").(string)
+				version = utils.PathSearch("datastore.version", instance, "").(string)
+				status  = utils.PathSearch("status", instance, "").(string)
+			)
+			// The remote version may be a minor version of the target version, e.g. 8.0.28 of 8.0.
+			if isSameMajorVersion(dbType, version, targetVersion) && status == "ACTIVE" {
+				return instance, "COMPLETED", nil
+			}
+			return instance, "PENDING", nil
+		},
+		Timeout:      timeout,
+		Delay:        10 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for RDS instance (%s) to be upgraded to version (%s): %s", instanceId,
+			targetVersion, err)
+	}
+	return nil
+}
+
+func resourceRdsInstanceMajorVersionUpgradeRead(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("rds", region)
+	if err != nil {
+		return diag.Errorf("error creating RDS client: %s", err)
+	}
+
+	instance, err := GetRdsInstanceByID(client, d.Id())
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "error retrieving RDS instance")
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("version", utils.PathSearch("datastore.version", instance, nil)),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+func resourceRdsInstanceMajorVersionUpgradeUpdate(_ context.Context, _ *schema.ResourceData,
+	_ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceRdsInstanceMajorVersionUpgradeDelete(_ context.Context, _ *schema.ResourceData,
+	_ interface{}) diag.Diagnostics {
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary: "Deleting major version upgrade resource is not supported. The instance will not be rolled back " +
+				"and this resource is only removed from the state.",
+		},
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -34,7 +35,10 @@ func ResourceRdsConfiguration() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		CustomizeDiff: config.FlexibleForceNew(configurationNonUpdatableParams),
+		CustomizeDiff: customdiff.All(
+			config.FlexibleForceNew(configurationNonUpdatableParams),
+			checkRdsConfigurationValues,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -267,6 +271,8 @@ func resourceRdsConfigurationUpdate(ctx context.Context, d *schema.ResourceData,
 	cfg := meta.(*config.Config)
 	region := cfg.GetRegion(d)
 
+	oldValues, newValues := d.GetChange("values")
+	changedValues := common.GetChangedParameters(oldValues.(map[string]interface{}), newValues.(map[string]interface{}))
 	if d.HasChanges("name", "description", "values") {
 		var (
 			httpUrl = "v3/{project_id}/configurations/{config_id}"
@@ -291,7 +297,24 @@ func resourceRdsConfigurationUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	return resourceRdsConfigurationRead(ctx, d, meta)
+	diags := resourceRdsConfigurationRead(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, buildRdsConfigurationRestartWarning(d, changedValues)...)
+}
+
+// buildRdsConfigurationRestartWarning returns the warning of the changed values which require a restart of the
+// instances to which the parameter template is applied.
+func buildRdsConfigurationRestartWarning(d *schema.ResourceData, changedValues map[string]string) diag.Diagnostics {
+	restartNames := make([]string, 0)
+	for _, v := range d.Get("configuration_parameters").([]interface{}) {
+		param := v.(map[string]interface{})
+		if _, ok := changedValues[param["name"].(string)]; ok && param["restart_required"].(bool) {
+			restartNames = append(restartNames, param["name"].(string))
+		}
+	}
+	return common.BuildParameterRestartWarning(restartNames, rdsInstanceRestartResource)
 }
 
 func buildUpdateConfigurationBodyParams(d *schema.ResourceData) map[string]interface{} {
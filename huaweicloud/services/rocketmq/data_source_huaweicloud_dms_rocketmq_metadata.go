@@ -0,0 +1,67 @@
+package rocketmq
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// DataSourceDmsRocketMQMetadata is the impl for huaweicloud_dms_rocketmq_metadata data source, which exports the
+// metadata of all topics and consumer groups of the RocketMQ instance for backups.
+// @API RocketMQ GET /v2/{project_id}/instances/{instance_id}/topics
+// @API RocketMQ GET /v2/{project_id}/instances/{instance_id}/groups
+func DataSourceDmsRocketMQMetadata() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDmsRocketMQMetadataRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: `The region where the RocketMQ instance is located.`,
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The ID of the RocketMQ instance.`,
+			},
+			"metadata": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The RocketMQ metadata of all topics and consumer groups of the instance, in JSON format.`,
+			},
+		},
+	}
+}
+
+func dataSourceDmsRocketMQMetadataRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("dmsv2", region)
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	metadata, err := exportRocketmqMetadata(client, d.Get("instance_id").(string))
+	if err != nil {
+		return diag.Errorf("error exporting metadata of the RocketMQ instance: %s", err)
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(id)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("metadata", metadata.String()),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
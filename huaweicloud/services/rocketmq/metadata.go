@@ -0,0 +1,271 @@
+package rocketmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const (
+	// The attribute of the topic which is used to specify the message type in the RocketMQ 5.x metadata.
+	rocketmqMessageTypeAttribute = "+message.type"
+	// The default maximum number of retry times of the consumer group in the RocketMQ metadata.
+	rocketmqDefaultRetryMaxTimes = 16
+	rocketmqMetadataPageLimit    = 50
+)
+
+// The permission values of the RocketMQ metadata and the corresponding permission of the DMS APIs.
+var rocketmqPermissions = map[int]string{
+	2: "pub",
+	4: "sub",
+	6: "all",
+}
+
+// rocketmqMetadata is the subset of the RocketMQ metadata, which consists of the topic configurations and the
+// subscription group configurations exported from the broker.
+type rocketmqMetadata struct {
+	TopicConfigTable       map[string]*rocketmqTopicConfig             `json:"topicConfigTable"`
+	SubscriptionGroupTable map[string]*rocketmqSubscriptionGroupConfig `json:"subscriptionGroupTable"`
+}
+
+type rocketmqTopicConfig struct {
+	TopicName      string            `json:"topicName"`
+	Perm           int               `json:"perm"`
+	ReadQueueNums  int               `json:"readQueueNums"`
+	WriteQueueNums int               `json:"writeQueueNums"`
+	Attributes     map[string]string `json:"attributes,omitempty"`
+}
+
+type rocketmqSubscriptionGroupConfig struct {
+	GroupName              string `json:"groupName"`
+	ConsumeEnable          *bool  `json:"consumeEnable"`
+	ConsumeBroadcastEnable *bool  `json:"consumeBroadcastEnable"`
+	ConsumeMessageOrderly  *bool  `json:"consumeMessageOrderly"`
+	RetryMaxTimes          *int   `json:"retryMaxTimes"`
+}
+
+func (t *rocketmqTopicConfig) messageType() string {
+	return t.Attributes[rocketmqMessageTypeAttribute]
+}
+
+// parseRocketmqMetadata parses the metadata JSON, the unsupported fields of the topics and the groups are ignored.
+func parseRocketmqMetadata(content string) (*rocketmqMetadata, error) {
+	var metadata rocketmqMetadata
+	if content != "" {
+		if err := json.Unmarshal([]byte(content), &metadata); err != nil {
+			return nil, fmt.Errorf("error parsing the metadata: %s", err)
+		}
+	}
+	metadata.normalize()
+	return &metadata, nil
+}
+
+func (m *rocketmqMetadata) normalize() {
+	if m.TopicConfigTable == nil {
+		m.TopicConfigTable = make(map[string]*rocketmqTopicConfig)
+	}
+	if m.SubscriptionGroupTable == nil {
+		m.SubscriptionGroupTable = make(map[string]*rocketmqSubscriptionGroupConfig)
+	}
+
+	for name, topic := range m.TopicConfigTable {
+		if topic == nil {
+			topic = &rocketmqTopicConfig{}
+			m.TopicConfigTable[name] = topic
+		}
+		topic.TopicName = name
+		if topic.Perm == 0 {
+			topic.Perm = 6
+		}
+		if topic.ReadQueueNums == 0 {
+			topic.ReadQueueNums = topic.WriteQueueNums
+		}
+		// Only the message type is supported by the DMS APIs.
+		if messageType := topic.messageType(); messageType != "" {
+			topic.Attributes = map[string]string{rocketmqMessageTypeAttribute: strings.ToUpper(messageType)}
+		} else {
+			topic.Attributes = nil
+		}
+	}
+
+	for name, group := range m.SubscriptionGroupTable {
+		if group == nil {
+			group = &rocketmqSubscriptionGroupConfig{}
+			m.SubscriptionGroupTable[name] = group
+		}
+		group.GroupName = name
+		if group.ConsumeEnable == nil {
+			group.ConsumeEnable = utils.Bool(true)
+		}
+		if group.ConsumeBroadcastEnable == nil {
+			group.ConsumeBroadcastEnable = utils.Bool(false)
+		}
+		if group.ConsumeMessageOrderly == nil {
+			group.ConsumeMessageOrderly = utils.Bool(false)
+		}
+		if group.RetryMaxTimes == nil {
+			group.RetryMaxTimes = utils.Int(rocketmqDefaultRetryMaxTimes)
+		}
+	}
+}
+
+func (m *rocketmqMetadata) String() string {
+	m.normalize()
+	// The keys of the maps are sorted by the marshaling, so the result is stable.
+	content, err := json.Marshal(m)
+	if err != nil {
+		log.Printf("[ERROR] error marshaling the RocketMQ metadata: %s", err)
+		return ""
+	}
+	return string(content)
+}
+
+// validate checks whether the metadata can be applied by the DMS APIs.
+func (m *rocketmqMetadata) validate() error {
+	var mErr *multierror.Error
+	for name, topic := range m.TopicConfigTable {
+		if topic.WriteQueueNums <= 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("the writeQueueNums of the topic (%s) must be greater than 0",
+				name))
+		}
+		if _, ok := rocketmqPermissions[topic.Perm]; !ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("the perm (%d) of the topic (%s) is invalid, valid values are "+
+				"2, 4 and 6", topic.Perm, name))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// filter returns the topics and the groups of the metadata which are also included by the managed metadata. The
+// message types which are not managed are ignored.
+func (m *rocketmqMetadata) filter(managed *rocketmqMetadata) *rocketmqMetadata {
+	result := rocketmqMetadata{
+		TopicConfigTable:       make(map[string]*rocketmqTopicConfig),
+		SubscriptionGroupTable: make(map[string]*rocketmqSubscriptionGroupConfig),
+	}
+	for name, topic := range m.TopicConfigTable {
+		managedTopic, ok := managed.TopicConfigTable[name]
+		if !ok {
+			continue
+		}
+		if managedTopic.messageType() == "" {
+			topic.Attributes = nil
+		}
+		result.TopicConfigTable[name] = topic
+	}
+	for name, group := range m.SubscriptionGroupTable {
+		if _, ok := managed.SubscriptionGroupTable[name]; ok {
+			result.SubscriptionGroupTable[name] = group
+		}
+	}
+	return &result
+}
+
+// normalizeRocketmqMetadata is the state function of the metadata, the invalid JSON is kept as it is and reported by
+// the validation.
+func normalizeRocketmqMetadata(v interface{}) string {
+	metadata, err := parseRocketmqMetadata(v.(string))
+	if err != nil {
+		return v.(string)
+	}
+	return metadata.String()
+}
+
+func buildRocketmqMetadataPath(client *golangsdk.ServiceClient, instanceId, httpUrl string) string {
+	path := client.Endpoint + httpUrl
+	path = strings.ReplaceAll(path, "{project_id}", client.ProjectID)
+	return strings.ReplaceAll(path, "{instance_id}", instanceId)
+}
+
+// listRocketmqMetadataItems queries all items of the paged list API.
+func listRocketmqMetadataItems(client *golangsdk.ServiceClient, listPath, itemsKey string) ([]interface{}, error) {
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+
+	listPath += fmt.Sprintf("?limit=%d", rocketmqMetadataPageLimit)
+	result := make([]interface{}, 0)
+	offset := 0
+	for {
+		listResp, err := client.Request("GET", listPath+fmt.Sprintf("&offset=%d", offset), &listOpt)
+		if err != nil {
+			return nil, err
+		}
+		listRespBody, err := utils.FlattenResponse(listResp)
+		if err != nil {
+			return nil, err
+		}
+		items := utils.PathSearch(itemsKey, listRespBody, make([]interface{}, 0)).([]interface{})
+		result = append(result, items...)
+
+		offset += rocketmqMetadataPageLimit
+		total := utils.PathSearch("total", listRespBody, float64(0)).(float64)
+		if len(items) == 0 || int(total) <= offset {
+			return result, nil
+		}
+	}
+}
+
+func flattenRocketmqMetadataTopic(topic interface{}) *rocketmqTopicConfig {
+	result := rocketmqTopicConfig{
+		TopicName:      utils.PathSearch("name", topic, "").(string),
+		ReadQueueNums:  int(utils.PathSearch("total_read_queue_num", topic, float64(0)).(float64)),
+		WriteQueueNums: int(utils.PathSearch("total_write_queue_num", topic, float64(0)).(float64)),
+	}
+	permission := utils.PathSearch("permission", topic, "").(string)
+	for perm, v := range rocketmqPermissions {
+		if v == permission {
+			result.Perm = perm
+		}
+	}
+	if messageType := utils.PathSearch("message_type", topic, "").(string); messageType != "" {
+		result.Attributes = map[string]string{rocketmqMessageTypeAttribute: messageType}
+	}
+	return &result
+}
+
+func flattenRocketmqMetadataGroup(group interface{}) *rocketmqSubscriptionGroupConfig {
+	return &rocketmqSubscriptionGroupConfig{
+		GroupName:              utils.PathSearch("name", group, "").(string),
+		ConsumeEnable:          utils.Bool(utils.PathSearch("enabled", group, false).(bool)),
+		ConsumeBroadcastEnable: utils.Bool(utils.PathSearch("broadcast", group, false).(bool)),
+		ConsumeMessageOrderly:  utils.Bool(utils.PathSearch("consume_orderly", group, false).(bool)),
+		RetryMaxTimes:          utils.Int(int(utils.PathSearch("retry_max_time", group, float64(0)).(float64))),
+	}
+}
+
+// exportRocketmqMetadata queries the metadata of all topics and consumer groups of the instance.
+func exportRocketmqMetadata(client *golangsdk.ServiceClient, instanceId string) (*rocketmqMetadata, error) {
+	topics, err := listRocketmqMetadataItems(client,
+		buildRocketmqMetadataPath(client, instanceId, "v2/{project_id}/instances/{instance_id}/topics"), "topics")
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving topics: %s", err)
+	}
+	groups, err := listRocketmqMetadataItems(client,
+		buildRocketmqMetadataPath(client, instanceId, "v2/{project_id}/instances/{instance_id}/groups"), "groups")
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving consumer groups: %s", err)
+	}
+
+	metadata := rocketmqMetadata{
+		TopicConfigTable:       make(map[string]*rocketmqTopicConfig),
+		SubscriptionGroupTable: make(map[string]*rocketmqSubscriptionGroupConfig),
+	}
+	for _, v := range topics {
+		topic := flattenRocketmqMetadataTopic(v)
+		metadata.TopicConfigTable[topic.TopicName] = topic
+	}
+	for _, v := range groups {
+		group := flattenRocketmqMetadataGroup(v)
+		metadata.SubscriptionGroupTable[group.GroupName] = group
+	}
+	metadata.normalize()
+	return &metadata, nil
+}
@@ -0,0 +1,370 @@
+package rocketmq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/chnsz/golangsdk"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// ResourceDmsRocketMQMetadata is the impl for huaweicloud_dms_rocketmq_metadata resource, which reconciles the topics
+// and the consumer groups of the RocketMQ metadata JSON against the instance.
+// @API RocketMQ GET /v2/{project_id}/instances/{instance_id}/topics
+// @API RocketMQ POST /v2/{project_id}/instances/{instance_id}/topics
+// @API RocketMQ GET /v2/{project_id}/instances/{instance_id}/topics/{topic}
+// @API RocketMQ PUT /v2/{project_id}/instances/{instance_id}/topics/{topic}
+// @API RocketMQ DELETE /v2/{project_id}/instances/{instance_id}/topics/{topic}
+// @API RocketMQ GET /v2/{project_id}/instances/{instance_id}/groups
+// @API RocketMQ POST /v2/{project_id}/instances/{instance_id}/groups
+// @API RocketMQ PUT /v2/{project_id}/instances/{instance_id}/groups/{group}
+// @API RocketMQ DELETE /v2/{project_id}/instances/{instance_id}/groups/{group}
+func ResourceDmsRocketMQMetadata() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDmsRocketMQMetadataCreate,
+		ReadContext:   resourceDmsRocketMQMetadataRead,
+		UpdateContext: resourceDmsRocketMQMetadataUpdate,
+		DeleteContext: resourceDmsRocketMQMetadataDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: `The region where the RocketMQ instance is located.`,
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: `The ID of the RocketMQ instance.`,
+			},
+			"metadata": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateRocketmqMetadata,
+				StateFunc:    normalizeRocketmqMetadata,
+				Description:  `The RocketMQ metadata of the managed topics and consumer groups, in JSON format.`,
+			},
+			"brokers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The brokers associated with the topics and the consumer groups when they are created.`,
+			},
+		},
+	}
+}
+
+func validateRocketmqMetadata(v interface{}, k string) (ws []string, errs []error) {
+	metadata, err := parseRocketmqMetadata(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q is invalid: %s", k, err)}
+	}
+	if err = metadata.validate(); err != nil {
+		return nil, []error{fmt.Errorf("%q is invalid: %s", k, err)}
+	}
+	return nil, nil
+}
+
+func getRocketmqMetadataTopic(client *golangsdk.ServiceClient, instanceId, name string) (interface{}, error) {
+	getPath := buildRocketmqMetadataPath(client, instanceId, "v2/{project_id}/instances/{instance_id}/topics/{topic}")
+	getPath = strings.ReplaceAll(getPath, "{topic}", name)
+	getOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	getResp, err := client.Request("GET", getPath, &getOpt)
+	if err != nil {
+		return nil, err
+	}
+	return utils.FlattenResponse(getResp)
+}
+
+func createRocketmqMetadataTopic(client *golangsdk.ServiceClient, instanceId string, topic *rocketmqTopicConfig,
+	brokers []interface{}) error {
+	createPath := buildRocketmqMetadataPath(client, instanceId, "v2/{project_id}/instances/{instance_id}/topics")
+	createOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		JSONBody: utils.RemoveNil(map[string]interface{}{
+			"name":         topic.TopicName,
+			"queue_num":    topic.WriteQueueNums,
+			"permission":   rocketmqPermissions[topic.Perm],
+			"message_type": utils.ValueIgnoreEmpty(topic.messageType()),
+			"brokers":      utils.ValueIgnoreEmpty(brokers),
+		}),
+	}
+	if _, err := client.Request("POST", createPath, &createOpt); err != nil {
+		return fmt.Errorf("error creating topic (%s): %s", topic.TopicName, err)
+	}
+	return nil
+}
+
+func updateRocketmqMetadataTopic(client *golangsdk.ServiceClient, instanceId string, topic *rocketmqTopicConfig) error {
+	updatePath := buildRocketmqMetadataPath(client, instanceId, "v2/{project_id}/instances/{instance_id}/topics/{topic}")
+	updatePath = strings.ReplaceAll(updatePath, "{topic}", topic.TopicName)
+	updateOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          []int{204},
+		JSONBody: map[string]interface{}{
+			"read_queue_num":  topic.ReadQueueNums,
+			"write_queue_num": topic.WriteQueueNums,
+			"permission":      rocketmqPermissions[topic.Perm],
+		},
+	}
+	if _, err := client.Request("PUT", updatePath, &updateOpt); err != nil {
+		return fmt.Errorf("error updating topic (%s): %s", topic.TopicName, err)
+	}
+	return nil
+}
+
+func deleteRocketmqMetadataObject(client *golangsdk.ServiceClient, instanceId, httpUrl, name string) error {
+	deletePath := buildRocketmqMetadataPath(client, instanceId, httpUrl)
+	deletePath = strings.ReplaceAll(deletePath, "{name}", name)
+	deleteOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          []int{200, 204},
+	}
+	_, err := client.Request("DELETE", deletePath, &deleteOpt)
+	if _, ok := err.(golangsdk.ErrDefault404); ok {
+		return nil
+	}
+	return err
+}
+
+func applyRocketmqMetadataGroup(client *golangsdk.ServiceClient, instanceId string,
+	group *rocketmqSubscriptionGroupConfig, brokers []interface{}, exists bool) error {
+	bodyParams := map[string]interface{}{
+		"enabled":         *group.ConsumeEnable,
+		"broadcast":       *group.ConsumeBroadcastEnable,
+		"consume_orderly": *group.ConsumeMessageOrderly,
+		"retry_max_time":  *group.RetryMaxTimes,
+	}
+	var (
+		method  = "PUT"
+		httpUrl = "v2/{project_id}/instances/{instance_id}/groups/{group}"
+		okCodes = []int{204}
+	)
+	if !exists {
+		method = "POST"
+		httpUrl = "v2/{project_id}/instances/{instance_id}/groups"
+		okCodes = []int{200}
+		bodyParams["name"] = group.GroupName
+		if len(brokers) > 0 {
+			bodyParams["brokers"] = brokers
+		}
+	}
+
+	requestPath := buildRocketmqMetadataPath(client, instanceId, httpUrl)
+	requestPath = strings.ReplaceAll(requestPath, "{group}", group.GroupName)
+	requestOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		OkCodes:          okCodes,
+		JSONBody:         bodyParams,
+	}
+	if _, err := client.Request(method, requestPath, &requestOpt); err != nil {
+		return fmt.Errorf("error applying consumer group (%s): %s", group.GroupName, err)
+	}
+	return nil
+}
+
+// applyRocketmqMetadata reconciles the topics and the consumer groups of the instance from the old metadata to the
+// new metadata. The message type of the existing topics can not be changed.
+func applyRocketmqMetadata(client *golangsdk.ServiceClient, instanceId string, oldMetadata,
+	newMetadata *rocketmqMetadata, brokers []interface{}) error {
+	remote, err := exportRocketmqMetadata(client, instanceId)
+	if err != nil {
+		return err
+	}
+
+	for name, group := range oldMetadata.SubscriptionGroupTable {
+		if _, ok := newMetadata.SubscriptionGroupTable[name]; ok {
+			continue
+		}
+		if _, ok := remote.SubscriptionGroupTable[name]; !ok {
+			continue
+		}
+		err = deleteRocketmqMetadataObject(client, instanceId, "v2/{project_id}/instances/{instance_id}/groups/{name}",
+			group.GroupName)
+		if err != nil {
+			return fmt.Errorf("error deleting consumer group (%s): %s", name, err)
+		}
+	}
+	for name, topic := range oldMetadata.TopicConfigTable {
+		if _, ok := newMetadata.TopicConfigTable[name]; ok {
+			continue
+		}
+		if _, ok := remote.TopicConfigTable[name]; !ok {
+			continue
+		}
+		err = deleteRocketmqMetadataObject(client, instanceId, "v2/{project_id}/instances/{instance_id}/topics/{name}",
+			topic.TopicName)
+		if err != nil {
+			return fmt.Errorf("error deleting topic (%s): %s", name, err)
+		}
+	}
+
+	for name, topic := range newMetadata.TopicConfigTable {
+		remoteTopic, ok := remote.TopicConfigTable[name]
+		if !ok {
+			if err = createRocketmqMetadataTopic(client, instanceId, topic, brokers); err != nil {
+				return err
+			}
+			topicDetail, err := getRocketmqMetadataTopic(client, instanceId, name)
+			if err != nil {
+				return fmt.Errorf("error retrieving topic (%s): %s", name, err)
+			}
+			remoteTopic = flattenRocketmqMetadataTopic(topicDetail)
+			remoteTopic.TopicName = name
+		} else if topic.messageType() != "" {
+			topicDetail, err := getRocketmqMetadataTopic(client, instanceId, name)
+			if err != nil {
+				return fmt.Errorf("error retrieving topic (%s): %s", name, err)
+			}
+			if messageType := utils.PathSearch("message_type", topicDetail, "").(string); messageType != "" &&
+				!strings.EqualFold(messageType, topic.messageType()) {
+				return fmt.Errorf("the message type of the topic (%s) can not be changed from %s to %s", name,
+					messageType, topic.messageType())
+			}
+		}
+
+		if remoteTopic.Perm != topic.Perm || remoteTopic.ReadQueueNums != topic.ReadQueueNums ||
+			remoteTopic.WriteQueueNums != topic.WriteQueueNums {
+			if err = updateRocketmqMetadataTopic(client, instanceId, topic); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, group := range newMetadata.SubscriptionGroupTable {
+		remoteGroup, ok := remote.SubscriptionGroupTable[name]
+		if ok && utils.JsonToString(remoteGroup) == utils.JsonToString(group) {
+			continue
+		}
+		if err = applyRocketmqMetadataGroup(client, instanceId, group, brokers, ok); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceDmsRocketMQMetadataCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dmsv2", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	instanceId := d.Get("instance_id").(string)
+	metadata, err := parseRocketmqMetadata(d.Get("metadata").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = applyRocketmqMetadata(client, instanceId, &rocketmqMetadata{}, metadata, d.Get("brokers").([]interface{}))
+	if err != nil {
+		return diag.Errorf("error applying metadata to the RocketMQ instance (%s): %s", instanceId, err)
+	}
+
+	d.SetId(instanceId)
+
+	return resourceDmsRocketMQMetadataRead(ctx, d, meta)
+}
+
+func resourceDmsRocketMQMetadataRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NewServiceClient("dmsv2", region)
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	remote, err := exportRocketmqMetadata(client, d.Id())
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "error retrieving metadata of the RocketMQ instance")
+	}
+
+	// All topics and groups are managed after the import, otherwise only the managed ones are refreshed, and the ones
+	// which are removed outside are reported as the changes of the metadata.
+	if managed := d.Get("metadata").(string); managed != "" {
+		managedMetadata, err := parseRocketmqMetadata(managed)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		remote = remote.filter(managedMetadata)
+
+		// The message type is only returned by the topic details.
+		for name, topic := range remote.TopicConfigTable {
+			if managedMetadata.TopicConfigTable[name].messageType() == "" {
+				continue
+			}
+			topicDetail, err := getRocketmqMetadataTopic(client, d.Id(), name)
+			if err != nil {
+				return diag.Errorf("error retrieving topic (%s): %s", name, err)
+			}
+			topic.Attributes = flattenRocketmqMetadataTopic(topicDetail).Attributes
+		}
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("instance_id", d.Id()),
+		d.Set("metadata", remote.String()),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
+
+func resourceDmsRocketMQMetadataUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dmsv2", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	if d.HasChange("metadata") {
+		oldRaw, newRaw := d.GetChange("metadata")
+		oldMetadata, err := parseRocketmqMetadata(oldRaw.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		newMetadata, err := parseRocketmqMetadata(newRaw.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = applyRocketmqMetadata(client, d.Id(), oldMetadata, newMetadata, d.Get("brokers").([]interface{}))
+		if err != nil {
+			return diag.Errorf("error applying metadata to the RocketMQ instance (%s): %s", d.Id(), err)
+		}
+	}
+
+	return resourceDmsRocketMQMetadataRead(ctx, d, meta)
+}
+
+func resourceDmsRocketMQMetadataDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NewServiceClient("dmsv2", cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating DMS client: %s", err)
+	}
+
+	metadata, err := parseRocketmqMetadata(d.Get("metadata").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err = applyRocketmqMetadata(client, d.Id(), metadata, &rocketmqMetadata{}, nil); err != nil {
+		return common.CheckDeletedDiag(d, err, fmt.Sprintf("error deleting metadata of the RocketMQ instance (%s)",
+			d.Id()))
+	}
+	return nil
+}
@@ -40,6 +40,7 @@ type ctxType string
 // @API TaurusDB GET /v3/{project_id}/configurations
 // @API TaurusDB GET /v3/{project_id}/dedicated-resources
 // @API TaurusDB POST /v3/{project_id}/instances
+// @API TaurusDB GET /v3/{project_id}/instances/{instance_id}/restore-time
 // @API TaurusDB POST /v3/{project_id}/instance/{instance_id}/audit-log/switch
 // @API TaurusDB PUT /v3/{project_id}/instances/{instance_id}/audit-log-policy
 // @API TaurusDB GET /v3/{project_id}/instances/{instance_id}/audit-log-policy
@@ -115,6 +116,7 @@ func ResourceTaurusDBInstance() *schema.Resource {
 				return nil
 			},
 			config.MergeDefaultTags(),
+			common.CheckRestoreFromTime("gaussdb", "restore_times"),
 		),
 
 		Timeouts: &schema.ResourceTimeout{
@@ -143,6 +145,7 @@ func ResourceTaurusDBInstance() *schema.Resource {
 				Sensitive: true,
 				Required:  true,
 			},
+			"restore_from": common.RestoreFromSchema(nil),
 			"vpc_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -689,6 +692,24 @@ func GaussDBInstanceStateRefreshFunc(client *golangsdk.ServiceClient, instanceID
 	}
 }
 
+// taurusDBRestoreCreateOpts is the create options with the restore point, which creates a new instance from the backup
+// or the point-in-time of the source instance.
+type taurusDBRestoreCreateOpts struct {
+	instances.CreateTaurusDBOpts
+	RestorePoint map[string]interface{}
+}
+
+func (opts taurusDBRestoreCreateOpts) ToInstancesCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateTaurusDBOpts.ToInstancesCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.RestorePoint != nil {
+		b["restore_point"] = opts.RestorePoint
+	}
+	return b, nil
+}
+
 // nolint:gocyclo
 func resourceGaussDBInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cfg := meta.(*config.Config)
@@ -820,7 +841,11 @@ func resourceGaussDBInstanceCreate(ctx context.Context, d *schema.ResourceData,
 	// Add password here so it wouldn't go in the above log entry
 	createOpts.Password = d.Get("password").(string)
 
-	instance, err := instances.Create(client, createOpts).Extract()
+	restoreOpts := taurusDBRestoreCreateOpts{
+		CreateTaurusDBOpts: createOpts,
+		RestorePoint:       common.BuildRestorePointBodyParams(d),
+	}
+	instance, err := instances.Create(client, restoreOpts).Extract()
 	if err != nil {
 		return diag.Errorf("error creating GaussDB instance : %s", err)
 	}
@@ -0,0 +1,207 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// @API VPC GET /v2.0/ports/{id}
+// @API VPC GET /v2.0/ports
+// @API VPC GET /v1/{project_id}/subnets/{id}
+// @API VPC GET /v1/{project_id}/subnets
+// @API VPC GET /v3/{project_id}/vpc/vpcs/{vpc_id}
+// @API VPC GET /v1/{project_id}/routetables
+// @API VPC GET /v1/{project_id}/routetables/{id}
+// @API VPC GET /v2.0/vpc/peerings/{id}
+// @API VPC GET /v3/{project_id}/vpc/security-group-rules
+// @API VPC GET /v3/{project_id}/vpc/firewalls
+// @API VPC GET /v3/{project_id}/vpc/firewalls/{id}
+// @API VPC GET /v3/{project_id}/vpc/address-groups/{address_group_id}
+// @API ER GET /v3/{project_id}/enterprise-router/{er_id}/attachments
+// @API ER GET /v3/{project_id}/enterprise-router/route-tables/{route_table_id}/routes
+func DataSourceNetworkPathAnalysis() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkPathAnalysisRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: `The region in which to analyze the network path.`,
+			},
+			"source": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem:        networkPathEndpointSchema(),
+				Description: `The source of the network path.`,
+			},
+			"destination": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem:        networkPathEndpointSchema(),
+				Description: `The destination of the network path.`,
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "icmp"}, false),
+				Description:  `The protocol of the traffic.`,
+			},
+			"destination_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  `The destination port of the traffic.`,
+			},
+			"source_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  `The source port of the traffic.`,
+			},
+			"result": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The analysis result of the network path.`,
+			},
+			"hops": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        networkPathHopSchema(),
+				Description: `The hops evaluated along the network path.`,
+			},
+		},
+	}
+}
+
+func networkPathEndpointSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"port_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The ID of the port.`,
+			},
+			"ip_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The IP address.`,
+			},
+			"vpc_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The ID of the VPC to which the IP address belongs.`,
+			},
+		},
+	}
+}
+
+func networkPathHopSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The type of the hop.`,
+			},
+			"resource_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The ID of the resource evaluated in the hop.`,
+			},
+			"decision": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The decision of the hop.`,
+			},
+			"matched_rule": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The rule or route that matched the traffic.`,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The description of the decision.`,
+			},
+		},
+	}
+}
+
+func resolveNetworkPathEndpoint(analyzer *pathAnalyzer, raw []interface{}, name string) (*pathEndpoint, error) {
+	endpoint, _ := raw[0].(map[string]interface{})
+	portId, _ := endpoint["port_id"].(string)
+	ipAddress, _ := endpoint["ip_address"].(string)
+	vpcId, _ := endpoint["vpc_id"].(string)
+	if portId == "" && ipAddress == "" {
+		return nil, fmt.Errorf("one of port_id and ip_address must be specified in %s", name)
+	}
+	return analyzer.resolveEndpoint(portId, ipAddress, vpcId)
+}
+
+func flattenNetworkPathHops(hops []pathHop) []interface{} {
+	result := make([]interface{}, 0, len(hops))
+	for _, hop := range hops {
+		result = append(result, map[string]interface{}{
+			"type":         hop.hopType,
+			"resource_id":  hop.resourceId,
+			"decision":     hop.decision,
+			"matched_rule": hop.matchedRule,
+			"description":  hop.description,
+		})
+	}
+	return result
+}
+
+func dataSourceNetworkPathAnalysisRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var (
+		cfg    = meta.(*config.Config)
+		region = cfg.GetRegion(d)
+	)
+
+	protocol := d.Get("protocol").(string)
+	if protocol != "icmp" && d.Get("destination_port").(int) == 0 {
+		return diag.Errorf("destination_port is required when the protocol is %s", protocol)
+	}
+	analyzer, err := newPathAnalyzer(cfg, region, protocol, d.Get("source_port").(int),
+		d.Get("destination_port").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	source, err := resolveNetworkPathEndpoint(analyzer, d.Get("source").([]interface{}), "source")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	destination, err := resolveNetworkPathEndpoint(analyzer, d.Get("destination").([]interface{}), "destination")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := analyzer.analyze(source, destination); err != nil {
+		return diag.Errorf("error analyzing the network path: %s", err)
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(id)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("result", analyzer.result()),
+		d.Set("hops", flattenNetworkPathHops(analyzer.hops)),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
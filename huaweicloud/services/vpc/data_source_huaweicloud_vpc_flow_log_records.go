@@ -0,0 +1,510 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/flowlogs"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// @API VPC GET /v1/{project_id}/fl/flow_logs/{id}
+// @API LTS POST /v2/{project_id}/groups/{log_group_id}/streams/{log_stream_id}/content/query
+func DataSourceVpcFlowLogRecords() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVpcFlowLogRecordsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: `The region where the flow log records are located.`,
+			},
+			"flow_log_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"flow_log_id", "log_stream_id"},
+				Description:  `The ID of the flow log whose LTS log stream is queried.`,
+			},
+			"log_group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"log_stream_id"},
+				Description:  `The ID of the LTS log group to which the flow log records are delivered.`,
+			},
+			"log_stream_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"log_group_id"},
+				Description:  `The ID of the LTS log stream to which the flow log records are delivered.`,
+			},
+			"start_time": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  `The start time of the query window, in RFC3339 format.`,
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  `The end time of the query window, in RFC3339 format.`,
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ACCEPT", "REJECT"}, false),
+				Description:  `The action of the flow log records to be queried.`,
+			},
+			"source_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The source IP address of the flow log records to be queried.`,
+			},
+			"destination_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The destination IP address of the flow log records to be queried.`,
+			},
+			"destination_port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: `The destination port of the flow log records to be queried.`,
+			},
+			"protocol": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: `The IANA protocol number of the flow log records to be queried.`,
+			},
+			"max_records": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntBetween(1, 10000),
+				Description:  `The maximum number of the flow log records that match the filter parameters.`,
+			},
+			"top_talkers_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  `The maximum number of the top talkers.`,
+			},
+			"records": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        flowLogRecordSchema(),
+				Description: `The flow log records that match the filter parameters.`,
+			},
+			"top_talkers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        flowLogTopTalkerSchema(),
+				Description: `The source and destination address pairs with the most bytes.`,
+			},
+			"rejected_ports": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        flowLogRejectedPortSchema(),
+				Description: `The destination ports of the rejected flows.`,
+			},
+			"total_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The total bytes of the flow log records.`,
+			},
+			"total_packets": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The total packets of the flow log records.`,
+			},
+			"accepted_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The number of the accepted flow log records.`,
+			},
+			"rejected_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The number of the rejected flow log records.`,
+			},
+			"truncated": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: `Whether the query stops at the max_records before all log lines are read.`,
+			},
+		},
+	}
+}
+
+func flowLogRecordSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The version of the flow log record.`,
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The project ID of the flow log record.`,
+			},
+			"interface_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The ID of the network interface of the flow.`,
+			},
+			"source_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The source IP address of the flow.`,
+			},
+			"destination_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The destination IP address of the flow.`,
+			},
+			"source_port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The source port of the flow.`,
+			},
+			"destination_port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The destination port of the flow.`,
+			},
+			"protocol": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The IANA protocol number of the flow.`,
+			},
+			"packets": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The number of the packets transferred during the capture window.`,
+			},
+			"bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The number of the bytes transferred during the capture window.`,
+			},
+			"start_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The start time of the capture window, in RFC3339 format.`,
+			},
+			"end_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The end time of the capture window, in RFC3339 format.`,
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The action of the flow.`,
+			},
+			"log_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The logging status of the flow log record.`,
+			},
+		},
+	}
+}
+
+func flowLogTopTalkerSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"source_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The source IP address.`,
+			},
+			"destination_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The destination IP address.`,
+			},
+			"bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The total bytes between the addresses.`,
+			},
+			"packets": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The total packets between the addresses.`,
+			},
+			"flow_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The number of the flow log records between the addresses.`,
+			},
+		},
+	}
+}
+
+func flowLogRejectedPortSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"protocol": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The IANA protocol number of the rejected flows.`,
+			},
+			"destination_port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The destination port of the rejected flows.`,
+			},
+			"packets": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The total packets of the rejected flows.`,
+			},
+			"flow_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: `The number of the rejected flow log records.`,
+			},
+		},
+	}
+}
+
+func getFlowLogStream(cfg *config.Config, region string, d *schema.ResourceData) (groupId, streamId string, err error) {
+	flowLogId := d.Get("flow_log_id").(string)
+	if flowLogId == "" {
+		return d.Get("log_group_id").(string), d.Get("log_stream_id").(string), nil
+	}
+
+	vpcClient, err := cfg.NetworkingV1Client(region)
+	if err != nil {
+		return "", "", fmt.Errorf("error creating VPC client: %s", err)
+	}
+	fl, err := flowlogs.Get(vpcClient, flowLogId).Extract()
+	if err != nil {
+		return "", "", fmt.Errorf("error retrieving VPC flow log (%s): %s", flowLogId, err)
+	}
+	return fl.LogGroupID, fl.LogTopicID, nil
+}
+
+// queryFlowLogRecords queries the log lines of the query window page by page and returns the flow log records that
+// match the filter parameters. Only the matched records are counted toward the max_records, and the returned flag
+// reports whether the query stops at the max_records before all log lines are read.
+func queryFlowLogRecords(client *golangsdk.ServiceClient, d *schema.ResourceData, groupId,
+	streamId string) ([]*flowLogRecord, bool, error) {
+	var (
+		httpUrl    = "v2/{project_id}/groups/{log_group_id}/streams/{log_stream_id}/content/query"
+		maxRecords = d.Get("max_records").(int)
+		limit      = 500
+		lineNum    = ""
+		result     = make([]*flowLogRecord, 0)
+	)
+
+	queryPath := client.Endpoint + httpUrl
+	queryPath = strings.ReplaceAll(queryPath, "{project_id}", client.ProjectID)
+	queryPath = strings.ReplaceAll(queryPath, "{log_group_id}", groupId)
+	queryPath = strings.ReplaceAll(queryPath, "{log_stream_id}", streamId)
+	queryOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+		MoreHeaders: map[string]string{
+			"Content-Type": "application/json;charset=utf8",
+		},
+	}
+
+	for {
+		queryOpt.JSONBody = utils.RemoveNil(map[string]interface{}{
+			"start_time": fmt.Sprintf("%d", utils.ConvertTimeStrToNanoTimestamp(d.Get("start_time").(string))),
+			"end_time":   fmt.Sprintf("%d", utils.ConvertTimeStrToNanoTimestamp(d.Get("end_time").(string))),
+			"is_count":   true,
+			"limit":      limit,
+			"line_num":   utils.ValueIgnoreEmpty(lineNum),
+		})
+		resp, err := client.Request("POST", queryPath, &queryOpt)
+		if err != nil {
+			return nil, false, err
+		}
+		respBody, err := utils.FlattenResponse(resp)
+		if err != nil {
+			return nil, false, err
+		}
+
+		logs := utils.PathSearch("logs", respBody, make([]interface{}, 0)).([]interface{})
+		contents := make([]string, 0, len(logs))
+		for _, v := range logs {
+			contents = append(contents, utils.PathSearch("content", v, "").(string))
+		}
+		records := filterFlowLogRecords(d, contents)
+		if len(result)+len(records) > maxRecords {
+			return append(result, records[:maxRecords-len(result)]...), true, nil
+		}
+		result = append(result, records...)
+		if len(logs) < limit {
+			return result, false, nil
+		}
+		if len(result) == maxRecords {
+			return result, true, nil
+		}
+		lineNum = utils.PathSearch("[-1].line_num", logs, "").(string)
+	}
+}
+
+func filterFlowLogRecords(d *schema.ResourceData, contents []string) []*flowLogRecord {
+	var (
+		action     = d.Get("action").(string)
+		srcAddr    = d.Get("source_address").(string)
+		dstAddr    = d.Get("destination_address").(string)
+		dstPort    = d.Get("destination_port").(int)
+		protocol   = d.Get("protocol").(int)
+		result     = make([]*flowLogRecord, 0, len(contents))
+		skipped    = 0
+		parseError error
+	)
+
+	for _, content := range contents {
+		record, err := parseFlowLogRecord(content)
+		if err != nil {
+			skipped++
+			parseError = err
+			continue
+		}
+		if action != "" && record.action != action {
+			continue
+		}
+		if srcAddr != "" && record.srcAddr != srcAddr {
+			continue
+		}
+		if dstAddr != "" && record.dstAddr != dstAddr {
+			continue
+		}
+		if dstPort != 0 && record.dstPort != dstPort {
+			continue
+		}
+		if protocol != 0 && record.protocol != protocol {
+			continue
+		}
+		result = append(result, record)
+	}
+	if skipped > 0 {
+		log.Printf("[WARN] %d log lines are not in the flow log record format, the last error is: %s", skipped,
+			parseError)
+	}
+	return result
+}
+
+func flattenFlowLogRecords(records []*flowLogRecord) []interface{} {
+	result := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		result = append(result, map[string]interface{}{
+			"version":             record.version,
+			"project_id":          record.projectId,
+			"interface_id":        record.interfaceId,
+			"source_address":      record.srcAddr,
+			"destination_address": record.dstAddr,
+			"source_port":         record.srcPort,
+			"destination_port":    record.dstPort,
+			"protocol":            record.protocol,
+			"packets":             record.packets,
+			"bytes":               record.bytes,
+			"start_time":          utils.FormatTimeStampRFC3339(record.start, false),
+			"end_time":            utils.FormatTimeStampRFC3339(record.end, false),
+			"action":              record.action,
+			"log_status":          record.logStatus,
+		})
+	}
+	return result
+}
+
+func flattenFlowLogTopTalkers(talkers []*flowLogTalker) []interface{} {
+	result := make([]interface{}, 0, len(talkers))
+	for _, talker := range talkers {
+		result = append(result, map[string]interface{}{
+			"source_address":      talker.srcAddr,
+			"destination_address": talker.dstAddr,
+			"bytes":               talker.bytes,
+			"packets":             talker.packets,
+			"flow_count":          talker.flowCount,
+		})
+	}
+	return result
+}
+
+func flattenFlowLogRejectedPorts(ports []*flowLogRejectedPort) []interface{} {
+	result := make([]interface{}, 0, len(ports))
+	for _, port := range ports {
+		result = append(result, map[string]interface{}{
+			"protocol":         port.protocol,
+			"destination_port": port.dstPort,
+			"packets":          port.packets,
+			"flow_count":       port.flowCount,
+		})
+	}
+	return result
+}
+
+func dataSourceVpcFlowLogRecordsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var (
+		cfg    = meta.(*config.Config)
+		region = cfg.GetRegion(d)
+	)
+
+	groupId, streamId, err := getFlowLogStream(cfg, region, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := cfg.NewServiceClient("lts", region)
+	if err != nil {
+		return diag.Errorf("error creating LTS client: %s", err)
+	}
+	records, truncated, err := queryFlowLogRecords(client, d, groupId, streamId)
+	if err != nil {
+		return diag.Errorf("error querying flow log records from LTS log stream (%s): %s", streamId, err)
+	}
+
+	var totalBytes, totalPackets, acceptedCount, rejectedCount int
+	for _, record := range records {
+		totalBytes += record.bytes
+		totalPackets += record.packets
+		switch record.action {
+		case "ACCEPT":
+			acceptedCount++
+		case "REJECT":
+			rejectedCount++
+		}
+	}
+
+	randomUUID, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.Errorf("unable to generate ID: %s", err)
+	}
+	d.SetId(randomUUID)
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("records", flattenFlowLogRecords(records)),
+		d.Set("top_talkers", flattenFlowLogTopTalkers(aggregateFlowLogTopTalkers(records,
+			d.Get("top_talkers_limit").(int)))),
+		d.Set("rejected_ports", flattenFlowLogRejectedPorts(aggregateFlowLogRejectedPorts(records))),
+		d.Set("total_bytes", totalBytes),
+		d.Set("total_packets", totalPackets),
+		d.Set("accepted_count", acceptedCount),
+		d.Set("rejected_count", rejectedCount),
+		d.Set("truncated", truncated),
+	)
+	return diag.FromErr(mErr.ErrorOrNil())
+}
@@ -0,0 +1,164 @@
+package vpc
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// flowLogRecord is a record of the VPC flow log, the format of the record is:
+// <version> <project-id> <interface-id> <srcaddr> <dstaddr> <srcport> <dstport> <protocol> <packets> <bytes> <start>
+// <end> <action> <log-status>
+// The fields of the records whose log status is NODATA or SKIPDATA are "-".
+type flowLogRecord struct {
+	version     int
+	projectId   string
+	interfaceId string
+	srcAddr     string
+	dstAddr     string
+	srcPort     int
+	dstPort     int
+	protocol    int
+	packets     int
+	bytes       int
+	start       int64
+	end         int64
+	action      string
+	logStatus   string
+}
+
+const flowLogRecordFieldCount = 14
+
+func parseFlowLogRecord(content string) (*flowLogRecord, error) {
+	fields := strings.Fields(content)
+	if len(fields) != flowLogRecordFieldCount {
+		return nil, fmt.Errorf("expected %d fields, but got %d", flowLogRecordFieldCount, len(fields))
+	}
+
+	var (
+		numbers = make([]int64, 0, 8)
+		err     error
+	)
+	// The numeric fields are version, srcport, dstport, protocol, packets, bytes, start and end.
+	for _, index := range []int{0, 5, 6, 7, 8, 9, 10, 11} {
+		var number int64
+		if fields[index] != "-" {
+			if number, err = strconv.ParseInt(fields[index], 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid numeric field (%s): %s", fields[index], err)
+			}
+		}
+		numbers = append(numbers, number)
+	}
+
+	return &flowLogRecord{
+		version:     int(numbers[0]),
+		projectId:   fields[1],
+		interfaceId: fields[2],
+		srcAddr:     flowLogStringField(fields[3]),
+		dstAddr:     flowLogStringField(fields[4]),
+		srcPort:     int(numbers[1]),
+		dstPort:     int(numbers[2]),
+		protocol:    int(numbers[3]),
+		packets:     int(numbers[4]),
+		bytes:       int(numbers[5]),
+		start:       numbers[6],
+		end:         numbers[7],
+		action:      flowLogStringField(fields[12]),
+		logStatus:   fields[13],
+	}, nil
+}
+
+func flowLogStringField(value string) string {
+	if value == "-" {
+		return ""
+	}
+	return value
+}
+
+// flowLogTalker is the traffic between a source address and a destination address.
+type flowLogTalker struct {
+	srcAddr   string
+	dstAddr   string
+	bytes     int
+	packets   int
+	flowCount int
+}
+
+// aggregateFlowLogTopTalkers returns the address pairs with the most bytes.
+func aggregateFlowLogTopTalkers(records []*flowLogRecord, limit int) []*flowLogTalker {
+	talkerMap := make(map[string]*flowLogTalker)
+	for _, record := range records {
+		if record.srcAddr == "" || record.dstAddr == "" {
+			continue
+		}
+		key := record.srcAddr + "|" + record.dstAddr
+		talker, ok := talkerMap[key]
+		if !ok {
+			talker = &flowLogTalker{srcAddr: record.srcAddr, dstAddr: record.dstAddr}
+			talkerMap[key] = talker
+		}
+		talker.bytes += record.bytes
+		talker.packets += record.packets
+		talker.flowCount++
+	}
+
+	talkers := make([]*flowLogTalker, 0, len(talkerMap))
+	for _, talker := range talkerMap {
+		talkers = append(talkers, talker)
+	}
+	sort.Slice(talkers, func(i, j int) bool {
+		if talkers[i].bytes != talkers[j].bytes {
+			return talkers[i].bytes > talkers[j].bytes
+		}
+		if talkers[i].srcAddr != talkers[j].srcAddr {
+			return talkers[i].srcAddr < talkers[j].srcAddr
+		}
+		return talkers[i].dstAddr < talkers[j].dstAddr
+	})
+	if limit > 0 && len(talkers) > limit {
+		talkers = talkers[:limit]
+	}
+	return talkers
+}
+
+// flowLogRejectedPort is the rejected traffic to a destination port.
+type flowLogRejectedPort struct {
+	protocol  int
+	dstPort   int
+	packets   int
+	flowCount int
+}
+
+// aggregateFlowLogRejectedPorts returns the destination ports of the rejected flows, sorted by the number of flows.
+func aggregateFlowLogRejectedPorts(records []*flowLogRecord) []*flowLogRejectedPort {
+	portMap := make(map[string]*flowLogRejectedPort)
+	for _, record := range records {
+		if record.action != "REJECT" {
+			continue
+		}
+		key := fmt.Sprintf("%d|%d", record.protocol, record.dstPort)
+		port, ok := portMap[key]
+		if !ok {
+			port = &flowLogRejectedPort{protocol: record.protocol, dstPort: record.dstPort}
+			portMap[key] = port
+		}
+		port.packets += record.packets
+		port.flowCount++
+	}
+
+	ports := make([]*flowLogRejectedPort, 0, len(portMap))
+	for _, port := range portMap {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].flowCount != ports[j].flowCount {
+			return ports[i].flowCount > ports[j].flowCount
+		}
+		if ports[i].protocol != ports[j].protocol {
+			return ports[i].protocol < ports[j].protocol
+		}
+		return ports[i].dstPort < ports[j].dstPort
+	})
+	return ports
+}
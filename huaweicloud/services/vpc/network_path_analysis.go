@@ -0,0 +1,908 @@
+package vpc
+
+// This set of code evaluates the reachability between two endpoints for the huaweicloud_network_path_analysis data
+// source.
+//
+// The configurations along the path are fetched through the existing clients and evaluated offline, hop by hop:
+// 1. the egress rules of the security groups bound to the source port;
+// 2. the outbound rules of the network ACL associated with the source subnet;
+// 3. the route table associated with the source subnet, which leads to the local VPC, a VPC peering connection or an
+//    enterprise router;
+// 4. the effective routes of the enterprise router route table associated with the source VPC attachment;
+// 5. the inbound rules of the network ACL associated with the destination subnet;
+// 6. the ingress rules of the security groups bound to the destination port;
+// 7. the return route from the destination subnet to the source;
+// 8. the outbound rules of the destination subnet ACL and the inbound rules of the source subnet ACL for the response.
+// The security groups are stateful, so the response traffic is only evaluated against the network ACLs, with the
+// source and destination (addresses and ports) swapped.
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/routetables"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/subnets"
+	"github.com/chnsz/golangsdk/openstack/networking/v2/peerings"
+	"github.com/chnsz/golangsdk/openstack/networking/v2/ports"
+	v3rules "github.com/chnsz/golangsdk/openstack/networking/v3/security/rules"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const (
+	pathDecisionAllow   = "ALLOW"
+	pathDecisionDeny    = "DENY"
+	pathDecisionUnknown = "UNKNOWN"
+
+	pathHopSecurityGroupEgress  = "SECURITY_GROUP_EGRESS"
+	pathHopNetworkAclOutbound   = "NETWORK_ACL_OUTBOUND"
+	pathHopVpcRoute             = "VPC_ROUTE"
+	pathHopVpcPeering           = "VPC_PEERING"
+	pathHopErRoute              = "ER_ROUTE"
+	pathHopNetworkAclInbound    = "NETWORK_ACL_INBOUND"
+	pathHopSecurityGroupIngress = "SECURITY_GROUP_INGRESS"
+	pathHopVpcReturnRoute       = "VPC_RETURN_ROUTE"
+	pathHopNetworkAclReturnOut  = "NETWORK_ACL_RETURN_OUTBOUND"
+	pathHopNetworkAclReturnIn   = "NETWORK_ACL_RETURN_INBOUND"
+)
+
+// pathEndpoint is the source or the destination of the path.
+type pathEndpoint struct {
+	ip             net.IP
+	portId         string
+	securityGroups []string
+	// The ID of the subnet (the network ID of the port).
+	subnetId string
+	vpcId    string
+	// Whether the endpoint is specified only by the IP address, which may exist in more than one VPC.
+	ipOnly bool
+}
+
+type pathHop struct {
+	hopType     string
+	resourceId  string
+	decision    string
+	matchedRule string
+	description string
+}
+
+type pathAnalyzer struct {
+	cfg      *config.Config
+	region   string
+	protocol string
+	// The source port is 0 if it is not specified.
+	sourcePort      int
+	destinationPort int
+
+	v1Client    *golangsdk.ServiceClient
+	v2Client    *golangsdk.ServiceClient
+	v3Client    *golangsdk.ServiceClient
+	vpcV3Client *golangsdk.ServiceClient
+	erClient    *golangsdk.ServiceClient
+
+	addressGroups map[string][]string
+	hops          []pathHop
+}
+
+func newPathAnalyzer(cfg *config.Config, region, protocol string, sourcePort,
+	destinationPort int) (*pathAnalyzer, error) {
+	v1Client, err := cfg.NetworkingV1Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating VPC v1 client: %s", err)
+	}
+	v2Client, err := cfg.NetworkingV2Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating VPC v2 client: %s", err)
+	}
+	v3Client, err := cfg.NetworkingV3Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating networking v3 client: %s", err)
+	}
+	vpcV3Client, err := cfg.NewServiceClient("vpcv3", region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating VPC v3 client: %s", err)
+	}
+	erClient, err := cfg.NewServiceClient("er", region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ER client: %s", err)
+	}
+
+	return &pathAnalyzer{
+		cfg:             cfg,
+		region:          region,
+		protocol:        strings.ToLower(protocol),
+		sourcePort:      sourcePort,
+		destinationPort: destinationPort,
+		v1Client:        v1Client,
+		v2Client:        v2Client,
+		v3Client:        v3Client,
+		vpcV3Client:     vpcV3Client,
+		erClient:        erClient,
+		addressGroups:   make(map[string][]string),
+	}, nil
+}
+
+func (a *pathAnalyzer) addHop(hopType, resourceId, decision, matchedRule, description string) {
+	log.Printf("[DEBUG] network path hop %s (%s): %s, %s", hopType, resourceId, decision, description)
+	a.hops = append(a.hops, pathHop{
+		hopType:     hopType,
+		resourceId:  resourceId,
+		decision:    decision,
+		matchedRule: matchedRule,
+		description: description,
+	})
+}
+
+// result returns DENY if any hop denies the traffic, UNKNOWN if any hop cannot be evaluated, otherwise ALLOW.
+func (a *pathAnalyzer) result() string {
+	result := pathDecisionAllow
+	for _, hop := range a.hops {
+		switch hop.decision {
+		case pathDecisionDeny:
+			return pathDecisionDeny
+		case pathDecisionUnknown:
+			result = pathDecisionUnknown
+		}
+	}
+	return result
+}
+
+// resolveEndpoint finds the port, subnet and VPC of the endpoint. The endpoint without port is considered as an
+// address outside of the VPCs if no subnet of the VPC contains the address.
+func (a *pathAnalyzer) resolveEndpoint(portId, ipAddress, vpcId string) (*pathEndpoint, error) {
+	var port *ports.Port
+	if portId != "" {
+		p, err := ports.Get(a.v2Client, portId).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving port (%s): %s", portId, err)
+		}
+		port = p
+	} else {
+		p, err := a.findPortByIp(ipAddress, vpcId)
+		if err != nil {
+			return nil, err
+		}
+		port = p
+	}
+
+	endpoint := &pathEndpoint{
+		ipOnly: portId == "" && vpcId == "",
+	}
+	if port != nil {
+		if ipAddress == "" && len(port.FixedIPs) > 0 {
+			ipAddress = port.FixedIPs[0].IPAddress
+		}
+		endpoint.portId = port.ID
+		endpoint.securityGroups = port.SecurityGroups
+		endpoint.subnetId = port.NetworkID
+	}
+	endpoint.ip = net.ParseIP(ipAddress)
+	if endpoint.ip == nil {
+		return nil, fmt.Errorf("invalid IP address: %q", ipAddress)
+	}
+
+	if endpoint.subnetId != "" {
+		subnet, err := subnets.Get(a.v1Client, endpoint.subnetId).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving subnet (%s): %s", endpoint.subnetId, err)
+		}
+		endpoint.vpcId = subnet.VPC_ID
+		return endpoint, nil
+	}
+
+	if vpcId != "" {
+		allSubnets, err := subnets.List(a.v1Client, subnets.ListOpts{VPC_ID: vpcId})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving subnets of VPC (%s): %s", vpcId, err)
+		}
+		for _, subnet := range allSubnets {
+			if isIpInCidr(endpoint.ip, subnet.CIDR) || isIpInCidr(endpoint.ip, subnet.IPv6CIDR) {
+				endpoint.subnetId = subnet.ID
+				endpoint.vpcId = vpcId
+				break
+			}
+		}
+	}
+	return endpoint, nil
+}
+
+// findPortByIp returns the port whose fixed IP is the address, only the ports in the VPC are considered if the VPC is
+// specified. Nil is returned if no port is found.
+func (a *pathAnalyzer) findPortByIp(ipAddress, vpcId string) (*ports.Port, error) {
+	listPath := a.v2Client.ServiceURL("ports") + "?fixed_ips=ip_address=" + ipAddress
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	resp, err := a.v2Client.Request("GET", listPath, &listOpt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving the port of IP address (%s): %s", ipAddress, err)
+	}
+	respBody, err := utils.FlattenResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range utils.PathSearch("ports", respBody, make([]interface{}, 0)).([]interface{}) {
+		networkId := utils.PathSearch("network_id", v, "").(string)
+		if vpcId != "" {
+			subnet, err := subnets.Get(a.v1Client, networkId).Extract()
+			if err != nil || subnet.VPC_ID != vpcId {
+				continue
+			}
+		}
+		return &ports.Port{
+			ID:        utils.PathSearch("id", v, "").(string),
+			NetworkID: networkId,
+			SecurityGroups: utils.ExpandToStringList(
+				utils.PathSearch("security_groups", v, make([]interface{}, 0)).([]interface{})),
+		}, nil
+	}
+	return nil, nil
+}
+
+func isIpInCidr(ip net.IP, cidr string) bool {
+	if cidr == "" {
+		return false
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	return ipNet.Contains(ip)
+}
+
+// isIpInAddress checks whether the IP is in the address, which may be an IP, a CIDR or an IP range.
+func isIpInAddress(ip net.IP, address string) bool {
+	address = strings.TrimSpace(address)
+	switch {
+	case address == "":
+		return false
+	case strings.Contains(address, "/"):
+		return isIpInCidr(ip, address)
+	case strings.Contains(address, "-"):
+		parts := strings.SplitN(address, "-", 2)
+		first, last := net.ParseIP(strings.TrimSpace(parts[0])), net.ParseIP(strings.TrimSpace(parts[1]))
+		if first == nil || last == nil {
+			return false
+		}
+		target := ip.To16()
+		return bytes.Compare(target, first.To16()) >= 0 && bytes.Compare(target, last.To16()) <= 0
+	default:
+		other := net.ParseIP(address)
+		return other != nil && other.Equal(ip)
+	}
+}
+
+// isPortInRanges checks whether the port is in the port ranges, e.g. "22,80-90". Empty ranges mean all ports.
+func isPortInRanges(port int, ranges string) bool {
+	ranges = strings.TrimSpace(ranges)
+	if ranges == "" {
+		return true
+	}
+	for _, r := range strings.Split(ranges, ",") {
+		parts := strings.SplitN(strings.TrimSpace(r), "-", 2)
+		first, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil {
+			continue
+		}
+		last := first
+		if len(parts) == 2 {
+			if last, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil {
+				continue
+			}
+		}
+		if port >= first && port <= last {
+			return true
+		}
+	}
+	return false
+}
+
+// isProtocolMatched checks whether the rule protocol covers the protocol of the traffic. The ICMP traffic between IPv6
+// addresses is ICMPv6, which is only covered by the icmpv6 rules.
+func (a *pathAnalyzer) isProtocolMatched(ruleProtocol string, isIpv6 bool) bool {
+	protocol := a.protocol
+	if protocol == "icmp" && isIpv6 {
+		protocol = "icmpv6"
+	}
+	ruleProtocol = strings.ToLower(ruleProtocol)
+	return ruleProtocol == "" || ruleProtocol == "any" || ruleProtocol == protocol
+}
+
+// isTransportProtocol returns whether the ports of the traffic should be evaluated.
+func (a *pathAnalyzer) isTransportProtocol() bool {
+	return a.protocol == "tcp" || a.protocol == "udp"
+}
+
+func (a *pathAnalyzer) getAddressGroupAddresses(groupId string) ([]string, error) {
+	if addresses, ok := a.addressGroups[groupId]; ok {
+		return addresses, nil
+	}
+
+	getPath := a.vpcV3Client.Endpoint + "v3/{project_id}/vpc/address-groups/{address_group_id}"
+	getPath = strings.ReplaceAll(getPath, "{project_id}", a.vpcV3Client.ProjectID)
+	getPath = strings.ReplaceAll(getPath, "{address_group_id}", groupId)
+	getOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	resp, err := a.vpcV3Client.Request("GET", getPath, &getOpt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving address group (%s): %s", groupId, err)
+	}
+	respBody, err := utils.FlattenResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := utils.ExpandToStringList(
+		utils.PathSearch("address_group.ip_set", respBody, make([]interface{}, 0)).([]interface{}))
+	a.addressGroups[groupId] = addresses
+	return addresses, nil
+}
+
+// isIpInAddressGroup checks whether the IP is in the address group. Any error is treated as not matched.
+func (a *pathAnalyzer) isIpInAddressGroup(ip net.IP, groupId string) bool {
+	addresses, err := a.getAddressGroupAddresses(groupId)
+	if err != nil {
+		log.Printf("[WARN] %s", err)
+		return false
+	}
+	for _, address := range addresses {
+		if isIpInAddress(ip, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateSecurityGroups evaluates the rules of the security groups bound to the local port against the remote
+// endpoint. The rules in each group are matched by priority and the deny rules take precedence over the allow rules
+// with the same priority. All groups are evaluated, the traffic is allowed if any group allows it, and denied if no
+// group allows it.
+func (a *pathAnalyzer) evaluateSecurityGroups(hopType, direction string, local, remote *pathEndpoint) error {
+	if local.portId == "" {
+		return nil
+	}
+	if len(local.securityGroups) == 0 {
+		a.addHop(hopType, local.portId, pathDecisionAllow, "", "no security group is bound to the port")
+		return nil
+	}
+
+	ethertype := "IPv4"
+	if remote.ip.To4() == nil {
+		ethertype = "IPv6"
+	}
+	var denied *pathHop
+	for _, groupId := range local.securityGroups {
+		rules, err := v3rules.List(a.v3Client, v3rules.ListOpts{SecurityGroupId: groupId, Direction: direction})
+		if err != nil {
+			return fmt.Errorf("error retrieving rules of security group (%s): %s", groupId, err)
+		}
+		sort.SliceStable(rules, func(i, j int) bool {
+			if rules[i].Priority != rules[j].Priority {
+				return rules[i].Priority < rules[j].Priority
+			}
+			return rules[i].Action == "deny" && rules[j].Action != "deny"
+		})
+
+		for _, rule := range rules {
+			if !a.isSecurityGroupRuleMatched(rule, ethertype, remote) {
+				continue
+			}
+			description := fmt.Sprintf("matched the %s rule of security group (%s) with priority %d", rule.Action,
+				groupId, rule.Priority)
+			if rule.Action != "deny" {
+				a.addHop(hopType, groupId, pathDecisionAllow, rule.ID, description)
+				return nil
+			}
+			if denied == nil {
+				denied = &pathHop{resourceId: groupId, matchedRule: rule.ID, description: description}
+			}
+			break
+		}
+	}
+
+	if denied != nil {
+		a.addHop(hopType, denied.resourceId, pathDecisionDeny, denied.matchedRule, denied.description)
+		return nil
+	}
+	a.addHop(hopType, strings.Join(local.securityGroups, ","), pathDecisionDeny, "",
+		fmt.Sprintf("no %s rule of the security groups matches the traffic", direction))
+	return nil
+}
+
+func (a *pathAnalyzer) isSecurityGroupRuleMatched(rule v3rules.SecurityGroupRule, ethertype string,
+	remote *pathEndpoint) bool {
+	if rule.Ethertype != "" && !strings.EqualFold(rule.Ethertype, ethertype) {
+		return false
+	}
+	if !a.isProtocolMatched(rule.Protocol, ethertype == "IPv6") {
+		return false
+	}
+	if a.isTransportProtocol() && !isPortInRanges(a.destinationPort, rule.MultiPort) {
+		return false
+	}
+
+	switch {
+	case rule.RemoteGroupId != "":
+		return utils.StrSliceContains(remote.securityGroups, rule.RemoteGroupId)
+	case rule.RemoteAddressGroupId != "":
+		return a.isIpInAddressGroup(remote.ip, rule.RemoteAddressGroupId)
+	case rule.RemoteIpPrefix != "":
+		return isIpInCidr(remote.ip, rule.RemoteIpPrefix)
+	}
+	return true
+}
+
+// getSubnetNetworkAcl returns the network ACL associated with the subnet, nil is returned if there is no one.
+func (a *pathAnalyzer) getSubnetNetworkAcl(subnetId string) (interface{}, error) {
+	listPath := a.vpcV3Client.Endpoint + "v3/{project_id}/vpc/firewalls"
+	listPath = strings.ReplaceAll(listPath, "{project_id}", a.vpcV3Client.ProjectID)
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+
+	marker := ""
+	for {
+		queryPath := listPath + "?limit=2000&enterprise_project_id=all_granted_eps"
+		if marker != "" {
+			queryPath = fmt.Sprintf("%s&marker=%s", queryPath, marker)
+		}
+		resp, err := a.vpcV3Client.Request("GET", queryPath, &listOpt)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving network ACLs: %s", err)
+		}
+		respBody, err := utils.FlattenResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		expression := fmt.Sprintf("firewalls[?associations[?virsubnet_id=='%s']]|[0].id", subnetId)
+		if aclId := utils.PathSearch(expression, respBody, "").(string); aclId != "" {
+			getPath := fmt.Sprintf("%s/%s", listPath, aclId)
+			getResp, err := a.vpcV3Client.Request("GET", getPath, &listOpt)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving network ACL (%s): %s", aclId, err)
+			}
+			return utils.FlattenResponse(getResp)
+		}
+
+		marker = utils.PathSearch("page_info.next_marker", respBody, "").(string)
+		if marker == "" {
+			return nil, nil
+		}
+	}
+}
+
+// evaluateNetworkAcl evaluates the rules of the network ACL associated with the local subnet against the traffic from
+// the source to the destination, the ports are the ones of the traffic and zero means unknown. The rules are matched
+// in order and the traffic is denied if no rule matches. The rule which depends on an unknown port is skipped, and the
+// decision is UNKNOWN if the skipped rule may change it.
+func (a *pathAnalyzer) evaluateNetworkAcl(hopType, rulesKey string, local, source, destination *pathEndpoint,
+	sourcePort, destinationPort int) error {
+	if local.subnetId == "" {
+		return nil
+	}
+	acl, err := a.getSubnetNetworkAcl(local.subnetId)
+	if err != nil {
+		return err
+	}
+	if acl == nil {
+		a.addHop(hopType, local.subnetId, pathDecisionAllow, "", "no network ACL is associated with the subnet")
+		return nil
+	}
+
+	aclId := utils.PathSearch("firewall.id", acl, "").(string)
+	if !utils.PathSearch("firewall.admin_state_up", acl, true).(bool) {
+		a.addHop(hopType, aclId, pathDecisionAllow, "", "the network ACL is disabled")
+		return nil
+	}
+
+	ipVersion := float64(4)
+	if destination.ip.To4() == nil {
+		ipVersion = 6
+	}
+	uncertainActions := make(map[string]bool)
+	for _, rule := range utils.PathSearch("firewall."+rulesKey, acl, make([]interface{}, 0)).([]interface{}) {
+		if !utils.PathSearch("enabled", rule, true).(bool) {
+			continue
+		}
+		matched, uncertain := a.isNetworkAclRuleMatched(rule, ipVersion, source, destination, sourcePort,
+			destinationPort)
+		action := utils.PathSearch("action", rule, "").(string)
+		if uncertain {
+			uncertainActions[action] = true
+			continue
+		}
+		if !matched {
+			continue
+		}
+		decision := pathDecisionAllow
+		if action == "deny" {
+			decision = pathDecisionDeny
+		}
+		description := fmt.Sprintf("matched the %s rule (%s) of network ACL (%s)", action,
+			utils.PathSearch("name", rule, "").(string), aclId)
+		for uncertainAction := range uncertainActions {
+			if uncertainAction != action {
+				decision = pathDecisionUnknown
+				description += ", but a previous rule depends on the unknown port of the traffic"
+				break
+			}
+		}
+		a.addHop(hopType, aclId, decision, utils.PathSearch("id", rule, "").(string), description)
+		return nil
+	}
+
+	if uncertainActions["allow"] {
+		a.addHop(hopType, aclId, pathDecisionUnknown, "",
+			"the allow rules of the network ACL depend on the unknown port of the traffic")
+		return nil
+	}
+	a.addHop(hopType, aclId, pathDecisionDeny, "", "no rule of the network ACL matches the traffic")
+	return nil
+}
+
+// isNetworkAclPortMatched checks whether the port is in the port ranges of the rule, the result is uncertain if the
+// rule limits the ports but the port is unknown.
+func isNetworkAclPortMatched(port int, ranges string) (matched, uncertain bool) {
+	if strings.TrimSpace(ranges) == "" {
+		return true, false
+	}
+	if port == 0 {
+		return false, true
+	}
+	return isPortInRanges(port, ranges), false
+}
+
+func (a *pathAnalyzer) isNetworkAclRuleMatched(rule interface{}, ipVersion float64, source, destination *pathEndpoint,
+	sourcePort, destinationPort int) (matched, uncertain bool) {
+	if v := utils.PathSearch("ip_version", rule, ipVersion).(float64); v != ipVersion {
+		return false, false
+	}
+	if !a.isProtocolMatched(utils.PathSearch("protocol", rule, "").(string), ipVersion == 6) {
+		return false, false
+	}
+	if !a.isNetworkAclAddressMatched(rule, "source", source.ip) ||
+		!a.isNetworkAclAddressMatched(rule, "destination", destination.ip) {
+		return false, false
+	}
+	if !a.isTransportProtocol() {
+		return true, false
+	}
+
+	destinationMatched, destinationUncertain := isNetworkAclPortMatched(destinationPort,
+		utils.PathSearch("destination_port", rule, "").(string))
+	sourceMatched, sourceUncertain := isNetworkAclPortMatched(sourcePort,
+		utils.PathSearch("source_port", rule, "").(string))
+	if (!destinationMatched && !destinationUncertain) || (!sourceMatched && !sourceUncertain) {
+		return false, false
+	}
+	return destinationMatched && sourceMatched, destinationUncertain || sourceUncertain
+}
+
+func (a *pathAnalyzer) isNetworkAclAddressMatched(rule interface{}, prefix string, ip net.IP) bool {
+	if groupId := utils.PathSearch(prefix+"_address_group_id", rule, "").(string); groupId != "" {
+		return a.isIpInAddressGroup(ip, groupId)
+	}
+	address := utils.PathSearch(prefix+"_ip_address", rule, "").(string)
+	return address == "" || isIpInAddress(ip, address)
+}
+
+// listVpcCidrsForPath returns the primary CIDR, the secondary CIDRs and the IPv6 CIDRs of the subnets in the VPC.
+func (a *pathAnalyzer) listVpcCidrsForPath(vpcId string) ([]string, error) {
+	cidrs, err := listVpcCidrs(a.cfg, a.region, vpcId)
+	if err != nil {
+		return nil, err
+	}
+	allSubnets, err := subnets.List(a.v1Client, subnets.ListOpts{VPC_ID: vpcId})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving subnets of VPC (%s): %s", vpcId, err)
+	}
+	for _, subnet := range allSubnets {
+		if subnet.IPv6CIDR != "" {
+			cidrs = append(cidrs, subnet.IPv6CIDR)
+		}
+	}
+	return cidrs, nil
+}
+
+// lookupVpcRoute returns the route which the traffic from the subnet to the IP follows. The local route has the
+// highest priority, otherwise the route with the longest prefix in the route table associated with the subnet is
+// returned. Nil is returned if no route matches.
+func (a *pathAnalyzer) lookupVpcRoute(vpcId, subnetId string, ip net.IP) (*routetables.Route, string, error) {
+	vpcCidrs, err := a.listVpcCidrsForPath(vpcId)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, cidr := range vpcCidrs {
+		if isIpInCidr(ip, cidr) {
+			return &routetables.Route{Type: "local", DestinationCIDR: cidr, NextHop: vpcId}, "", nil
+		}
+	}
+
+	pages, err := routetables.List(a.v1Client, routetables.ListOpts{VpcID: vpcId, SubnetID: subnetId}).AllPages()
+	if err != nil {
+		return nil, "", fmt.Errorf("error retrieving route tables of VPC (%s): %s", vpcId, err)
+	}
+	tables, err := routetables.ExtractRouteTables(pages)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(tables) == 0 {
+		return nil, "", fmt.Errorf("unable to find the route table associated with subnet (%s)", subnetId)
+	}
+	routeTable, err := routetables.Get(a.v1Client, tables[0].ID).Extract()
+	if err != nil {
+		return nil, "", fmt.Errorf("error retrieving route table (%s): %s", tables[0].ID, err)
+	}
+
+	var matched *routetables.Route
+	matchedLength := -1
+	for i, route := range routeTable.Routes {
+		_, ipNet, err := net.ParseCIDR(route.DestinationCIDR)
+		if err != nil || !ipNet.Contains(ip) {
+			continue
+		}
+		if ones, _ := ipNet.Mask.Size(); ones > matchedLength {
+			matched = &routeTable.Routes[i]
+			matchedLength = ones
+		}
+	}
+	return matched, routeTable.ID, nil
+}
+
+// evaluateVpcRoute evaluates the route from the source subnet and returns the VPC where the traffic arrives, an empty
+// string is returned if the traffic leaves the VPCs or is dropped.
+func (a *pathAnalyzer) evaluateVpcRoute(source, destination *pathEndpoint) (string, error) {
+	route, routeTableId, err := a.lookupVpcRoute(source.vpcId, source.subnetId, destination.ip)
+	if err != nil {
+		return "", err
+	}
+	if route == nil {
+		a.addHop(pathHopVpcRoute, routeTableId, pathDecisionDeny, "",
+			fmt.Sprintf("no route to %s in the route table", destination.ip))
+		return "", nil
+	}
+
+	matchedRule := fmt.Sprintf("%s -> %s (%s)", route.DestinationCIDR, route.NextHop, route.Type)
+	switch route.Type {
+	case "local":
+		a.addHop(pathHopVpcRoute, source.vpcId, pathDecisionAllow, matchedRule, "the destination is in the local VPC")
+		return source.vpcId, nil
+	case "peering":
+		a.addHop(pathHopVpcRoute, routeTableId, pathDecisionAllow, matchedRule,
+			"the traffic is forwarded to the VPC peering connection")
+		return a.evaluateVpcPeering(route.NextHop, source.vpcId)
+	case "er":
+		a.addHop(pathHopVpcRoute, routeTableId, pathDecisionAllow, matchedRule,
+			"the traffic is forwarded to the enterprise router")
+		return a.evaluateErRoute(route.NextHop, source.vpcId, destination.ip)
+	}
+	a.addHop(pathHopVpcRoute, routeTableId, pathDecisionUnknown, matchedRule,
+		fmt.Sprintf("the next hop type (%s) is not supported by the analysis", route.Type))
+	return "", nil
+}
+
+func (a *pathAnalyzer) evaluateVpcPeering(peeringId, sourceVpcId string) (string, error) {
+	peering, err := peerings.Get(a.v2Client, peeringId).Extract()
+	if err != nil {
+		return "", fmt.Errorf("error retrieving VPC peering connection (%s): %s", peeringId, err)
+	}
+	if peering.Status != "ACTIVE" {
+		a.addHop(pathHopVpcPeering, peeringId, pathDecisionDeny, "",
+			fmt.Sprintf("the status of the VPC peering connection is %s", peering.Status))
+		return "", nil
+	}
+
+	peerVpcId := peering.AcceptVpcInfo.VpcId
+	if peerVpcId == sourceVpcId {
+		peerVpcId = peering.RequestVpcInfo.VpcId
+	}
+	a.addHop(pathHopVpcPeering, peeringId, pathDecisionAllow, "",
+		fmt.Sprintf("the traffic is forwarded to VPC (%s)", peerVpcId))
+	return peerVpcId, nil
+}
+
+// listErEffectiveRoutes queries all effective routes of the enterprise router route table.
+func (a *pathAnalyzer) listErEffectiveRoutes(routeTableId string) ([]interface{}, error) {
+	listPath := a.erClient.Endpoint + "v3/{project_id}/enterprise-router/route-tables/{route_table_id}/routes"
+	listPath = strings.ReplaceAll(listPath, "{project_id}", a.erClient.ProjectID)
+	listPath = strings.ReplaceAll(listPath, "{route_table_id}", routeTableId)
+	listPath += "?limit=2000"
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+
+	var (
+		marker = ""
+		result = make([]interface{}, 0)
+	)
+	for {
+		requestPath := listPath
+		if marker != "" {
+			requestPath += fmt.Sprintf("&marker=%s", marker)
+		}
+		resp, err := a.erClient.Request("GET", requestPath, &listOpt)
+		if err != nil {
+			return nil, err
+		}
+		respBody, err := utils.FlattenResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, utils.PathSearch("routes", respBody, make([]interface{}, 0)).([]interface{})...)
+		nextMarker := utils.PathSearch("page_info.next_marker", respBody, "").(string)
+		if nextMarker == "" || nextMarker == marker {
+			break
+		}
+		marker = nextMarker
+	}
+	return result, nil
+}
+
+// evaluateErRoute evaluates the effective routes of the route table associated with the VPC attachment.
+func (a *pathAnalyzer) evaluateErRoute(erId, sourceVpcId string, ip net.IP) (string, error) {
+	attachmentsPath := a.erClient.Endpoint + "v3/{project_id}/enterprise-router/{er_id}/attachments"
+	attachmentsPath = strings.ReplaceAll(attachmentsPath, "{project_id}", a.erClient.ProjectID)
+	attachmentsPath = strings.ReplaceAll(attachmentsPath, "{er_id}", erId)
+	attachmentsPath += fmt.Sprintf("?resource_type=vpc&resource_id=%s", sourceVpcId)
+	opt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	resp, err := a.erClient.Request("GET", attachmentsPath, &opt)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving attachments of enterprise router (%s): %s", erId, err)
+	}
+	respBody, err := utils.FlattenResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	attachmentId := utils.PathSearch("attachments|[0].id", respBody, "").(string)
+	routeTableId := utils.PathSearch("attachments|[0].route_table_id", respBody, "").(string)
+	if attachmentId == "" {
+		a.addHop(pathHopErRoute, erId, pathDecisionDeny, "",
+			fmt.Sprintf("VPC (%s) is not attached to the enterprise router", sourceVpcId))
+		return "", nil
+	}
+	if routeTableId == "" {
+		a.addHop(pathHopErRoute, attachmentId, pathDecisionDeny, "",
+			"the attachment is not associated with any route table")
+		return "", nil
+	}
+
+	routes, err := a.listErEffectiveRoutes(routeTableId)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving effective routes of route table (%s): %s", routeTableId, err)
+	}
+
+	var matched interface{}
+	matchedLength := -1
+	for _, route := range routes {
+		_, ipNet, err := net.ParseCIDR(utils.PathSearch("destination", route, "").(string))
+		if err != nil || !ipNet.Contains(ip) {
+			continue
+		}
+		if ones, _ := ipNet.Mask.Size(); ones > matchedLength {
+			matched = route
+			matchedLength = ones
+		}
+	}
+	if matched == nil {
+		a.addHop(pathHopErRoute, routeTableId, pathDecisionDeny, "",
+			fmt.Sprintf("no effective route to %s in the route table", ip))
+		return "", nil
+	}
+
+	routeId := utils.PathSearch("route_id", matched, "").(string)
+	if utils.PathSearch("is_blackhole", matched, false).(bool) {
+		a.addHop(pathHopErRoute, routeTableId, pathDecisionDeny, routeId, "the matched route is a blackhole route")
+		return "", nil
+	}
+	resourceType := utils.PathSearch("next_hops|[0].resource_type", matched, "").(string)
+	resourceId := utils.PathSearch("next_hops|[0].resource_id", matched, "").(string)
+	if resourceType != "vpc" {
+		a.addHop(pathHopErRoute, routeTableId, pathDecisionUnknown, routeId,
+			fmt.Sprintf("the next hop type (%s) is not supported by the analysis", resourceType))
+		return "", nil
+	}
+	a.addHop(pathHopErRoute, routeTableId, pathDecisionAllow, routeId,
+		fmt.Sprintf("the traffic is forwarded to VPC (%s)", resourceId))
+	return resourceId, nil
+}
+
+// analyze evaluates all hops from the source to the destination.
+func (a *pathAnalyzer) analyze(source, destination *pathEndpoint) error {
+	if source.vpcId == "" {
+		return fmt.Errorf("unable to find the subnet of the source address (%s)", source.ip)
+	}
+
+	// The route is evaluated first to find the VPC where the traffic arrives, and its hops are appended after the
+	// egress hops of the source.
+	arrivedVpcId, err := a.evaluateVpcRoute(source, destination)
+	if err != nil {
+		return err
+	}
+	routeHops := a.hops
+	a.hops = nil
+
+	// The private address ranges of the VPCs may overlap, so the destination specified only by the IP address is
+	// resolved again in the VPC where the traffic arrives.
+	if arrivedVpcId != "" && destination.vpcId != arrivedVpcId && (destination.vpcId == "" || destination.ipOnly) {
+		resolved, err := a.resolveEndpoint("", destination.ip.String(), arrivedVpcId)
+		if err != nil {
+			return err
+		}
+		*destination = *resolved
+	}
+
+	if err := a.evaluateSecurityGroups(pathHopSecurityGroupEgress, "egress", source, destination); err != nil {
+		return err
+	}
+	if err := a.evaluateNetworkAcl(pathHopNetworkAclOutbound, "egress_rules", source, source, destination,
+		a.sourcePort, a.destinationPort); err != nil {
+		return err
+	}
+	a.hops = append(a.hops, routeHops...)
+
+	if arrivedVpcId == "" {
+		return nil
+	}
+	if destination.vpcId != arrivedVpcId {
+		a.addHop(pathHopVpcRoute, arrivedVpcId, pathDecisionDeny, "",
+			fmt.Sprintf("the traffic arrives at VPC (%s), but the destination is not in the VPC", arrivedVpcId))
+		return nil
+	}
+
+	if err := a.evaluateNetworkAcl(pathHopNetworkAclInbound, "ingress_rules", destination, source, destination,
+		a.sourcePort, a.destinationPort); err != nil {
+		return err
+	}
+	if err := a.evaluateSecurityGroups(pathHopSecurityGroupIngress, "ingress", destination, source); err != nil {
+		return err
+	}
+	if err := a.evaluateReturnRoute(source, destination); err != nil {
+		return err
+	}
+
+	// The response traffic is from the destination to the source, so the addresses and ports are swapped.
+	if err := a.evaluateNetworkAcl(pathHopNetworkAclReturnOut, "egress_rules", destination, destination, source,
+		a.destinationPort, a.sourcePort); err != nil {
+		return err
+	}
+	return a.evaluateNetworkAcl(pathHopNetworkAclReturnIn, "ingress_rules", source, destination, source,
+		a.destinationPort, a.sourcePort)
+}
+
+// evaluateReturnRoute checks whether the destination subnet has a route back to the source.
+func (a *pathAnalyzer) evaluateReturnRoute(source, destination *pathEndpoint) error {
+	if destination.vpcId == source.vpcId {
+		return nil
+	}
+	route, routeTableId, err := a.lookupVpcRoute(destination.vpcId, destination.subnetId, source.ip)
+	if err != nil {
+		return err
+	}
+	if route == nil {
+		a.addHop(pathHopVpcReturnRoute, routeTableId, pathDecisionDeny, "",
+			fmt.Sprintf("no route back to %s in the route table of the destination subnet", source.ip))
+		return nil
+	}
+
+	matchedRule := fmt.Sprintf("%s -> %s (%s)", route.DestinationCIDR, route.NextHop, route.Type)
+	decision := pathDecisionAllow
+	if route.Type != "peering" && route.Type != "er" {
+		decision = pathDecisionUnknown
+	}
+	a.addHop(pathHopVpcReturnRoute, routeTableId, decision, matchedRule, "the route back to the source")
+	return nil
+}
@@ -0,0 +1,214 @@
+package vpc
+
+import (
+	"net"
+	"testing"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func TestNetworkPathAnalysisFunc_isPortInRanges(t *testing.T) {
+	testCases := []struct {
+		port     int
+		ranges   string
+		expected bool
+	}{
+		{port: 22, ranges: "", expected: true},
+		{port: 22, ranges: "22", expected: true},
+		{port: 23, ranges: "22", expected: false},
+		{port: 85, ranges: "22,80-90", expected: true},
+		{port: 80, ranges: "22, 80-90", expected: true},
+		{port: 90, ranges: "22,80-90", expected: true},
+		{port: 91, ranges: "22,80-90", expected: false},
+		{port: 443, ranges: "abc,443", expected: true},
+		{port: 443, ranges: "400-abc", expected: false},
+	}
+
+	for _, tc := range testCases {
+		if result := isPortInRanges(tc.port, tc.ranges); result != tc.expected {
+			t.Fatalf("The match result of the port (%d) and the ranges (%s) is not as expected, want %s, but got %s",
+				tc.port, tc.ranges, utils.Green(tc.expected), utils.Yellow(result))
+		}
+	}
+}
+
+func TestNetworkPathAnalysisFunc_isIpInAddress(t *testing.T) {
+	testCases := []struct {
+		ip       string
+		address  string
+		expected bool
+	}{
+		{ip: "192.168.0.10", address: "", expected: false},
+		{ip: "192.168.0.10", address: "192.168.0.10", expected: true},
+		{ip: "192.168.0.10", address: "192.168.0.11", expected: false},
+		{ip: "192.168.0.10", address: "192.168.0.0/24", expected: true},
+		{ip: "192.168.1.10", address: "192.168.0.0/24", expected: false},
+		{ip: "192.168.0.10", address: "192.168.0.1-192.168.0.20", expected: true},
+		{ip: "192.168.0.10", address: "192.168.0.1 - 192.168.0.10", expected: true},
+		{ip: "192.168.0.21", address: "192.168.0.1-192.168.0.20", expected: false},
+		{ip: "192.168.0.10", address: "192.168.0.1-invalid", expected: false},
+		{ip: "2001:db8::10", address: "2001:db8::/64", expected: true},
+		{ip: "2001:db8::10", address: "2001:db8::1-2001:db8::ff", expected: true},
+		{ip: "2001:db8:1::10", address: "2001:db8::/64", expected: false},
+	}
+
+	for _, tc := range testCases {
+		if result := isIpInAddress(net.ParseIP(tc.ip), tc.address); result != tc.expected {
+			t.Fatalf("The match result of the IP (%s) and the address (%s) is not as expected, want %s, but got %s",
+				tc.ip, tc.address, utils.Green(tc.expected), utils.Yellow(result))
+		}
+	}
+}
+
+func TestNetworkPathAnalysisFunc_isNetworkAclPortMatched(t *testing.T) {
+	testCases := []struct {
+		port              int
+		ranges            string
+		expectedMatched   bool
+		expectedUncertain bool
+	}{
+		{port: 0, ranges: "", expectedMatched: true, expectedUncertain: false},
+		{port: 0, ranges: "80", expectedMatched: false, expectedUncertain: true},
+		{port: 80, ranges: "80", expectedMatched: true, expectedUncertain: false},
+		{port: 81, ranges: "80", expectedMatched: false, expectedUncertain: false},
+	}
+
+	for _, tc := range testCases {
+		matched, uncertain := isNetworkAclPortMatched(tc.port, tc.ranges)
+		if matched != tc.expectedMatched || uncertain != tc.expectedUncertain {
+			t.Fatalf("The match result of the port (%d) and the ranges (%s) is not as expected, want %v/%v, "+
+				"but got %v/%v", tc.port, tc.ranges, tc.expectedMatched, tc.expectedUncertain, matched, uncertain)
+		}
+	}
+}
+
+func TestNetworkPathAnalysisFunc_isNetworkAclRuleMatched(t *testing.T) {
+	var (
+		source      = &pathEndpoint{ip: net.ParseIP("192.168.0.10")}
+		destination = &pathEndpoint{ip: net.ParseIP("172.16.0.20")}
+		v6Source    = &pathEndpoint{ip: net.ParseIP("2001:db8::10")}
+		v6Dest      = &pathEndpoint{ip: net.ParseIP("2001:db8:1::20")}
+	)
+
+	testCases := []struct {
+		name              string
+		protocol          string
+		rule              map[string]interface{}
+		source            *pathEndpoint
+		destination       *pathEndpoint
+		sourcePort        int
+		expectedMatched   bool
+		expectedUncertain bool
+	}{
+		{
+			name:            "rule without any limit",
+			protocol:        "tcp",
+			rule:            map[string]interface{}{"ip_version": float64(4), "protocol": "any"},
+			expectedMatched: true,
+		},
+		{
+			name:     "different IP version",
+			protocol: "tcp",
+			rule:     map[string]interface{}{"ip_version": float64(6), "protocol": "any"},
+		},
+		{
+			name:     "different protocol",
+			protocol: "tcp",
+			rule:     map[string]interface{}{"ip_version": float64(4), "protocol": "udp"},
+		},
+		{
+			name:     "source address not matched",
+			protocol: "tcp",
+			rule: map[string]interface{}{
+				"ip_version":        float64(4),
+				"protocol":          "tcp",
+				"source_ip_address": "10.0.0.0/8",
+			},
+		},
+		{
+			name:     "addresses and destination port matched",
+			protocol: "tcp",
+			rule: map[string]interface{}{
+				"ip_version":             float64(4),
+				"protocol":               "tcp",
+				"source_ip_address":      "192.168.0.0/16",
+				"destination_ip_address": "172.16.0.1-172.16.0.100",
+				"destination_port":       "22,80-90",
+			},
+			expectedMatched: true,
+		},
+		{
+			name:     "destination port not matched",
+			protocol: "tcp",
+			rule: map[string]interface{}{
+				"ip_version":       float64(4),
+				"protocol":         "tcp",
+				"destination_port": "443",
+			},
+		},
+		{
+			name:     "unknown source port",
+			protocol: "tcp",
+			rule: map[string]interface{}{
+				"ip_version":  float64(4),
+				"protocol":    "tcp",
+				"source_port": "1024-65535",
+			},
+			expectedUncertain: true,
+		},
+		{
+			name:     "known source port",
+			protocol: "tcp",
+			rule: map[string]interface{}{
+				"ip_version":  float64(4),
+				"protocol":    "tcp",
+				"source_port": "1024-65535",
+			},
+			sourcePort:      30000,
+			expectedMatched: true,
+		},
+		{
+			name:     "ports are ignored for ICMP",
+			protocol: "icmp",
+			rule: map[string]interface{}{
+				"ip_version":       float64(4),
+				"protocol":         "icmp",
+				"destination_port": "443",
+			},
+			expectedMatched: true,
+		},
+		{
+			name:        "ICMP between IPv6 addresses is only matched by icmpv6 rules",
+			protocol:    "icmp",
+			rule:        map[string]interface{}{"ip_version": float64(6), "protocol": "icmp"},
+			source:      v6Source,
+			destination: v6Dest,
+		},
+		{
+			name:            "ICMPv6 rule",
+			protocol:        "icmp",
+			rule:            map[string]interface{}{"ip_version": float64(6), "protocol": "icmpv6"},
+			source:          v6Source,
+			destination:     v6Dest,
+			expectedMatched: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		a := &pathAnalyzer{protocol: tc.protocol}
+		src, dest := source, destination
+		if tc.source != nil {
+			src, dest = tc.source, tc.destination
+		}
+		ipVersion := float64(4)
+		if dest.ip.To4() == nil {
+			ipVersion = 6
+		}
+
+		matched, uncertain := a.isNetworkAclRuleMatched(tc.rule, ipVersion, src, dest, tc.sourcePort, 80)
+		if matched != tc.expectedMatched || uncertain != tc.expectedUncertain {
+			t.Fatalf("[%s] The match result is not as expected, want %v/%v, but got %v/%v", tc.name,
+				tc.expectedMatched, tc.expectedUncertain, matched, uncertain)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/chnsz/golangsdk"
+	v3Groups "github.com/chnsz/golangsdk/openstack/networking/v3/security/groups"
+	v3Rules "github.com/chnsz/golangsdk/openstack/networking/v3/security/rules"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// The maximum number of rules which can be created in one batch-create request.
+const secGroupRuleBatchCreateLimit = 100
+
+// @API VPC GET /v3/{project_id}/vpc/security-groups/{security_group_id}
+// @API VPC GET /v3/{project_id}/vpc/security-group-rules
+// @API VPC POST /v3/{project_id}/vpc/security-groups/{security_group_id}/security-group-rules/batch-create
+// @API VPC DELETE /v3/{project_id}/vpc/security-group-rules/{rule_id}
+func ResourceNetworkingSecGroupRuleSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkingSecGroupRuleSetCreate,
+		ReadContext:   resourceNetworkingSecGroupRuleSetRead,
+		UpdateContext: resourceNetworkingSecGroupRuleSetUpdate,
+		DeleteContext: resourceNetworkingSecGroupRuleSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"security_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rules": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     secGroupRuleSetRuleSchema(),
+			},
+		},
+	}
+}
+
+func secGroupRuleSetRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"direction": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ingress", "egress"}, false),
+			},
+			"ethertype": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "IPv4",
+				ValidateFunc: validation.StringInSlice([]string{"IPv4", "IPv6"}, false),
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.Any(
+					validation.StringInSlice([]string{"tcp", "udp", "icmp", "icmpv6"}, false),
+					validation.StringMatch(regexp.MustCompile("^([0-1]?[0-9]?[0-9]|2[0-4][0-9]|25[0-5])$"),
+						"The valid protocol is range from 0 to 255.",
+					),
+				),
+			},
+			"ports": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"remote_ip_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: utils.ValidateCIDR,
+			},
+			"remote_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"remote_address_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "allow",
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// secGroupRuleKey returns a string which uniquely identifies the content of a security group rule, the rules with the
+// same key are treated as the same rule.
+func secGroupRuleKey(rule map[string]interface{}) string {
+	return strings.Join([]string{
+		rule["direction"].(string),
+		rule["ethertype"].(string),
+		rule["protocol"].(string),
+		rule["ports"].(string),
+		strings.ToLower(rule["remote_ip_prefix"].(string)),
+		rule["remote_group_id"].(string),
+		rule["remote_address_group_id"].(string),
+		rule["action"].(string),
+		fmt.Sprint(rule["priority"]),
+		rule["description"].(string),
+	}, "|")
+}
+
+func flattenSecGroupRuleSetRule(rule v3Rules.SecurityGroupRule) map[string]interface{} {
+	return map[string]interface{}{
+		"direction":               rule.Direction,
+		"ethertype":               rule.Ethertype,
+		"protocol":                rule.Protocol,
+		"ports":                   rule.MultiPort,
+		"remote_ip_prefix":        strings.ToLower(rule.RemoteIpPrefix),
+		"remote_group_id":         rule.RemoteGroupId,
+		"remote_address_group_id": rule.RemoteAddressGroupId,
+		"action":                  rule.Action,
+		"priority":                rule.Priority,
+		"description":             rule.Description,
+	}
+}
+
+func validateSecGroupRuleSetRules(rules []interface{}) error {
+	for _, v := range rules {
+		rule := v.(map[string]interface{})
+		remoteCount := 0
+		for _, k := range []string{"remote_ip_prefix", "remote_group_id", "remote_address_group_id"} {
+			if rule[k].(string) != "" {
+				remoteCount++
+			}
+		}
+		if remoteCount > 1 {
+			return fmt.Errorf("only one of remote_ip_prefix, remote_group_id and remote_address_group_id can be " +
+				"specified in a rule")
+		}
+		if rule["ports"].(string) != "" && rule["protocol"].(string) == "" {
+			return fmt.Errorf("the protocol must be specified if the ports is specified")
+		}
+	}
+	return nil
+}
+
+func batchCreateSecGroupRules(client *golangsdk.ServiceClient, securityGroupId string, rules []interface{}) error {
+	createHttpUrl := "v3/{project_id}/vpc/security-groups/{security_group_id}/security-group-rules/batch-create"
+	createPath := client.Endpoint + createHttpUrl
+	createPath = strings.ReplaceAll(createPath, "{project_id}", client.ProjectID)
+	createPath = strings.ReplaceAll(createPath, "{security_group_id}", securityGroupId)
+
+	for start := 0; start < len(rules); start += secGroupRuleBatchCreateLimit {
+		end := start + secGroupRuleBatchCreateLimit
+		if end > len(rules) {
+			end = len(rules)
+		}
+
+		ruleList := make([]map[string]interface{}, 0, end-start)
+		for _, v := range rules[start:end] {
+			rule := v.(map[string]interface{})
+			ruleList = append(ruleList, utils.RemoveNil(map[string]interface{}{
+				"direction":               rule["direction"],
+				"ethertype":               rule["ethertype"],
+				"protocol":                utils.ValueIgnoreEmpty(rule["protocol"]),
+				"multiport":               utils.ValueIgnoreEmpty(rule["ports"]),
+				"remote_ip_prefix":        utils.ValueIgnoreEmpty(rule["remote_ip_prefix"]),
+				"remote_group_id":         utils.ValueIgnoreEmpty(rule["remote_group_id"]),
+				"remote_address_group_id": utils.ValueIgnoreEmpty(rule["remote_address_group_id"]),
+				"action":                  rule["action"],
+				"priority":                rule["priority"],
+				"description":             utils.ValueIgnoreEmpty(rule["description"]),
+			}))
+		}
+
+		createOpt := golangsdk.RequestOpts{
+			KeepResponseBody: true,
+			JSONBody: map[string]interface{}{
+				"security_group_rules": ruleList,
+				"ignore_duplicate":     true,
+			},
+		}
+		_, err := client.Request("POST", createPath, &createOpt)
+		if err != nil {
+			return fmt.Errorf("error creating security group rules: %s", err)
+		}
+	}
+	return nil
+}
+
+// applySecGroupRuleSet creates the missing rules and deletes the rules which are not in the rule set, the rules with
+// the same content are kept as they are.
+func applySecGroupRuleSet(client *golangsdk.ServiceClient, securityGroupId string, rules []interface{}) error {
+	if err := validateSecGroupRuleSetRules(rules); err != nil {
+		return err
+	}
+
+	config.MutexKV.Lock(securityGroupId)
+	defer config.MutexKV.Unlock(securityGroupId)
+
+	remoteRules, err := v3Rules.List(client, v3Rules.ListOpts{SecurityGroupId: securityGroupId})
+	if err != nil {
+		return fmt.Errorf("error retrieving security group rules: %s", err)
+	}
+
+	expected := make(map[string]bool, len(rules))
+	for _, v := range rules {
+		expected[secGroupRuleKey(v.(map[string]interface{}))] = true
+	}
+
+	existing := make(map[string]bool, len(remoteRules))
+	var mErr *multierror.Error
+	for _, rule := range remoteRules {
+		key := secGroupRuleKey(flattenSecGroupRuleSetRule(rule))
+		if expected[key] && !existing[key] {
+			existing[key] = true
+			continue
+		}
+
+		// Both the rules removed from the configuration and the unmanaged rules are deleted.
+		log.Printf("[DEBUG] deleting security group rule (%s) which is not in the rule set", rule.ID)
+		err = v3Rules.Delete(client, rule.ID).ExtractErr()
+		if _, ok := err.(golangsdk.ErrDefault404); err != nil && !ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("error deleting security group rule (%s): %s", rule.ID, err))
+		}
+	}
+	if err = mErr.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	missingRules := make([]interface{}, 0)
+	for _, v := range rules {
+		if !existing[secGroupRuleKey(v.(map[string]interface{}))] {
+			missingRules = append(missingRules, v)
+		}
+	}
+	log.Printf("[DEBUG] %d rules of security group (%s) are kept, %d rules are going to be created",
+		len(existing), securityGroupId, len(missingRules))
+	return batchCreateSecGroupRules(client, securityGroupId, missingRules)
+}
+
+func resourceNetworkingSecGroupRuleSetCreate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NetworkingV3Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating networking v3 client: %s", err)
+	}
+
+	securityGroupId := d.Get("security_group_id").(string)
+	err = applySecGroupRuleSet(client, securityGroupId, d.Get("rules").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(securityGroupId)
+
+	return resourceNetworkingSecGroupRuleSetRead(ctx, d, meta)
+}
+
+func resourceNetworkingSecGroupRuleSetRead(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	region := cfg.GetRegion(d)
+	client, err := cfg.NetworkingV3Client(region)
+	if err != nil {
+		return diag.Errorf("error creating networking v3 client: %s", err)
+	}
+
+	securityGroupId := d.Id()
+	_, err = v3Groups.Get(client, securityGroupId)
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "error retrieving security group")
+	}
+
+	remoteRules, err := v3Rules.List(client, v3Rules.ListOpts{SecurityGroupId: securityGroupId})
+	if err != nil {
+		return diag.Errorf("error retrieving security group rules: %s", err)
+	}
+
+	// All rules of the security group are set, so the rules added out-of-band are shown as the drift.
+	rules := make([]interface{}, len(remoteRules))
+	for i, rule := range remoteRules {
+		rules[i] = flattenSecGroupRuleSetRule(rule)
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("security_group_id", securityGroupId),
+		d.Set("rules", rules),
+	)
+	if err = mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting security group rule set fields: %s", err)
+	}
+	return nil
+}
+
+func resourceNetworkingSecGroupRuleSetUpdate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NetworkingV3Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating networking v3 client: %s", err)
+	}
+
+	if d.HasChange("rules") {
+		err = applySecGroupRuleSet(client, d.Id(), d.Get("rules").(*schema.Set).List())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceNetworkingSecGroupRuleSetRead(ctx, d, meta)
+}
+
+func resourceNetworkingSecGroupRuleSetDelete(_ context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	cfg := meta.(*config.Config)
+	client, err := cfg.NetworkingV3Client(cfg.GetRegion(d))
+	if err != nil {
+		return diag.Errorf("error creating networking v3 client: %s", err)
+	}
+
+	// All rules of the security group are deleted, including the rules added out-of-band.
+	return diag.FromErr(applySecGroupRuleSet(client, d.Id(), nil))
+}
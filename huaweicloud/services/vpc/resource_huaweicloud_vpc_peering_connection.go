@@ -2,6 +2,7 @@ package vpc
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
@@ -22,6 +23,9 @@ import (
 // @API VPC GET /v2.0/vpc/peerings/{id}
 // @API VPC PUT /v2.0/vpc/peerings/{id}
 // @API VPC POST /v2.0/vpc/peerings
+// @API VPC PUT /v2.0/vpc/peerings/{id}/accept
+// @API IAM POST /v3.0/OS-CREDENTIAL/securitytokens
+// @API STS POST /v5/agencies/assume
 func ResourceVpcPeeringConnectionV2() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVPCPeeringCreate,
@@ -34,6 +38,7 @@ func ResourceVpcPeeringConnectionV2() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
@@ -70,10 +75,17 @@ func ResourceVpcPeeringConnectionV2() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"peer_assume_role": common.PeerAssumeRoleSchema(
+				"The agency of the peer account, which is used to accept the peering connection."),
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"peer_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the peering connection in the peer account.",
+			},
 		},
 	}
 }
@@ -123,9 +135,76 @@ func resourceVPCPeeringCreate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error creating VPC Peering Connection: %s", err)
 	}
 
+	if err := acceptVpcPeeringByPeer(ctx, d, cfg, peeringClient, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceVPCPeeringRead(ctx, d, meta)
 }
 
+// acceptVpcPeeringByPeer accepts the peering connection by the agency of the peer account and waits for the peering
+// connection to become active on both sides.
+func acceptVpcPeeringByPeer(ctx context.Context, d *schema.ResourceData, cfg *config.Config,
+	peeringClient *golangsdk.ServiceClient, timeout time.Duration) error {
+	peerCfg, err := common.GetPeerConfig(d, cfg)
+	if err != nil {
+		return err
+	}
+	if peerCfg == nil {
+		return nil
+	}
+
+	peerClient, err := peerCfg.NetworkingV2Client(cfg.GetRegion(d))
+	if err != nil {
+		return fmt.Errorf("error creating VPC Peering Connection client of the peer account: %s", err)
+	}
+
+	n, err := peerings.Get(peeringClient, d.Id()).Extract()
+	if err != nil {
+		return fmt.Errorf("error retrieving VPC Peering Connection: %s", err)
+	}
+	if n.Status == "PENDING_ACCEPTANCE" {
+		if _, err = peerings.Accept(peerClient, d.Id()).ExtractResult(); err != nil {
+			return fmt.Errorf("unable to accept VPC Peering Connection by the peer account: %s", err)
+		}
+
+		for _, client := range []*golangsdk.ServiceClient{peeringClient, peerClient} {
+			stateConf := &retry.StateChangeConf{
+				Pending:    []string{"PENDING"},
+				Target:     []string{"ACTIVE"},
+				Refresh:    waitForVpcPeeringConnStatus(client, d.Id(), "ACTIVE"),
+				Timeout:    timeout,
+				Delay:      5 * time.Second,
+				MinTimeout: 3 * time.Second,
+			}
+			if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+				return fmt.Errorf("error waiting for the VPC Peering Connection to become active: %s", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getVpcPeeringPeerStatus queries the status of the peering connection in the peer account by the agency of the peer
+// account, an empty status is returned if the peer_assume_role is not specified.
+func getVpcPeeringPeerStatus(d *schema.ResourceData, cfg *config.Config) (string, error) {
+	peerCfg, err := common.GetPeerConfig(d, cfg)
+	if err != nil || peerCfg == nil {
+		return "", err
+	}
+
+	peerClient, err := peerCfg.NetworkingV2Client(cfg.GetRegion(d))
+	if err != nil {
+		return "", fmt.Errorf("error creating VPC Peering Connection client of the peer account: %s", err)
+	}
+	peer, err := peerings.Get(peerClient, d.Id()).Extract()
+	if err != nil {
+		return "", fmt.Errorf("error retrieving VPC Peering Connection in the peer account: %s", err)
+	}
+	return peer.Status, nil
+}
+
 func resourceVPCPeeringRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cfg := meta.(*config.Config)
 	region := cfg.GetRegion(d)
@@ -149,11 +228,22 @@ func resourceVPCPeeringRead(_ context.Context, d *schema.ResourceData, meta inte
 		d.Set("peer_tenant_id", n.AcceptVpcInfo.TenantId),
 	)
 
+	var diags diag.Diagnostics
+	if peerStatus, err := getVpcPeeringPeerStatus(d, cfg); err != nil {
+		// The saved peer_status is kept if the agency of the peer account is unavailable.
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unable to refresh the peer status",
+			Detail:   fmt.Sprintf("The status of the VPC Peering Connection in the peer account is not refreshed: %s", err),
+		})
+	} else {
+		mErr = multierror.Append(mErr, d.Set("peer_status", peerStatus))
+	}
+
 	if err := mErr.ErrorOrNil(); err != nil {
 		return diag.Errorf("error setting VPC Peering Connection fields: %s", err)
 	}
-
-	return nil
+	return diags
 }
 
 func resourceVPCPeeringUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -174,6 +264,12 @@ func resourceVPCPeeringUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error updating VPC Peering Connection: %s", err)
 	}
 
+	if d.HasChange("peer_assume_role") {
+		if err := acceptVpcPeeringByPeer(ctx, d, cfg, peeringClient, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceVPCPeeringRead(ctx, d, meta)
 }
 
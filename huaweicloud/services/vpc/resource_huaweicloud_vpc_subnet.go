@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/common/tags"
@@ -131,15 +132,27 @@ func ResourceVpcSubnetV1() *schema.Resource {
 			},
 			"cidr": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
 				ValidateFunc: utils.ValidateCIDR,
+				ExactlyOneOf: []string{"cidr", "cidr_prefix_length"},
+				RequiredWith: []string{"gateway_ip"},
 			},
-			"gateway_ip": {
-				Type:         schema.TypeString,
-				Required:     true,
+			"cidr_prefix_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
 				ForceNew:     true,
-				ValidateFunc: utils.ValidateIP,
+				ValidateFunc: validation.IntBetween(8, 29),
+			},
+			// The gateway IP is required with the cidr, it is computed when the CIDR is allocated automatically.
+			"gateway_ip": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ValidateFunc:  utils.ValidateIP,
+				ConflictsWith: []string{"cidr_prefix_length"},
 			},
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -305,9 +318,19 @@ func resourceVpcSubnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 		DnsList:          buildSubnetDNSList(d, cfg, region),
 		ExtraDhcpOpts:    buildDhcpOpts(d, false),
 	}
-	log.Printf("[DEBUG] Create VPC subnet options: %#v", createOpts)
 
-	n, err := subnets.Create(subnetClient, createOpts).Extract()
+	var n *subnets.Subnet
+	if prefixLength, ok := d.GetOk("cidr_prefix_length"); ok {
+		// The lock is held until the subnet becomes ACTIVE, so that the next allocation can see the CIDR of it.
+		vpcId := createOpts.VPC_ID
+		config.MutexKV.Lock(vpcId)
+		defer config.MutexKV.Unlock(vpcId)
+
+		n, err = createVpcSubnetWithAllocatedCidr(cfg, region, subnetClient, createOpts, prefixLength.(int))
+	} else {
+		log.Printf("[DEBUG] Create VPC subnet options: %#v", createOpts)
+		n, err = subnets.Create(subnetClient, createOpts).Extract()
+	}
 	if err != nil {
 		return diag.Errorf("error creating VPC subnet: %s", err)
 	}
@@ -347,6 +370,41 @@ func resourceVpcSubnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 	return resourceVpcSubnetRead(ctx, d, cfg)
 }
 
+// createVpcSubnetWithAllocatedCidr allocates a free CIDR for the subnet and creates it. If the creation fails because
+// the CIDR is taken by others (e.g. another workspace) in the meantime, the allocation is retried.
+func createVpcSubnetWithAllocatedCidr(cfg *config.Config, region string, client *golangsdk.ServiceClient,
+	createOpts subnets.CreateOpts, prefixLength int) (*subnets.Subnet, error) {
+	var (
+		excluded    = make([]string, 0)
+		maxAttempts = 3
+		err         error
+	)
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		createOpts.CIDR, err = allocateSubnetCidr(cfg, region, createOpts.VPC_ID, prefixLength, excluded)
+		if err != nil {
+			return nil, err
+		}
+		createOpts.GatewayIP, err = getSubnetGatewayIp(createOpts.CIDR)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[DEBUG] Create VPC subnet options: %#v", createOpts)
+
+		n, createErr := subnets.Create(client, createOpts).Extract()
+		if createErr == nil {
+			return n, nil
+		}
+
+		usedCidrs, err := listVpcUsedCidrs(cfg, region, createOpts.VPC_ID)
+		if err != nil || !isCidrOverlapped(createOpts.CIDR, usedCidrs) {
+			return nil, createErr
+		}
+		log.Printf("[WARN] the CIDR %s has been taken by others, retry the allocation: %s", createOpts.CIDR, createErr)
+		excluded = append(excluded, createOpts.CIDR)
+	}
+	return nil, fmt.Errorf("unable to allocate a free CIDR after %d attempts", maxAttempts)
+}
+
 // GetVpcSubnetById is a method to obtain subnet informations from special region through subnet ID.
 func GetVpcSubnetById(cfg *config.Config, region, networkId string) (*subnets.Subnet, error) {
 	subnetClient, err := cfg.NetworkingV1Client(region)
@@ -0,0 +1,217 @@
+package vpc
+
+// This set of code handles the automatic CIDR allocation of the huaweicloud_vpc_subnet resource.
+//
+// When `cidr_prefix_length` is specified, the next free IPv4 block of the requested prefix length is picked from the
+// primary CIDR and then the secondary CIDRs of the VPC. The CIDRs of the existing subnets and the subnet CIDR
+// reservations are considered as used. The allocation and the subnet creation are serialized per VPC by the MutexKV,
+// so the parallel creations in one apply never pick the same block.
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/subnets"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// ipv4Block is a CIDR block represented by the first and the last address.
+type ipv4Block struct {
+	first uint32
+	last  uint32
+}
+
+func parseIPv4Block(cidr string) (*ipv4Block, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return nil, fmt.Errorf("%s is not an IPv4 CIDR", cidr)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	first := binary.BigEndian.Uint32(ip)
+	return &ipv4Block{
+		first: first,
+		last:  first + uint32((uint64(1)<<uint(bits-ones))-1),
+	}, nil
+}
+
+func (b *ipv4Block) overlaps(other *ipv4Block) bool {
+	return b.first <= other.last && other.first <= b.last
+}
+
+func formatIPv4(v uint32) string {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, v)
+	return ip.String()
+}
+
+// findFreeIPv4Block returns the first block of the prefix length inside the parent CIDR which does not overlap with
+// any of the used blocks, an empty string is returned if there is no free block.
+func findFreeIPv4Block(parentCidr string, prefixLength int, used []*ipv4Block) (string, error) {
+	parent, err := parseIPv4Block(parentCidr)
+	if err != nil {
+		return "", err
+	}
+	size := uint64(1) << uint(32-prefixLength)
+	if uint64(parent.last)-uint64(parent.first)+1 < size {
+		return "", nil
+	}
+
+	for candidate := uint64(parent.first); candidate+size-1 <= uint64(parent.last); {
+		block := &ipv4Block{first: uint32(candidate), last: uint32(candidate + size - 1)}
+		var conflict *ipv4Block
+		for _, u := range used {
+			if block.overlaps(u) {
+				conflict = u
+				break
+			}
+		}
+		if conflict == nil {
+			return fmt.Sprintf("%s/%d", formatIPv4(block.first), prefixLength), nil
+		}
+		// Skip to the first aligned block after the conflict one.
+		candidate = (uint64(conflict.last)/size + 1) * size
+	}
+	return "", nil
+}
+
+// listVpcUsedCidrs returns the CIDRs of the subnets and the subnet CIDR reservations in the VPC.
+func listVpcUsedCidrs(cfg *config.Config, region, vpcId string) ([]string, error) {
+	subnetClient, err := cfg.NetworkingV1Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating networking client: %s", err)
+	}
+	allSubnets, err := subnets.List(subnetClient, subnets.ListOpts{VPC_ID: vpcId})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving subnets of VPC (%s): %s", vpcId, err)
+	}
+
+	result := make([]string, 0, len(allSubnets))
+	for _, subnet := range allSubnets {
+		result = append(result, subnet.CIDR)
+	}
+
+	client, err := cfg.NewServiceClient("vpcv3", region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating VPC v3 client: %s", err)
+	}
+	listPath := client.ResourceBaseURL() + "vpc/virsubnet-cidr-reservations"
+	listOpt := golangsdk.RequestOpts{
+		KeepResponseBody: true,
+	}
+	marker := ""
+	for {
+		queryPath := fmt.Sprintf("%s?limit=2000&ip_version=4&enterprise_project_id=all_granted_eps", listPath)
+		if marker != "" {
+			queryPath = fmt.Sprintf("%s&marker=%s", queryPath, marker)
+		}
+		resp, err := client.Request("GET", queryPath, &listOpt)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving subnet CIDR reservations: %s", err)
+		}
+		respBody, err := utils.FlattenResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		expression := fmt.Sprintf("virsubnet_cidr_reservations[?vpc_id=='%s'].cidr", vpcId)
+		for _, cidr := range utils.PathSearch(expression, respBody, make([]interface{}, 0)).([]interface{}) {
+			result = append(result, cidr.(string))
+		}
+		marker = utils.PathSearch("page_info.next_marker", respBody, "").(string)
+		if marker == "" {
+			break
+		}
+	}
+	return result, nil
+}
+
+// listVpcCidrs returns the primary CIDR and the secondary CIDRs of the VPC.
+func listVpcCidrs(cfg *config.Config, region, vpcId string) ([]string, error) {
+	client, err := cfg.NewServiceClient("vpcv3", region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating VPC v3 client: %s", err)
+	}
+	resp, err := obtainV3VpcResp(client, vpcId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving VPC (%s) v3 detail: %s", vpcId, err)
+	}
+
+	result := []string{utils.PathSearch("vpc.cidr", resp, "").(string)}
+	for _, cidr := range utils.PathSearch("vpc.extend_cidrs", resp, make([]interface{}, 0)).([]interface{}) {
+		result = append(result, cidr.(string))
+	}
+	return result, nil
+}
+
+// allocateSubnetCidr picks the next free block of the prefix length in the VPC, the blocks in the excluded list are
+// considered as used. The caller should hold the lock of the VPC.
+func allocateSubnetCidr(cfg *config.Config, region, vpcId string, prefixLength int, excluded []string) (string, error) {
+	vpcCidrs, err := listVpcCidrs(cfg, region, vpcId)
+	if err != nil {
+		return "", err
+	}
+	usedCidrs, err := listVpcUsedCidrs(cfg, region, vpcId)
+	if err != nil {
+		return "", err
+	}
+
+	used := make([]*ipv4Block, 0, len(usedCidrs)+len(excluded))
+	for _, cidr := range append(usedCidrs, excluded...) {
+		block, err := parseIPv4Block(cidr)
+		if err != nil {
+			log.Printf("[WARN] ignore the invalid CIDR (%s) of VPC (%s): %s", cidr, vpcId, err)
+			continue
+		}
+		used = append(used, block)
+	}
+
+	for _, vpcCidr := range vpcCidrs {
+		if vpcCidr == "" {
+			continue
+		}
+		cidr, err := findFreeIPv4Block(vpcCidr, prefixLength, used)
+		if err != nil {
+			return "", fmt.Errorf("error parsing the CIDR (%s) of VPC (%s): %s", vpcCidr, vpcId, err)
+		}
+		if cidr != "" {
+			log.Printf("[DEBUG] the CIDR %s is allocated from %s of VPC (%s)", cidr, vpcCidr, vpcId)
+			return cidr, nil
+		}
+	}
+	return "", fmt.Errorf("no free /%d block is available in the CIDRs (%s) of VPC (%s)", prefixLength,
+		strings.Join(vpcCidrs, ", "), vpcId)
+}
+
+// isCidrOverlapped checks whether the CIDR overlaps with any of the CIDR list.
+func isCidrOverlapped(cidr string, cidrList []string) bool {
+	block, err := parseIPv4Block(cidr)
+	if err != nil {
+		return false
+	}
+	for _, v := range cidrList {
+		if other, err := parseIPv4Block(v); err == nil && block.overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// getSubnetGatewayIp returns the first usable address of the CIDR as the gateway.
+func getSubnetGatewayIp(cidr string) (string, error) {
+	block, err := parseIPv4Block(cidr)
+	if err != nil {
+		return "", err
+	}
+	return formatIPv4(block.first + 1), nil
+}
@@ -451,6 +451,36 @@ func RandomString(n int, allowedChars ...[]rune) (result string) {
 	return
 }
 
+// GenerateStrongPassword generates a random password which contains at least one uppercase letter, one lowercase
+// letter, one digit and one special character, the special characters are accepted by most database services.
+// Note: make sure the length is not less than 4.
+func GenerateStrongPassword(length int) string {
+	charGroups := []string{
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+		"abcdefghijklmnopqrstuvwxyz",
+		"0123456789",
+		"~!@#^*-_=+?",
+	}
+
+	password := make([]rune, 0, length)
+	for _, chars := range charGroups {
+		password = append(password, []rune(RandomString(1, []rune(chars)))...)
+	}
+	password = append(password, []rune(RandomString(length-len(password), []rune(strings.Join(charGroups, ""))))...)
+
+	// Shuffle the password to avoid the fixed positions of the character groups.
+	for i := len(password) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			log.Printf("[ERROR] failed to shuffle the password: %s", err)
+			continue
+		}
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password)
+}
+
 // PathSearch evaluates a JMESPath expression against input data and returns the result.
 func PathSearch(expression string, obj interface{}, defaultValue interface{}) interface{} {
 	v, err := jmespath.Search(expression, obj)
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -177,3 +178,29 @@ func TestAccFunction_FilterMapWithSameKey(t *testing.T) {
 		t.Logf("The processing result of `FilterMapWithSameKey` method meets expectation: %s", Green(expectedMap))
 	}
 }
+
+func TestAccFunction_GenerateStrongPassword(t *testing.T) {
+	charGroups := []string{
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+		"abcdefghijklmnopqrstuvwxyz",
+		"0123456789",
+		"~!@#^*-_=+?",
+	}
+
+	for _, length := range []int{8, 16, 32} {
+		password := GenerateStrongPassword(length)
+		if len(password) != length {
+			t.Fatalf("The length of the generated password is not as expected, want %s, but got %s",
+				Green(length), Yellow(len(password)))
+		}
+		for _, chars := range charGroups {
+			if !strings.ContainsAny(password, chars) {
+				t.Fatalf("The generated password does not contain any character of %s", Yellow(chars))
+			}
+		}
+		if strings.Trim(password, strings.Join(charGroups, "")) != "" {
+			t.Fatalf("The generated password contains unexpected characters: %s", Yellow(password))
+		}
+		t.Logf("The generated password meets expectation, the length is %s", Green(length))
+	}
+}